@@ -0,0 +1,30 @@
+terraform {
+  required_providers {
+    crowdstrike = {
+      source = "registry.terraform.io/crowdstrike/crowdstrike"
+    }
+  }
+}
+
+provider "crowdstrike" {
+  cloud = "us-2"
+}
+
+variable "image_tag" {
+  type = string
+}
+
+data "crowdstrike_container_images" "release" {
+  filter = "registry:'registry.example.com'+repository:'payments/api'+tag:'${var.image_tag}'"
+}
+
+# Fail the promotion when the image has critical vulnerabilities.
+check "no_critical_vulnerabilities" {
+  assert {
+    condition = alltrue([
+      for image in data.crowdstrike_container_images.release.images :
+      image.highest_vulnerability_severity != "CRITICAL"
+    ])
+    error_message = "The release image has critical vulnerabilities."
+  }
+}
@@ -0,0 +1,24 @@
+terraform {
+  required_providers {
+    crowdstrike = {
+      source = "registry.terraform.io/crowdstrike/crowdstrike"
+    }
+  }
+}
+
+provider "crowdstrike" {
+  cloud = "us-2"
+}
+
+data "crowdstrike_custom_ioa_rule_groups" "example" {
+  name     = "example rule group"
+  platform = "windows"
+}
+
+resource "crowdstrike_prevention_policy_windows" "example" {
+  name            = "example_prevention_policy"
+  enabled         = true
+  description     = "made with terraform"
+  host_groups     = []
+  ioa_rule_groups = data.crowdstrike_custom_ioa_rule_groups.example.rule_groups[*].id
+}
@@ -0,0 +1,28 @@
+terraform {
+  required_providers {
+    crowdstrike = {
+      source = "registry.terraform.io/crowdstrike/crowdstrike"
+    }
+  }
+}
+
+provider "crowdstrike" {
+  cloud = "us-2"
+}
+
+data "crowdstrike_filevantage_policies" "windows" {
+  platform_name = "Windows"
+}
+
+data "crowdstrike_filevantage_policies" "servers" {
+  platform_name = "Linux"
+  name          = "Linux Servers"
+}
+
+output "windows_policy_ids" {
+  value = data.crowdstrike_filevantage_policies.windows.policies[*].id
+}
+
+output "linux_servers_rule_groups" {
+  value = one(data.crowdstrike_filevantage_policies.servers.policies[*].rule_groups)
+}
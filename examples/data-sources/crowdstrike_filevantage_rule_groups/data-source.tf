@@ -0,0 +1,28 @@
+terraform {
+  required_providers {
+    crowdstrike = {
+      source = "registry.terraform.io/crowdstrike/crowdstrike"
+    }
+  }
+}
+
+provider "crowdstrike" {
+  cloud = "us-2"
+}
+
+data "crowdstrike_filevantage_rule_groups" "registry" {
+  type = "WindowsRegistry"
+}
+
+data "crowdstrike_filevantage_rule_groups" "baseline" {
+  type = "WindowsFiles"
+  name = "Windows Baseline"
+}
+
+output "registry_rule_group_ids" {
+  value = data.crowdstrike_filevantage_rule_groups.registry.rule_groups[*].id
+}
+
+output "baseline_rule_group_id" {
+  value = one(data.crowdstrike_filevantage_rule_groups.baseline.rule_groups[*].id)
+}
@@ -0,0 +1,19 @@
+terraform {
+  required_providers {
+    crowdstrike = {
+      source = "registry.terraform.io/crowdstrike/crowdstrike"
+    }
+  }
+}
+
+provider "crowdstrike" {
+  cloud = "us-2"
+}
+
+data "crowdstrike_firewall_policies" "windows" {
+  filter = "platform_name:'Windows'+enabled:true"
+}
+
+output "windows_policy_ids" {
+  value = data.crowdstrike_firewall_policies.windows.policies[*].id
+}
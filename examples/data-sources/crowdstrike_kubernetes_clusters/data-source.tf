@@ -0,0 +1,19 @@
+terraform {
+  required_providers {
+    crowdstrike = {
+      source = "registry.terraform.io/crowdstrike/crowdstrike"
+    }
+  }
+}
+
+provider "crowdstrike" {
+  cloud = "us-2"
+}
+
+data "crowdstrike_kubernetes_clusters" "aws" {
+  filter = "cloud_name:'aws'+agent_status:'Online'"
+}
+
+output "aws_cluster_ids" {
+  value = data.crowdstrike_kubernetes_clusters.aws.clusters[*].cluster_id
+}
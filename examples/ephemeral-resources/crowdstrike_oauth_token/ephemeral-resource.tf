@@ -0,0 +1,24 @@
+terraform {
+  required_providers {
+    crowdstrike = {
+      source = "registry.terraform.io/crowdstrike/crowdstrike"
+    }
+    restapi = {
+      source = "Mastercard/restapi"
+    }
+  }
+}
+
+provider "crowdstrike" {
+  cloud = "us-2"
+}
+
+ephemeral "crowdstrike_oauth_token" "token" {}
+
+# the token is never written to state or plan
+provider "restapi" {
+  uri = ephemeral.crowdstrike_oauth_token.token.base_url
+  headers = {
+    Authorization = "Bearer ${ephemeral.crowdstrike_oauth_token.token.access_token}"
+  }
+}
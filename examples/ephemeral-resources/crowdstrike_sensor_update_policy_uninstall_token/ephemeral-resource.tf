@@ -0,0 +1,22 @@
+terraform {
+  required_providers {
+    crowdstrike = {
+      source = "registry.terraform.io/crowdstrike/crowdstrike"
+    }
+  }
+}
+
+provider "crowdstrike" {
+  cloud = "us-2"
+}
+
+# bulk maintenance token for all hosts
+ephemeral "crowdstrike_sensor_update_policy_uninstall_token" "maintenance" {
+  audit_message = "decommissioning automation"
+}
+
+# uninstall token for a single host
+ephemeral "crowdstrike_sensor_update_policy_uninstall_token" "host" {
+  device_id     = "0123456789abcdef0123456789abcdef"
+  audit_message = "decommissioning automation"
+}
@@ -0,0 +1,7 @@
+# name:'production'+platform_name:['Linux','Mac']
+output "filter" {
+  value = provider::crowdstrike::fql({
+    name          = "production"
+    platform_name = ["Linux", "Mac"]
+  })
+}
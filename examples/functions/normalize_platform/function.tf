@@ -0,0 +1,4 @@
+# "Windows"
+output "platform" {
+  value = provider::crowdstrike::normalize_platform("WIN")
+}
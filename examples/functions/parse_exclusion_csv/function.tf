@@ -0,0 +1,5 @@
+# value,comment,host_groups
+# /opt/legacy-av/**,Migrated from the legacy AV,a1b2c3;d4e5f6
+resource "crowdstrike_sensor_visibility_exclusions" "legacy" {
+  exclusions = provider::crowdstrike::parse_exclusion_csv(file("${path.module}/exclusions.csv"))
+}
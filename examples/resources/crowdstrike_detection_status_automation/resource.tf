@@ -0,0 +1,23 @@
+terraform {
+  required_providers {
+    crowdstrike = {
+      source = "registry.terraform.io/crowdstrike/crowdstrike"
+    }
+  }
+}
+
+provider "crowdstrike" {
+  cloud = "us-2"
+}
+
+# Close low severity alerts from the lab hosts and tag them for review.
+resource "crowdstrike_detection_status_automation" "lab" {
+  filter  = "product:'epp'+severity:<30+device.hostname:*'lab-*'"
+  status  = "closed"
+  tags    = ["auto-triaged", "lab"]
+  comment = "Closed by Terraform triage automation."
+}
+
+output "lab_pending_alerts" {
+  value = crowdstrike_detection_status_automation.lab.pending_alert_count
+}
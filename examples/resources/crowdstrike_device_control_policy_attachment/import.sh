@@ -0,0 +1,5 @@
+# device control policy attachments can be imported by specifying the policy id. every host group attached to the policy is imported.
+terraform import crowdstrike_device_control_policy_attachment.example 7fb858a949034a0cbca175f660f1e769
+
+# device control policy attachment in a child CID set with member_cid can be imported by prefixing the id with the member cid.
+terraform import crowdstrike_device_control_policy_attachment.example "1be6a4b7c8d94e0f9a2b3c4d5e6f7a8b/7fb858a949034a0cbca175f660f1e769"
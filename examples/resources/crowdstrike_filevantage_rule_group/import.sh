@@ -1,3 +1,13 @@
 # filevantage rule group can be imported by specifying the policy id.
 terraform import crowdstrike_filevantage_rule_group.example 7fb858a949034a0cbca175f660f1e769
 
+# filevantage rule group in a child CID set with member_cid can be imported by prefixing the id with the member cid.
+terraform import crowdstrike_filevantage_rule_group.example "1be6a4b7c8d94e0f9a2b3c4d5e6f7a8b/7fb858a949034a0cbca175f660f1e769"
+
+# with terraform 1.12 and later an import block can use the resource identity instead.
+# import {
+#   to = crowdstrike_filevantage_rule_group.example
+#   identity = {
+#     id = "7fb858a949034a0cbca175f660f1e769"
+#   }
+# }
@@ -0,0 +1,21 @@
+terraform {
+  required_providers {
+    crowdstrike = {
+      source = "registry.terraform.io/crowdstrike/crowdstrike"
+    }
+  }
+}
+
+provider "crowdstrike" {
+  cloud = "us-2"
+}
+
+# attach host groups owned by this configuration to a firewall policy managed elsewhere.
+resource "crowdstrike_firewall_policy_attachment" "example" {
+  policy_id   = "7fb858a949034a0cbca175f660f1e769"
+  host_groups = ["d6e3c1e1b3d0467da0fowc96a5e6ecb5"]
+}
+
+output "firewall_policy_attachment" {
+  value = crowdstrike_firewall_policy_attachment.example
+}
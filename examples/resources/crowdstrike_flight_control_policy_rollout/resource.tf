@@ -0,0 +1,32 @@
+terraform {
+  required_providers {
+    crowdstrike = {
+      source = "registry.terraform.io/crowdstrike/crowdstrike"
+    }
+  }
+}
+
+# the provider authenticates with an API client of the Flight Control parent CID.
+provider "crowdstrike" {
+  cloud = "us-2"
+}
+
+# Roll out the same sensor update policy to every child CID.
+resource "crowdstrike_flight_control_policy_rollout" "windows_n1" {
+  member_cids = [
+    "1be6a4b7c8d94e0f9a2b3c4d5e6f7a8b",
+    "2cf7b5c8d9ea4f1a0b3c4d5e6f7a8b9c",
+  ]
+
+  sensor_update_policy = {
+    name                 = "windows n-1"
+    description          = "managed by terraform"
+    platform_name        = "Windows"
+    build                = "18110"
+    uninstall_protection = true
+  }
+}
+
+output "rollout_policies" {
+  value = crowdstrike_flight_control_policy_rollout.windows_n1.policies
+}
@@ -0,0 +1,24 @@
+terraform {
+  required_providers {
+    crowdstrike = {
+      source = "registry.terraform.io/crowdstrike/crowdstrike"
+    }
+  }
+}
+
+provider "crowdstrike" {
+  cloud = "us-2"
+}
+
+# contain hosts during an incident, set desired_state to normal to lift containment.
+resource "crowdstrike_host_network_containment" "incident" {
+  device_ids = [
+    "0123456789abcdef0123456789abcdef",
+    "fedcba9876543210fedcba9876543210",
+  ]
+  desired_state = "contained"
+}
+
+output "host_network_containment" {
+  value = crowdstrike_host_network_containment.incident
+}
@@ -1,3 +1,16 @@
 # prevention policy can be imported by specifying the policy id.
 terraform import crowdstrike_prevention_policy_linux.example 7fb858a949034a0cbca175f660f1e769
 
+# prevention policy can also be imported by name. the import fails if more than one prevention policy has the name.
+terraform import crowdstrike_prevention_policy_linux.example "name:example"
+
+# prevention policy in a child CID set with member_cid can be imported by prefixing the id or name with the member cid.
+terraform import crowdstrike_prevention_policy_linux.example "1be6a4b7c8d94e0f9a2b3c4d5e6f7a8b/7fb858a949034a0cbca175f660f1e769"
+
+# with terraform 1.12 and later an import block can use the resource identity instead.
+# import {
+#   to = crowdstrike_prevention_policy_linux.example
+#   identity = {
+#     id = "7fb858a949034a0cbca175f660f1e769"
+#   }
+# }
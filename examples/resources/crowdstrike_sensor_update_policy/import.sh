@@ -1,3 +1,16 @@
 # prevention policy can be imported by specifying the policy id.
 terraform import crowdstrike_sensor_update_policy.example 7fb858a949034a0cbca175f660f1e769
 
+# sensor update policy can also be imported by name. the import fails if more than one sensor update policy has the name.
+terraform import crowdstrike_sensor_update_policy.example "name:example"
+
+# sensor update policy in a child CID set with member_cid can be imported by prefixing the id or name with the member cid.
+terraform import crowdstrike_sensor_update_policy.example "1be6a4b7c8d94e0f9a2b3c4d5e6f7a8b/7fb858a949034a0cbca175f660f1e769"
+
+# with terraform 1.12 and later an import block can use the resource identity instead.
+# import {
+#   to = crowdstrike_sensor_update_policy.example
+#   identity = {
+#     id = "7fb858a949034a0cbca175f660f1e769"
+#   }
+# }
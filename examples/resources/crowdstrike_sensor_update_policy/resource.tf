@@ -32,6 +32,19 @@ resource "crowdstrike_sensor_update_policy" "example" {
   }
 }
 
+resource "crowdstrike_sensor_update_policy" "linux" {
+  name          = "example_linux_sensor_update_policy"
+  enabled       = false
+  description   = "made with terraform"
+  platform_name = "Linux"
+  build         = "18110"
+  build_arm64   = "18110"
+  build_zlinux  = "18110"
+  schedule = {
+    enabled = false
+  }
+}
+
 output "sensor_policy" {
   value = crowdstrike_sensor_update_policy.example
 }
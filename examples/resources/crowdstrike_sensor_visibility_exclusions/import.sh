@@ -0,0 +1,10 @@
+# sensor visibility exclusions can be imported by specifying a comma separated list of exclusion ids.
+terraform import crowdstrike_sensor_visibility_exclusions.example 7fb858a949034a0cbca175f660f1e769,1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d
+
+# with terraform 1.12 and later an import block can use the resource identity instead.
+# import {
+#   to = crowdstrike_sensor_visibility_exclusions.example
+#   identity = {
+#     exclusion_ids = ["1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d", "7fb858a949034a0cbca175f660f1e769"]
+#   }
+# }
@@ -0,0 +1,36 @@
+terraform {
+  required_providers {
+    crowdstrike = {
+      source = "registry.terraform.io/crowdstrike/crowdstrike"
+    }
+  }
+}
+
+provider "crowdstrike" {
+  cloud = "us-2"
+}
+
+resource "crowdstrike_host_group" "example" {
+  name            = "example_host_group"
+  description     = "made with terraform"
+  type            = "dynamic"
+  assignment_rule = "tags:'SensorGroupingTags/cloud-lab'+os_version:'Amazon Linux 2'"
+}
+
+resource "crowdstrike_sensor_visibility_exclusions" "example" {
+  exclusions = [
+    {
+      value   = "/opt/build/**"
+      comment = "build artifacts"
+    },
+    {
+      value       = "/var/lib/scanner/*.tmp"
+      comment     = "scanner scratch files"
+      host_groups = [crowdstrike_host_group.example.id]
+    },
+  ]
+}
+
+output "sensor_visibility_exclusions" {
+  value = crowdstrike_sensor_visibility_exclusions.example
+}
@@ -0,0 +1,31 @@
+terraform {
+  required_providers {
+    crowdstrike = {
+      source = "registry.terraform.io/crowdstrike/crowdstrike"
+    }
+  }
+}
+
+provider "crowdstrike" {
+  cloud = "us-2"
+}
+
+# scan instances once, changing instance_ids starts a new scan.
+resource "crowdstrike_snapshot_scan_job" "incident" {
+  cloud_provider = "aws"
+  account_id     = "123456789012"
+  region         = "us-east-1"
+  instance_ids = [
+    "i-0123456789abcdef0",
+    "i-0fedcba9876543210",
+  ]
+  wait_for_completion = true
+
+  timeouts {
+    create = "1h"
+  }
+}
+
+output "snapshot_scan_statuses" {
+  value = crowdstrike_snapshot_scan_job.incident.statuses
+}
@@ -0,0 +1,2 @@
+# workflow schedules can be imported by specifying the workflow id.
+terraform import crowdstrike_workflow_schedule.example 7fb858a949034a0cbca175f660f1e769
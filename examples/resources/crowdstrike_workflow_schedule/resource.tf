@@ -0,0 +1,24 @@
+terraform {
+  required_providers {
+    crowdstrike = {
+      source = "registry.terraform.io/crowdstrike/crowdstrike"
+    }
+  }
+}
+
+provider "crowdstrike" {
+  cloud = "us-2"
+}
+
+variable "environment" {
+  type    = string
+  default = "production"
+}
+
+# Run the nightly hygiene workflow every night in production and only on weekdays elsewhere.
+resource "crowdstrike_workflow_schedule" "nightly_hygiene" {
+  workflow_id = "7fb858a949034a0cbca175f660f1e769"
+  cron        = var.environment == "production" ? "0 2 * * *" : "0 2 * * 1-5"
+  timezone    = "America/Chicago"
+  enabled     = true
+}
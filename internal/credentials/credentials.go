@@ -0,0 +1,118 @@
+package credentials
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultProfile is the profile used when no profile is configured.
+const DefaultProfile = "default"
+
+// ErrProfileNotFound is returned when the requested profile is missing from the credentials file.
+var ErrProfileNotFound = errors.New("profile not found in credentials file")
+
+// Profile holds the CrowdStrike API settings for a named profile in a shared credentials file.
+type Profile struct {
+	ClientId     string
+	ClientSecret string
+	AccessToken  string
+	Cloud        string
+}
+
+// DefaultFilePath returns the default location of the shared credentials file (~/.crowdstrike/credentials).
+func DefaultFilePath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, ".crowdstrike", "credentials"), nil
+}
+
+// LoadProfile reads the shared credentials file at path and returns the named profile.
+func LoadProfile(path, profile string) (Profile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Profile{}, err
+	}
+	defer f.Close()
+
+	return ParseProfile(f, profile)
+}
+
+// ParseProfile parses an ini formatted credentials file and returns the named profile.
+//
+// Example:
+//
+//	[default]
+//	client_id     = xxxxxxxx
+//	client_secret = xxxxxxxx
+//	cloud         = us-2
+func ParseProfile(r io.Reader, profile string) (Profile, error) {
+	var p Profile
+	var found bool
+	var current string
+
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return Profile{}, fmt.Errorf("line %d: invalid profile header %q", lineNumber, line)
+			}
+			current = strings.TrimSpace(line[1 : len(line)-1])
+			if current == profile {
+				found = true
+			}
+			continue
+		}
+
+		if current != profile {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return Profile{}, fmt.Errorf("line %d: expected key = value", lineNumber)
+		}
+
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.Trim(strings.TrimSpace(value), `"'`)
+
+		switch key {
+		case "client_id":
+			p.ClientId = value
+		case "client_secret":
+			p.ClientSecret = value
+		case "access_token":
+			p.AccessToken = value
+		case "cloud":
+			p.Cloud = value
+		default:
+			return Profile{}, fmt.Errorf("line %d: unsupported key %q", lineNumber, key)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return Profile{}, err
+	}
+
+	if !found {
+		return Profile{}, fmt.Errorf("%w: %s", ErrProfileNotFound, profile)
+	}
+
+	return p, nil
+}
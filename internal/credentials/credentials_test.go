@@ -0,0 +1,99 @@
+package credentials
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const testFile = `
+# shared credentials
+[default]
+client_id     = default-id
+client_secret = default-secret
+
+[ci]
+client_id     = "ci-id"
+client_secret = 'ci-secret'
+cloud         = us-2
+
+[token]
+access_token = my-token
+cloud        = eu-1
+`
+
+func TestParseProfile(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		profile  string
+		expected Profile
+		wantErr  bool
+	}{
+		{
+			name:     "default",
+			input:    testFile,
+			profile:  DefaultProfile,
+			expected: Profile{ClientId: "default-id", ClientSecret: "default-secret"},
+		},
+		{
+			name:     "quoted values",
+			input:    testFile,
+			profile:  "ci",
+			expected: Profile{ClientId: "ci-id", ClientSecret: "ci-secret", Cloud: "us-2"},
+		},
+		{
+			name:     "access token",
+			input:    testFile,
+			profile:  "token",
+			expected: Profile{AccessToken: "my-token", Cloud: "eu-1"},
+		},
+		{
+			name:    "missing profile",
+			input:   testFile,
+			profile: "missing",
+			wantErr: true,
+		},
+		{
+			name:    "unsupported key",
+			input:   "[default]\nregion = us-1\n",
+			profile: DefaultProfile,
+			wantErr: true,
+		},
+		{
+			name:    "invalid header",
+			input:   "[default\nclient_id = a\n",
+			profile: DefaultProfile,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := ParseProfile(strings.NewReader(tt.input), tt.profile)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseProfile() error = nil, want error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ParseProfile() error = %v, want no error", err)
+			}
+
+			if p != tt.expected {
+				t.Errorf("ParseProfile() = %+v, want %+v", p, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseProfileNotFound(t *testing.T) {
+	_, err := ParseProfile(strings.NewReader(testFile), "missing")
+
+	if !errors.Is(err, ErrProfileNotFound) {
+		t.Errorf("ParseProfile() error = %v, want ErrProfileNotFound", err)
+	}
+}
@@ -0,0 +1,505 @@
+package detectionstatusautomation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/client/alerts"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/scopes"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/tferrors"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &detectionStatusAutomationResource{}
+	_ resource.ResourceWithConfigure      = &detectionStatusAutomationResource{}
+	_ resource.ResourceWithValidateConfig = &detectionStatusAutomationResource{}
+)
+
+// NewDetectionStatusAutomationResource is a helper function to simplify the provider implementation.
+func NewDetectionStatusAutomationResource() resource.Resource {
+	return &detectionStatusAutomationResource{}
+}
+
+// detectionStatusAutomationResource is the resource implementation.
+type detectionStatusAutomationResource struct {
+	client *client.CrowdStrikeAPISpecification
+}
+
+// detectionStatusAutomationResourceModel is the resource model.
+type detectionStatusAutomationResourceModel struct {
+	ID                types.String   `tfsdk:"id"`
+	Filter            types.String   `tfsdk:"filter"`
+	Status            types.String   `tfsdk:"status"`
+	AssignedToUUID    types.String   `tfsdk:"assigned_to_uuid"`
+	Tags              types.Set      `tfsdk:"tags"`
+	Comment           types.String   `tfsdk:"comment"`
+	PendingAlertCount types.Int64    `tfsdk:"pending_alert_count"`
+	LastUpdated       types.String   `tfsdk:"last_updated"`
+	Timeouts          timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *detectionStatusAutomationResource) Configure(
+	ctx context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.CrowdStrikeAPISpecification)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf(
+				"Expected *client.CrowdStrikeAPISpecification, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Metadata returns the resource type name.
+func (r *detectionStatusAutomationResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_detection_status_automation"
+}
+
+// Schema defines the schema for the resource.
+func (r *detectionStatusAutomationResource) Schema(
+	ctx context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: fmt.Sprintf(
+			"Alerts --- This resource triages the alerts matching an FQL filter by setting their status, assignee, and tags. Alerts that match the filter but are not triaged yet are counted on refresh and triaged on the next apply, so new alerts are only handled when Terraform runs. Destroying the resource leaves the alerts as they are.\n\n%s",
+			scopes.GenerateScopeDescription(apiScopes),
+		),
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Identifier for the automation.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"filter": schema.StringAttribute{
+				Required:    true,
+				Description: "FQL filter selecting the alerts to triage, e.g. product:'epp'+severity:<30.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"status": schema.StringAttribute{
+				Optional:    true,
+				Description: "Status to set on the matching alerts. (new, in_progress, closed, reopened)",
+				Validators: []validator.String{
+					stringvalidator.OneOf("new", "in_progress", "closed", "reopened"),
+				},
+			},
+			"assigned_to_uuid": schema.StringAttribute{
+				Optional:    true,
+				Description: "UUID of the user to assign the matching alerts to.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"tags": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Tags to add to the matching alerts. Existing tags are kept.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"comment": schema.StringAttribute{
+				Optional:    true,
+				Description: "Comment appended to each alert when it is triaged.",
+			},
+			"pending_alert_count": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Number of alerts matching the filter that are not triaged yet. A value other than 0 after refresh causes the next apply to triage them.",
+				PlanModifiers: []planmodifier.Int64{
+					pendingAlertsPlanModifier{},
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:    true,
+				Description: "Timestamp of the last Terraform update of the resource.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *detectionStatusAutomationResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan detectionStatusAutomationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	id, err := utils.NewRandomID()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating detection status automation",
+			"Could not generate resource id: "+err.Error(),
+		)
+		return
+	}
+	plan.ID = types.StringValue(id)
+
+	resp.Diagnostics.Append(r.reconcile(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *detectionStatusAutomationResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state detectionStatusAutomationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	filter, diags := pendingFilter(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, total, diags := r.queryAlerts(ctx, filter, 1, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.PendingAlertCount = types.Int64Value(total)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *detectionStatusAutomationResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var plan detectionStatusAutomationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(r.reconcile(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete removes the resource from the Terraform state, triaged alerts are left unchanged.
+func (r *detectionStatusAutomationResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+}
+
+// ValidateConfig runs during validate, plan, and apply
+// validate resource is configured as expected.
+func (r *detectionStatusAutomationResource) ValidateConfig(
+	ctx context.Context,
+	req resource.ValidateConfigRequest,
+	resp *resource.ValidateConfigResponse,
+) {
+	var config detectionStatusAutomationResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Status.IsNull() && config.AssignedToUUID.IsNull() && config.Tags.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("status"),
+			"Missing triage action",
+			"At least one of status, assigned_to_uuid, or tags must be set for alerts to be triaged.",
+		)
+	}
+}
+
+// reconcile applies the triage actions to every alert matching the filter that is not triaged
+// yet. The pending alert count and last updated are set on plan.
+func (r *detectionStatusAutomationResource) reconcile(
+	ctx context.Context,
+	plan *detectionStatusAutomationResourceModel,
+) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	filter, filterDiags := pendingFilter(ctx, *plan)
+	diags.Append(filterDiags...)
+	if diags.HasError() {
+		return diags
+	}
+
+	// Collect every pending alert before updating any of them, updated alerts no longer
+	// match the filter which would shift the pages.
+	var ids []string
+	var offset int64
+	for {
+		page, total, queryDiags := r.queryAlerts(ctx, filter, queryLimit, offset)
+		diags.Append(queryDiags...)
+		if diags.HasError() {
+			return diags
+		}
+
+		ids = append(ids, page...)
+		offset += int64(len(page))
+
+		if len(page) == 0 || offset >= total {
+			break
+		}
+	}
+
+	actions, actionDiags := actionParameters(ctx, *plan)
+	diags.Append(actionDiags...)
+	if diags.HasError() {
+		return diags
+	}
+
+	for i := 0; i < len(ids); i += batchSize {
+		batch := ids[i:min(i+batchSize, len(ids))]
+
+		res, err := r.client.Alerts.UpdateV3(&alerts.UpdateV3Params{
+			Context: ctx,
+			Body: &models.DetectsapiPatchEntitiesAlertsV3Request{
+				ActionParameters: actions,
+				CompositeIds:     batch,
+			},
+		})
+
+		if err != nil {
+			diags.AddError(
+				"Error triaging alerts",
+				fmt.Sprintf(
+					"Could not update %d alerts matching %s: %s",
+					len(batch),
+					plan.Filter.ValueString(),
+					tferrors.Message(err, apiScopes...),
+				),
+			)
+			return diags
+		}
+
+		if res != nil && res.Payload != nil {
+			for _, apiErr := range res.Payload.Errors {
+				diags.AddError(
+					"Error triaging alerts",
+					fmt.Sprintf("Could not update alert %s: %s", apiErr.ID, apiErr.String()),
+				)
+			}
+		}
+	}
+
+	if diags.HasError() {
+		return diags
+	}
+
+	plan.PendingAlertCount = types.Int64Value(0)
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	return diags
+}
+
+// queryAlerts returns a page of composite ids of the alerts matching filter and the total
+// number of matching alerts.
+func (r *detectionStatusAutomationResource) queryAlerts(
+	ctx context.Context,
+	filter string,
+	limit, offset int64,
+) ([]string, int64, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	res, err := r.client.Alerts.QueryV2(&alerts.QueryV2Params{
+		Context: ctx,
+		Filter:  &filter,
+		Limit:   &limit,
+		Offset:  &offset,
+	})
+
+	if err != nil {
+		diags.AddError(
+			"Error reading alerts",
+			fmt.Sprintf("Could not query alerts matching %s: %s", filter, tferrors.Message(err, apiScopes...)),
+		)
+		return nil, 0, diags
+	}
+
+	total := int64(len(res.Payload.Resources))
+	if res.Payload.Meta != nil && res.Payload.Meta.Pagination != nil &&
+		res.Payload.Meta.Pagination.Total != nil {
+		total = *res.Payload.Meta.Pagination.Total
+	}
+
+	return res.Payload.Resources, total, diags
+}
+
+// pendingFilter returns an FQL filter matching the alerts selected by the filter that are
+// missing at least one of the configured triage values.
+func pendingFilter(
+	ctx context.Context,
+	m detectionStatusAutomationResourceModel,
+) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var conditions []string
+
+	if !m.Status.IsNull() {
+		conditions = append(conditions, "status:!"+utils.QuoteFQL(m.Status.ValueString()))
+	}
+
+	if !m.AssignedToUUID.IsNull() {
+		conditions = append(conditions, "assigned_to_uuid:!"+utils.QuoteFQL(m.AssignedToUUID.ValueString()))
+	}
+
+	var tags []string
+	diags.Append(m.Tags.ElementsAs(ctx, &tags, true)...)
+	for _, tag := range tags {
+		conditions = append(conditions, "tags:!"+utils.QuoteFQL(tag))
+	}
+
+	return fmt.Sprintf("(%s)+(%s)", m.Filter.ValueString(), strings.Join(conditions, ",")), diags
+}
+
+// actionParameters returns the alert update actions for the configured triage values.
+func actionParameters(
+	ctx context.Context,
+	m detectionStatusAutomationResourceModel,
+) ([]*models.MsaspecActionParameter, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var actions []*models.MsaspecActionParameter
+
+	add := func(name, value string) {
+		actions = append(actions, &models.MsaspecActionParameter{
+			Name:  &name,
+			Value: &value,
+		})
+	}
+
+	if !m.Status.IsNull() {
+		add("update_status", m.Status.ValueString())
+	}
+
+	if !m.AssignedToUUID.IsNull() {
+		add("assign_to_uuid", m.AssignedToUUID.ValueString())
+	}
+
+	var tags []string
+	diags.Append(m.Tags.ElementsAs(ctx, &tags, true)...)
+	for _, tag := range tags {
+		add("add_tag", tag)
+	}
+
+	if m.Comment.ValueString() != "" {
+		add("append_comment", m.Comment.ValueString())
+	}
+
+	return actions, diags
+}
+
+// pendingAlertsPlanModifier always plans no pending alerts, so pending alerts found during
+// refresh show as a change and are triaged by the next apply.
+type pendingAlertsPlanModifier struct{}
+
+func (m pendingAlertsPlanModifier) Description(_ context.Context) string {
+	return "Plans the pending alert count as 0 so pending alerts are triaged on apply."
+}
+
+func (m pendingAlertsPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m pendingAlertsPlanModifier) PlanModifyInt64(
+	_ context.Context,
+	req planmodifier.Int64Request,
+	resp *planmodifier.Int64Response,
+) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.PlanValue = types.Int64Value(0)
+}
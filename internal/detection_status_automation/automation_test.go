@@ -0,0 +1,73 @@
+package detectionstatusautomation_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/acctest"
+	sdkacctest "github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDetectionStatusAutomationResource(t *testing.T) {
+	// The tag is random so the filter does not match, and change, any real alerts.
+	tag := sdkacctest.RandomWithPrefix("tf-acceptance-test")
+	resourceName := "crowdstrike_detection_status_automation.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.ProviderConfig + fmt.Sprintf(`
+resource "crowdstrike_detection_status_automation" "test" {
+  filter = "tags:'%[1]s'"
+  status = "in_progress"
+}
+`, tag),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "status", "in_progress"),
+					resource.TestCheckResourceAttr(resourceName, "pending_alert_count", "0"),
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttrSet(resourceName, "last_updated"),
+				),
+			},
+			{
+				Config: acctest.ProviderConfig + fmt.Sprintf(`
+resource "crowdstrike_detection_status_automation" "test" {
+  filter  = "tags:'%[1]s'"
+  status  = "closed"
+  tags    = ["%[1]s-closed"]
+  comment = "made with terraform"
+}
+`, tag),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "status", "closed"),
+					resource.TestCheckResourceAttr(resourceName, "tags.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "comment", "made with terraform"),
+					resource.TestCheckResourceAttr(resourceName, "pending_alert_count", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDetectionStatusAutomationResource_noAction(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.ProviderConfig + `
+resource "crowdstrike_detection_status_automation" "test" {
+  filter  = "product:'epp'"
+  comment = "only a comment"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Missing triage action"),
+			},
+		},
+	})
+}
@@ -0,0 +1,24 @@
+package detectionstatusautomation
+
+import (
+	"time"
+
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/scopes"
+)
+
+// defaultTimeout is used for an operation when the timeouts block does not configure one.
+const defaultTimeout = 20 * time.Minute
+
+// queryLimit is the number of alert ids requested per page when collecting matching alerts.
+const queryLimit int64 = 1000
+
+// batchSize is the number of alert ids sent in a single update request.
+const batchSize = 500
+
+var apiScopes = []scopes.Scope{
+	{
+		Name:  "Alerts",
+		Read:  true,
+		Write: true,
+	},
+}
@@ -3,6 +3,7 @@ package fim
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"reflect"
 	"strings"
 	"time"
@@ -10,8 +11,12 @@ import (
 	"github.com/crowdstrike/gofalcon/falcon/client"
 	"github.com/crowdstrike/gofalcon/falcon/client/filevantage"
 	"github.com/crowdstrike/gofalcon/falcon/models"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/identity"
+	membercid "github.com/crowdstrike/terraform-provider-crowdstrike/internal/member_cid"
 	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/scopes"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/tferrors"
 	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -25,6 +30,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/sync/errgroup"
 )
 
 // hostGroupAction action for policies-host-group api.
@@ -59,7 +65,9 @@ var (
 	_ resource.Resource                   = &fimPolicyResource{}
 	_ resource.ResourceWithConfigure      = &fimPolicyResource{}
 	_ resource.ResourceWithImportState    = &fimPolicyResource{}
+	_ resource.ResourceWithIdentity       = &fimPolicyResource{}
 	_ resource.ResourceWithValidateConfig = &fimPolicyResource{}
+	_ membercid.Resource                  = &fimPolicyResource{}
 )
 
 // NewFIMPolicyResource is a helper function to simplify the provider implementation.
@@ -69,12 +77,14 @@ func NewFIMPolicyResource() resource.Resource {
 
 // fimPolicyResource is the resource implementation.
 type fimPolicyResource struct {
-	client *client.CrowdStrikeAPISpecification
+	client        *client.CrowdStrikeAPISpecification
+	memberClients *membercid.Clients
 }
 
 // fimPolicyResourceModel is the resource implementation.
 type fimPolicyResourceModel struct {
 	ID                  types.String          `tfsdk:"id"`
+	MemberCID           types.String          `tfsdk:"member_cid"`
 	Name                types.String          `tfsdk:"name"`
 	Description         types.String          `tfsdk:"description"`
 	PlatformName        types.String          `tfsdk:"platform_name"`
@@ -82,7 +92,9 @@ type fimPolicyResourceModel struct {
 	HostGroups          types.Set             `tfsdk:"host_groups"`
 	RuleGroups          types.List            `tfsdk:"rule_groups"`
 	LastUpdated         types.String          `tfsdk:"last_updated"`
+	DeletionProtection  types.Bool            `tfsdk:"deletion_protection"`
 	ScheduledExclusions []*scheduledExclusion `tfsdk:"scheduled_exclusions"`
+	Timeouts            timeouts.Value        `tfsdk:"timeouts"`
 }
 
 type scheduledExclusion struct {
@@ -136,6 +148,24 @@ func (r *fimPolicyResource) Configure(
 	r.client = client
 }
 
+// SetMemberClients adds the clients used when member_cid is set.
+func (r *fimPolicyResource) SetMemberClients(m *membercid.Clients) {
+	r.memberClients = m
+}
+
+// withMemberCID returns a copy of the resource that uses the client of memberCID.
+func (r *fimPolicyResource) withMemberCID(memberCID types.String) (*fimPolicyResource, diag.Diagnostics) {
+	c, diags := membercid.ClientFor(r.client, r.memberClients, memberCID)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	scoped := *r
+	scoped.client = c
+
+	return &scoped, diags
+}
+
 // Metadata returns the resource type name.
 func (r *fimPolicyResource) Metadata(
 	_ context.Context,
@@ -147,7 +177,7 @@ func (r *fimPolicyResource) Metadata(
 
 // Schema defines the schema for the resource.
 func (r *fimPolicyResource) Schema(
-	_ context.Context,
+	ctx context.Context,
 	_ resource.SchemaRequest,
 	resp *resource.SchemaResponse,
 ) {
@@ -164,10 +194,12 @@ func (r *fimPolicyResource) Schema(
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"member_cid": membercid.Attribute("filevantage policy"),
 			"last_updated": schema.StringAttribute{
 				Computed:    true,
 				Description: "Timestamp of the last Terraform update of the resource.",
 			},
+			"deletion_protection": utils.DeletionProtectionAttribute("filevantage policy"),
 			"name": schema.StringAttribute{
 				Required:    true,
 				Description: "Name of the filevantage policy.",
@@ -329,6 +361,14 @@ func (r *fimPolicyResource) Schema(
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -347,6 +387,21 @@ func (r *fimPolicyResource) Create(
 		return
 	}
 
+	r, diags = r.withMemberCID(plan.MemberCID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	policy, diags := r.createFIMPolicy(ctx, plan)
 
 	resp.Diagnostics.Append(diags...)
@@ -428,6 +483,8 @@ func (r *fimPolicyResource) Create(
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, plan.ID)...)
 }
 
 // Read refreshes the Terraform state with the latest data.
@@ -444,11 +501,50 @@ func (r *fimPolicyResource) Read(
 		return
 	}
 
+	r, diags = r.withMemberCID(oldState.MemberCID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := oldState.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	if oldState.ID.ValueString() == "" {
 		return
 	}
 
-	policy, diags := r.getFIMPolicy(ctx, oldState.ID.ValueString())
+	var policy *models.PoliciesPolicy
+	var exclusions []*models.ScheduledexclusionsScheduledExclusion
+	var exclusionDiags diag.Diagnostics
+
+	// Scheduled exclusions are not returned with the policy, fetch both concurrently.
+	var g errgroup.Group
+	g.Go(func() error {
+		policy, diags = r.getFIMPolicy(ctx, oldState.ID.ValueString())
+		return nil
+	})
+	g.Go(func() error {
+		exclusions, exclusionDiags = r.getScheduledExclusions(ctx, oldState.ID.ValueString())
+		return nil
+	})
+	_ = g.Wait()
+
+	if tferrors.HasNotFoundError(diags) {
+		tflog.Warn(
+			ctx,
+			"FileVantage policy not found, removing from state",
+			map[string]interface{}{"id": oldState.ID.ValueString()},
+		)
+		resp.State.RemoveResource(ctx)
+		return
+	}
 
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -465,6 +561,8 @@ func (r *fimPolicyResource) Read(
 		state.Enabled = types.BoolValue(*policy.Enabled)
 		state.PlatformName = types.StringValue(policy.Platform)
 		state.LastUpdated = oldState.LastUpdated
+		state.DeletionProtection = oldState.DeletionProtection
+		state.Timeouts = oldState.Timeouts
 		hostGroups = policy.HostGroups
 		ruleGroups = policy.RuleGroups
 	}
@@ -479,8 +577,7 @@ func (r *fimPolicyResource) Read(
 		return
 	}
 
-	exclusions, diags := r.getScheduledExclusions(ctx, oldState.ID.ValueString())
-	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(exclusionDiags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -490,12 +587,19 @@ func (r *fimPolicyResource) Read(
 		return
 	}
 
+	// deletion_protection is not stored in Falcon, imported resources use the default.
+	if state.DeletionProtection.IsNull() {
+		state.DeletionProtection = types.BoolValue(false)
+	}
+
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, state.ID)...)
 }
 
 // Update updates the resource and sets the updated Terraform state on success.
@@ -512,6 +616,21 @@ func (r *fimPolicyResource) Update(
 		return
 	}
 
+	r, diags = r.withMemberCID(plan.MemberCID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	// Retrieve values from state
 	var state fimPolicyResourceModel
 	diags = req.State.Get(ctx, &state)
@@ -580,6 +699,8 @@ func (r *fimPolicyResource) Update(
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, plan.ID)...)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -595,17 +716,56 @@ func (r *fimPolicyResource) Delete(
 		return
 	}
 
+	r, diags = r.withMemberCID(state.MemberCID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.DeletionProtection.ValueBool() {
+		resp.Diagnostics.Append(utils.DeletionProtectionError("filevantage policy", state.ID.ValueString()))
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	resp.Diagnostics.Append(r.deleteFIMPolicy(ctx, state)...)
 }
 
+// IdentitySchema defines the identity schema for the resource.
+func (r *fimPolicyResource) IdentitySchema(
+	_ context.Context,
+	_ resource.IdentitySchemaRequest,
+	resp *resource.IdentitySchemaResponse,
+) {
+	resp.IdentitySchema = identity.Schema()
+}
+
 // ImportState implements the logic to support resource imports.
 func (r *fimPolicyResource) ImportState(
 	ctx context.Context,
 	req resource.ImportStateRequest,
 	resp *resource.ImportStateResponse,
 ) {
-	// Retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	// filevantage policies of a child CID are imported as <member_cid>/<id>.
+	if memberCID, id, ok := membercid.ImportID(req.ID); ok {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("member_cid"), memberCID)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		req.ID = id
+	}
+
+	// Retrieve import ID or identity and save to id attribute
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
 }
 
 // ValidateConfig runs during validate, plan, and apply
@@ -743,7 +903,7 @@ func (r *fimPolicyResource) deleteFIMPolicy(
 			fmt.Sprintf(
 				"Could not delete filevantage policy (%s): \n\n %s",
 				config.ID.ValueString(),
-				err.Error(),
+				tferrors.Message(err, apiScopes...),
 			),
 		)
 	}
@@ -776,7 +936,7 @@ func (r *fimPolicyResource) updatePolicy(
 			fmt.Sprintf(
 				"Could not update filevantage policy (%s), unexpected error: \n\n %s",
 				config.ID.ValueString(),
-				err.Error(),
+				tferrors.Message(err, apiScopes...),
 			),
 		)
 	}
@@ -796,16 +956,21 @@ func (r *fimPolicyResource) getFIMPolicy(
 		Ids:     []string{id},
 	})
 
-	if err != nil {
-		diags.AddError(
+	if tferrors.IsNotFound(err) || (err == nil && len(res.Payload.Resources) == 0) {
+		diags.Append(tferrors.NewNotFoundError(
 			"Failed to get FileVantage policy",
-			fmt.Sprintf("Failed to get FileVantage policy (%s): %s", id, err),
-		)
+			fmt.Sprintf("FileVantage policy (%s) not found.", id),
+		))
 
 		return nil, diags
 	}
 
-	if len(res.Payload.Resources) == 0 {
+	if err != nil {
+		diags.AddError(
+			"Failed to get FileVantage policy",
+			fmt.Sprintf("Failed to get FileVantage policy (%s): %s", id, tferrors.Message(err, apiScopes...)),
+		)
+
 		return nil, diags
 	}
 
@@ -819,19 +984,24 @@ func (r *fimPolicyResource) createFIMPolicy(
 ) (*models.PoliciesPolicy, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
-	res, err := r.client.Filevantage.CreatePolicies(&filevantage.CreatePoliciesParams{
-		Context: ctx,
-		Body: &models.PoliciesCreateRequest{
-			Name:        config.Name.ValueStringPointer(),
-			Description: config.Description.ValueString(),
-			Platform:    config.PlatformName.ValueString(),
-		},
+	var res *filevantage.CreatePoliciesOK
+	err := utils.RetryOnConflict(ctx, func() error {
+		var err error
+		res, err = r.client.Filevantage.CreatePolicies(&filevantage.CreatePoliciesParams{
+			Context: ctx,
+			Body: &models.PoliciesCreateRequest{
+				Name:        config.Name.ValueStringPointer(),
+				Description: config.Description.ValueString(),
+				Platform:    config.PlatformName.ValueString(),
+			},
+		})
+		return err
 	})
 
 	if err != nil {
 		diags.AddError(
 			"Failed to create FileVantage policy",
-			fmt.Sprintf("Failed to create FileVantage policy: %s", err),
+			fmt.Sprintf("Failed to create FileVantage policy: %s", tferrors.Message(err, apiScopes...)),
 		)
 
 		return nil, diags
@@ -903,7 +1073,7 @@ func (r *fimPolicyResource) updateHostGroups(
 				action.String(),
 				id,
 				strings.Join(hostGroupIDs, ","),
-				err.Error(),
+				tferrors.Message(err, apiScopes...),
 			),
 		)
 	}
@@ -1011,7 +1181,7 @@ func (r *fimPolicyResource) updateRuleGroups(
 				action.String(),
 				id,
 				strings.Join(ruleGroupIDs, ","),
-				err.Error(),
+				tferrors.Message(err, apiScopes...),
 			),
 		)
 	}
@@ -1199,7 +1369,7 @@ func (r *fimPolicyResource) getScheduledExclusions(
 	if err != nil {
 		diags.AddError(
 			"Error getting scheduled exclusions",
-			fmt.Sprintf("Could not get scheduled exclusions: %s", err.Error()),
+			fmt.Sprintf("Could not get scheduled exclusions: %s", tferrors.Message(err, apiScopes...)),
 		)
 
 		return exclusions, diags
@@ -1224,7 +1394,7 @@ func (r *fimPolicyResource) getScheduledExclusions(
 	if err != nil {
 		diags.AddError(
 			"Error getting scheduled exclusions",
-			fmt.Sprintf("Could not get scheduled exclusions: %s", err.Error()),
+			fmt.Sprintf("Could not get scheduled exclusions: %s", tferrors.Message(err, apiScopes...)),
 		)
 
 		return exclusions, diags
@@ -1338,13 +1508,13 @@ func (r *fimPolicyResource) updateScheduledExclusion(
 			errMsg := fmt.Sprintf(
 				"Could not update scheduled exclusion (%s): %s",
 				exclusion.ID.ValueString(),
-				err.Error(),
+				tferrors.Message(err, apiScopes...),
 			)
-			if strings.Contains(err.Error(), "500") {
+			if tferrors.StatusCode(err) == http.StatusInternalServerError {
 				errMsg = fmt.Sprintf(
 					"Could not update scheduled exclusion (%s): Returned error code 500, this could be caused by inproperly formmated users or processes strings. \n\n %s",
 					exclusion.ID.ValueString(),
-					err.Error(),
+					tferrors.Message(err, apiScopes...),
 				)
 			}
 
@@ -1400,7 +1570,7 @@ func (r *fimPolicyResource) deleteScheduledExclusions(
 			fmt.Sprintf(
 				"Could not delete scheduled exclusions (%s): %s",
 				strings.Join(exclusionIDs, ","),
-				err.Error(),
+				tferrors.Message(err, apiScopes...),
 			),
 		)
 
@@ -1525,13 +1695,13 @@ func (r *fimPolicyResource) createScheduledExclusions(
 		if err != nil {
 			errMsg := fmt.Sprintf(
 				"Could not create scheduled exclusion: %s",
-				err.Error(),
+				tferrors.Message(err, apiScopes...),
 			)
-			if strings.Contains(err.Error(), "500") {
+			if tferrors.StatusCode(err) == http.StatusInternalServerError {
 				errMsg = fmt.Sprintf(
 					"Could not create scheduled exclusion (%s): Returned error code 500, this could be caused by inproperly formmated users or processes strings. \n\n %s",
 					exclusion.Name.ValueString(),
-					err.Error(),
+					tferrors.Message(err, apiScopes...),
 				)
 			}
 
@@ -0,0 +1,247 @@
+package fim
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/client/filevantage"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/scopes"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/tferrors"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &filevantagePoliciesDataSource{}
+	_ datasource.DataSourceWithConfigure = &filevantagePoliciesDataSource{}
+)
+
+// NewFilevantagePoliciesDataSource is a helper function to simplify the provider implementation.
+func NewFilevantagePoliciesDataSource() datasource.DataSource {
+	return &filevantagePoliciesDataSource{}
+}
+
+// filevantagePoliciesDataSource is the data source implementation.
+type filevantagePoliciesDataSource struct {
+	client *client.CrowdStrikeAPISpecification
+}
+
+// filevantagePoliciesDataSourceModel maps the data source schema data.
+type filevantagePoliciesDataSourceModel struct {
+	PlatformName types.String                 `tfsdk:"platform_name"`
+	Name         types.String                 `tfsdk:"name"`
+	Policies     []filevantagePolicyDataModel `tfsdk:"policies"`
+}
+
+// filevantagePolicyDataModel maps a single filevantage policy returned by the data source.
+type filevantagePolicyDataModel struct {
+	ID           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	Description  types.String `tfsdk:"description"`
+	PlatformName types.String `tfsdk:"platform_name"`
+	Enabled      types.Bool   `tfsdk:"enabled"`
+	HostGroups   types.Set    `tfsdk:"host_groups"`
+	RuleGroups   types.List   `tfsdk:"rule_groups"`
+}
+
+// Metadata returns the data source type name.
+func (d *filevantagePoliciesDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_filevantage_policies"
+}
+
+// Schema defines the schema for the data source.
+func (d *filevantagePoliciesDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: fmt.Sprintf(
+			"FileVantage --- This data source returns the FileVantage policies of a platform. The FileVantage API does not support FQL filters, policies can be narrowed down by name instead.\n\n%s",
+			scopes.GenerateScopeDescription(readScopes),
+		),
+		Attributes: map[string]schema.Attribute{
+			"platform_name": schema.StringAttribute{
+				Required:    true,
+				Description: "Platform of the filevantage policies to return. (Windows, Mac, Linux)",
+				Validators: []validator.String{
+					stringvalidator.OneOf("Windows", "Linux", "Mac"),
+				},
+			},
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return the filevantage policy with this exact name. All policies of the platform are returned when left blank.",
+			},
+			"policies": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The filevantage policies ordered by precedence.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "Identifier for the filevantage policy.",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "Name of the filevantage policy.",
+						},
+						"description": schema.StringAttribute{
+							Computed:    true,
+							Description: "Description of the filevantage policy.",
+						},
+						"platform_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "Platform of the filevantage policy.",
+						},
+						"enabled": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the filevantage policy is enabled.",
+						},
+						"host_groups": schema.SetAttribute{
+							Computed:    true,
+							ElementType: types.StringType,
+							Description: "Host Group ids attached to the filevantage policy.",
+						},
+						"rule_groups": schema.ListAttribute{
+							Computed:    true,
+							ElementType: types.StringType,
+							Description: "Rule Group ids attached to the filevantage policy in order of precedence.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *filevantagePoliciesDataSource) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var state filevantagePoliciesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var policies []*models.PoliciesPolicy
+
+	err := queryPages(
+		func(limit, offset *int64) (*models.MsaspecQueryResponse, error) {
+			res, err := d.client.Filevantage.QueryPolicies(&filevantage.QueryPoliciesParams{
+				Context: ctx,
+				Type:    state.PlatformName.ValueString(),
+				Limit:   limit,
+				Offset:  offset,
+			})
+			if err != nil {
+				return nil, err
+			}
+			return res.Payload, nil
+		},
+		func(ids []string) error {
+			res, err := d.client.Filevantage.GetPolicies(&filevantage.GetPoliciesParams{
+				Context: ctx,
+				Ids:     ids,
+			})
+			if err != nil {
+				return err
+			}
+			policies = append(policies, res.Payload.Resources...)
+			return nil
+		},
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read filevantage policies",
+			tferrors.Message(err, readScopes...),
+		)
+		return
+	}
+
+	sort.SliceStable(policies, func(i, j int) bool {
+		return policies[i].Precedence < policies[j].Precedence
+	})
+
+	state.Policies = []filevantagePolicyDataModel{}
+
+	for _, p := range policies {
+		if !state.Name.IsNull() && p.Name != state.Name.ValueString() {
+			continue
+		}
+
+		hostGroups := []string{}
+		for _, group := range p.HostGroups {
+			if group != nil && group.ID != nil {
+				hostGroups = append(hostGroups, *group.ID)
+			}
+		}
+
+		ruleGroups := []string{}
+		for _, group := range p.RuleGroups {
+			if group != nil && group.ID != nil {
+				ruleGroups = append(ruleGroups, *group.ID)
+			}
+		}
+
+		hostGroupIDs, diags := types.SetValueFrom(ctx, types.StringType, hostGroups)
+		resp.Diagnostics.Append(diags...)
+
+		ruleGroupIDs, diags := types.ListValueFrom(ctx, types.StringType, ruleGroups)
+		resp.Diagnostics.Append(diags...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		state.Policies = append(state.Policies, filevantagePolicyDataModel{
+			ID:           types.StringPointerValue(p.ID),
+			Name:         types.StringValue(p.Name),
+			Description:  types.StringValue(p.Description),
+			PlatformName: types.StringValue(p.Platform),
+			Enabled:      types.BoolPointerValue(p.Enabled),
+			HostGroups:   hostGroupIDs,
+			RuleGroups:   ruleGroupIDs,
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *filevantagePoliciesDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.CrowdStrikeAPISpecification)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf(
+				"Expected *client.CrowdStrikeAPISpecification, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+		return
+	}
+
+	d.client = client
+}
@@ -0,0 +1,59 @@
+package fim_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/acctest"
+	sdkacctest "github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccFilevantagePoliciesDataSource(t *testing.T) {
+	rName := sdkacctest.RandomWithPrefix("tf-acceptance-test")
+	dataSourceName := "data.crowdstrike_filevantage_policies.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.ProviderConfig + fmt.Sprintf(`
+resource "crowdstrike_filevantage_rule_group" "test" {
+  name = "%[1]s"
+  type = "MacFiles"
+}
+
+resource "crowdstrike_filevantage_policy" "test" {
+  name          = "%[1]s"
+  enabled       = false
+  platform_name = "Mac"
+  rule_groups   = [crowdstrike_filevantage_rule_group.test.id]
+}
+
+data "crowdstrike_filevantage_policies" "test" {
+  platform_name = "Mac"
+  name          = crowdstrike_filevantage_policy.test.name
+}
+`, rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "policies.#", "1"),
+					resource.TestCheckResourceAttrPair(
+						dataSourceName,
+						"policies.0.id",
+						"crowdstrike_filevantage_policy.test",
+						"id",
+					),
+					resource.TestCheckResourceAttr(dataSourceName, "policies.0.platform_name", "Mac"),
+					resource.TestCheckResourceAttr(dataSourceName, "policies.0.enabled", "false"),
+					resource.TestCheckResourceAttrPair(
+						dataSourceName,
+						"policies.0.rule_groups.0",
+						"crowdstrike_filevantage_rule_group.test",
+						"id",
+					),
+				),
+			},
+		},
+	})
+}
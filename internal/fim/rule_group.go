@@ -10,7 +10,12 @@ import (
 	"github.com/crowdstrike/gofalcon/falcon/client"
 	"github.com/crowdstrike/gofalcon/falcon/client/filevantage"
 	"github.com/crowdstrike/gofalcon/falcon/models"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/identity"
+	membercid "github.com/crowdstrike/terraform-provider-crowdstrike/internal/member_cid"
 	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/scopes"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/tferrors"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -23,6 +28,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -30,7 +36,9 @@ var (
 	_ resource.Resource                   = &filevantageRuleGroupResource{}
 	_ resource.ResourceWithConfigure      = &filevantageRuleGroupResource{}
 	_ resource.ResourceWithImportState    = &filevantageRuleGroupResource{}
+	_ resource.ResourceWithIdentity       = &filevantageRuleGroupResource{}
 	_ resource.ResourceWithValidateConfig = &filevantageRuleGroupResource{}
+	_ membercid.Resource                  = &filevantageRuleGroupResource{}
 )
 
 const (
@@ -47,17 +55,20 @@ func NewFilevantageRuleGroupResource() resource.Resource {
 
 // filevantageRuleGroupResource is the resource implementation.
 type filevantageRuleGroupResource struct {
-	client *client.CrowdStrikeAPISpecification
+	client        *client.CrowdStrikeAPISpecification
+	memberClients *membercid.Clients
 }
 
 // filevantageRuleGroupResourceModel is the resource implementation.
 type filevantageRuleGroupResourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	Name        types.String `tfsdk:"name"`
-	Type        types.String `tfsdk:"type"`
-	Description types.String `tfsdk:"description"`
-	Rules       []*fimRule   `tfsdk:"rules"`
-	LastUpdated types.String `tfsdk:"last_updated"`
+	ID          types.String   `tfsdk:"id"`
+	MemberCID   types.String   `tfsdk:"member_cid"`
+	Name        types.String   `tfsdk:"name"`
+	Type        types.String   `tfsdk:"type"`
+	Description types.String   `tfsdk:"description"`
+	Rules       []*fimRule     `tfsdk:"rules"`
+	LastUpdated types.String   `tfsdk:"last_updated"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
 }
 
 // fimRule is the resource implementation.
@@ -129,6 +140,24 @@ func (r *filevantageRuleGroupResource) Configure(
 	r.client = client
 }
 
+// SetMemberClients adds the clients used when member_cid is set.
+func (r *filevantageRuleGroupResource) SetMemberClients(m *membercid.Clients) {
+	r.memberClients = m
+}
+
+// withMemberCID returns a copy of the resource that uses the client of memberCID.
+func (r *filevantageRuleGroupResource) withMemberCID(memberCID types.String) (*filevantageRuleGroupResource, diag.Diagnostics) {
+	c, diags := membercid.ClientFor(r.client, r.memberClients, memberCID)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	scoped := *r
+	scoped.client = c
+
+	return &scoped, diags
+}
+
 // Metadata returns the resource type name.
 func (r *filevantageRuleGroupResource) Metadata(
 	_ context.Context,
@@ -140,7 +169,7 @@ func (r *filevantageRuleGroupResource) Metadata(
 
 // Schema defines the schema for the resource.
 func (r *filevantageRuleGroupResource) Schema(
-	_ context.Context,
+	ctx context.Context,
 	_ resource.SchemaRequest,
 	resp *resource.SchemaResponse,
 ) {
@@ -157,6 +186,7 @@ func (r *filevantageRuleGroupResource) Schema(
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"member_cid": membercid.Attribute("filevantage rule group"),
 			"last_updated": schema.StringAttribute{
 				Computed:    true,
 				Description: "Timestamp of the last Terraform update of the resource.",
@@ -448,6 +478,14 @@ func (r *filevantageRuleGroupResource) Schema(
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -463,6 +501,21 @@ func (r *filevantageRuleGroupResource) Create(
 		return
 	}
 
+	r, diags := r.withMemberCID(plan.MemberCID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	rgType := plan.Type.ValueString()
 
 	params := filevantage.CreateRuleGroupsParams{
@@ -474,7 +527,12 @@ func (r *filevantageRuleGroupResource) Create(
 		},
 	}
 
-	res, err := r.client.Filevantage.CreateRuleGroups(&params)
+	var res *filevantage.CreateRuleGroupsOK
+	err := utils.RetryOnConflict(ctx, func() error {
+		var err error
+		res, err = r.client.Filevantage.CreateRuleGroups(&params)
+		return err
+	})
 
 	if res == nil {
 		res = &filevantage.CreateRuleGroupsOK{}
@@ -517,6 +575,8 @@ func (r *filevantageRuleGroupResource) Create(
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, plan.ID)...)
 }
 
 // Read refreshes the Terraform state with the latest data.
@@ -531,7 +591,32 @@ func (r *filevantageRuleGroupResource) Read(
 		return
 	}
 
+	r, diags := r.withMemberCID(state.MemberCID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	res, diags := r.getRuleGroup(ctx, state.ID.ValueString())
+	if tferrors.HasNotFoundError(diags) {
+		tflog.Warn(
+			ctx,
+			"Filevantage rule group not found, removing from state",
+			map[string]interface{}{"id": state.ID.ValueString()},
+		)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -568,6 +653,8 @@ func (r *filevantageRuleGroupResource) Read(
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, state.ID)...)
 }
 
 // Update updates the resource and sets the updated Terraform state on success.
@@ -584,6 +671,21 @@ func (r *filevantageRuleGroupResource) Update(
 		return
 	}
 
+	r, diags = r.withMemberCID(plan.MemberCID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	// Retrieve values from state
 	var state filevantageRuleGroupResourceModel
 	diags = req.State.Get(ctx, &state)
@@ -646,6 +748,8 @@ func (r *filevantageRuleGroupResource) Update(
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, plan.ID)...)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -660,6 +764,21 @@ func (r *filevantageRuleGroupResource) Delete(
 		return
 	}
 
+	r, diags := r.withMemberCID(state.MemberCID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	id := state.ID.ValueString()
 
 	if id == "" {
@@ -676,19 +795,38 @@ func (r *filevantageRuleGroupResource) Delete(
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to delete filevantage rule group",
-			fmt.Sprintf("Failed to delete filevantage rule group (%s): %s", id, err.Error()),
+			fmt.Sprintf("Failed to delete filevantage rule group (%s): %s", id, tferrors.Message(err, apiScopes...)),
 		)
 	}
 }
 
+// IdentitySchema defines the identity schema for the resource.
+func (r *filevantageRuleGroupResource) IdentitySchema(
+	_ context.Context,
+	_ resource.IdentitySchemaRequest,
+	resp *resource.IdentitySchemaResponse,
+) {
+	resp.IdentitySchema = identity.Schema()
+}
+
 // ImportState implements the logic to support resource imports.
 func (r *filevantageRuleGroupResource) ImportState(
 	ctx context.Context,
 	req resource.ImportStateRequest,
 	resp *resource.ImportStateResponse,
 ) {
-	// Retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	// filevantage rule groups of a child CID are imported as <member_cid>/<id>.
+	if memberCID, id, ok := membercid.ImportID(req.ID); ok {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("member_cid"), memberCID)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		req.ID = id
+	}
+
+	// Retrieve import ID or identity and save to id attribute
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
 }
 
 // ValidateConfig runs during validate, plan, and apply
@@ -879,7 +1017,7 @@ func handleRuleGroupErrors(
 				"Failed to %s filevantage rule group (%s): %s",
 				action,
 				rgID,
-				err.Error(),
+				tferrors.Message(err, apiScopes...),
 			),
 		)
 		return diags
@@ -937,6 +1075,16 @@ func (r *filevantageRuleGroupResource) getRuleGroup(
 
 	res, err := r.client.Filevantage.GetRuleGroups(&params)
 
+	if tferrors.IsNotFound(err) ||
+		(err == nil && res != nil && res.Payload != nil && len(res.Payload.Errors) == 0 && len(res.Payload.Resources) == 0) {
+		var diags diag.Diagnostics
+		diags.Append(tferrors.NewNotFoundError(
+			"Failed to read filevantage rule group",
+			fmt.Sprintf("Filevantage rule group (%s) not found.", id),
+		))
+		return nil, diags
+	}
+
 	if res == nil {
 		res = &filevantage.GetRuleGroupsOK{}
 	}
@@ -968,7 +1116,7 @@ func handleRuleGroupRulesErrors(
 				"Failed to %s filevantage rule group rule (%s): %s",
 				action,
 				rule.Path.ValueString(),
-				err.Error(),
+				tferrors.Message(err, apiScopes...),
 			),
 		)
 
@@ -1113,7 +1261,7 @@ func (r *filevantageRuleGroupResource) getRules(
 				fmt.Sprintf(
 					"Failed to get rules for ids (%s): %s",
 					strings.Join(assignedRuleIDs, ", "),
-					err.Error(),
+					tferrors.Message(err, apiScopes...),
 				),
 			)
 			return rules, diags
@@ -1218,7 +1366,7 @@ func (r *filevantageRuleGroupResource) deleteRules(
 			fmt.Sprintf(
 				"Failed to delete rules for rule group (%s): %s",
 				ruleGroupID,
-				err.Error(),
+				tferrors.Message(err, apiScopes...),
 			),
 		)
 	}
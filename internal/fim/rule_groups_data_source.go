@@ -0,0 +1,203 @@
+package fim
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/client/filevantage"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/scopes"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/tferrors"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &filevantageRuleGroupsDataSource{}
+	_ datasource.DataSourceWithConfigure = &filevantageRuleGroupsDataSource{}
+)
+
+// NewFilevantageRuleGroupsDataSource is a helper function to simplify the provider implementation.
+func NewFilevantageRuleGroupsDataSource() datasource.DataSource {
+	return &filevantageRuleGroupsDataSource{}
+}
+
+// filevantageRuleGroupsDataSource is the data source implementation.
+type filevantageRuleGroupsDataSource struct {
+	client *client.CrowdStrikeAPISpecification
+}
+
+// filevantageRuleGroupsDataSourceModel maps the data source schema data.
+type filevantageRuleGroupsDataSourceModel struct {
+	Type       types.String                    `tfsdk:"type"`
+	Name       types.String                    `tfsdk:"name"`
+	RuleGroups []filevantageRuleGroupDataModel `tfsdk:"rule_groups"`
+}
+
+// filevantageRuleGroupDataModel maps a single filevantage rule group returned by the data source.
+type filevantageRuleGroupDataModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	Type        types.String `tfsdk:"type"`
+}
+
+// Metadata returns the data source type name.
+func (d *filevantageRuleGroupsDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_filevantage_rule_groups"
+}
+
+// Schema defines the schema for the data source.
+func (d *filevantageRuleGroupsDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: fmt.Sprintf(
+			"FileVantage --- This data source returns the FileVantage rule groups of a type. The FileVantage API does not support FQL filters, rule groups can be narrowed down by name instead.\n\n%s",
+			scopes.GenerateScopeDescription(readScopes),
+		),
+		Attributes: map[string]schema.Attribute{
+			"type": schema.StringAttribute{
+				Required:    true,
+				Description: "Type of the filevantage rule groups to return. (LinuxFiles, MacFiles, WindowsFiles, WindowsRegistry)",
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						"LinuxFiles",
+						"MacFiles",
+						"WindowsFiles",
+						"WindowsRegistry",
+					),
+				},
+			},
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return the filevantage rule group with this exact name. All rule groups of the type are returned when left blank.",
+			},
+			"rule_groups": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The filevantage rule groups.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "Identifier for the filevantage rule group.",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "Name of the filevantage rule group.",
+						},
+						"description": schema.StringAttribute{
+							Computed:    true,
+							Description: "Description of the filevantage rule group.",
+						},
+						"type": schema.StringAttribute{
+							Computed:    true,
+							Description: "The type of filevantage rule group.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *filevantageRuleGroupsDataSource) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var state filevantageRuleGroupsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var ruleGroups []*models.RulegroupsRuleGroup
+
+	err := queryPages(
+		func(limit, offset *int64) (*models.MsaspecQueryResponse, error) {
+			res, err := d.client.Filevantage.QueryRuleGroups(&filevantage.QueryRuleGroupsParams{
+				Context: ctx,
+				Type:    state.Type.ValueString(),
+				Limit:   limit,
+				Offset:  offset,
+			})
+			if err != nil {
+				return nil, err
+			}
+			return res.Payload, nil
+		},
+		func(ids []string) error {
+			res, err := d.client.Filevantage.GetRuleGroups(&filevantage.GetRuleGroupsParams{
+				Context: ctx,
+				Ids:     ids,
+			})
+			if err != nil {
+				return err
+			}
+			ruleGroups = append(ruleGroups, res.Payload.Resources...)
+			return nil
+		},
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read filevantage rule groups",
+			tferrors.Message(err, readScopes...),
+		)
+		return
+	}
+
+	state.RuleGroups = []filevantageRuleGroupDataModel{}
+
+	for _, g := range ruleGroups {
+		if !state.Name.IsNull() && g.Name != state.Name.ValueString() {
+			continue
+		}
+
+		state.RuleGroups = append(state.RuleGroups, filevantageRuleGroupDataModel{
+			ID:          types.StringPointerValue(g.ID),
+			Name:        types.StringValue(g.Name),
+			Description: types.StringValue(g.Description),
+			Type:        types.StringValue(g.Type),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *filevantageRuleGroupsDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.CrowdStrikeAPISpecification)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf(
+				"Expected *client.CrowdStrikeAPISpecification, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+		return
+	}
+
+	d.client = client
+}
@@ -0,0 +1,47 @@
+package fim_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/acctest"
+	sdkacctest "github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccFilevantageRuleGroupsDataSource(t *testing.T) {
+	rName := sdkacctest.RandomWithPrefix("tf-acceptance-test")
+	dataSourceName := "data.crowdstrike_filevantage_rule_groups.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.ProviderConfig + fmt.Sprintf(`
+resource "crowdstrike_filevantage_rule_group" "test" {
+  name        = "%s"
+  type        = "LinuxFiles"
+  description = "made with terraform"
+}
+
+data "crowdstrike_filevantage_rule_groups" "test" {
+  type = "LinuxFiles"
+  name = crowdstrike_filevantage_rule_group.test.name
+}
+`, rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "rule_groups.#", "1"),
+					resource.TestCheckResourceAttrPair(
+						dataSourceName,
+						"rule_groups.0.id",
+						"crowdstrike_filevantage_rule_group.test",
+						"id",
+					),
+					resource.TestCheckResourceAttr(dataSourceName, "rule_groups.0.type", "LinuxFiles"),
+					resource.TestCheckResourceAttr(dataSourceName, "rule_groups.0.description", "made with terraform"),
+				),
+			},
+		},
+	})
+}
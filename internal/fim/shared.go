@@ -1,6 +1,14 @@
 package fim
 
-import "github.com/crowdstrike/terraform-provider-crowdstrike/internal/scopes"
+import (
+	"time"
+
+	"github.com/crowdstrike/gofalcon/falcon/models"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/scopes"
+)
+
+// defaultTimeout is used for an operation when the timeouts block does not configure one.
+const defaultTimeout = 20 * time.Minute
 
 var apiScopes = []scopes.Scope{
 	{
@@ -9,3 +17,43 @@ var apiScopes = []scopes.Scope{
 		Write: true,
 	},
 }
+
+var readScopes = []scopes.Scope{
+	{
+		Name:  "Falcon FileVantage",
+		Read:  true,
+		Write: false,
+	},
+}
+
+// queryLimit is the page size used when querying filevantage ids, the maximum the API allows.
+const queryLimit int64 = 500
+
+// queryPages calls query for each page of a paginated filevantage query and passes the ids
+// of the page to handle.
+func queryPages(
+	query func(limit, offset *int64) (*models.MsaspecQueryResponse, error),
+	handle func(ids []string) error,
+) error {
+	limit := queryLimit
+	var offset int64
+
+	for {
+		res, err := query(&limit, &offset)
+		if err != nil {
+			return err
+		}
+
+		if len(res.Resources) > 0 {
+			if err := handle(res.Resources); err != nil {
+				return err
+			}
+		}
+
+		offset += int64(len(res.Resources))
+		if len(res.Resources) == 0 || res.Meta == nil || res.Meta.Pagination == nil ||
+			res.Meta.Pagination.Total == nil || offset >= *res.Meta.Pagination.Total {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package fim_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/crowdstrike/gofalcon/falcon/client/filevantage"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/sweep"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestMain(m *testing.M) {
+	resource.TestMain(m)
+}
+
+func init() {
+	resource.AddTestSweepers("crowdstrike_filevantage_policy", &resource.Sweeper{
+		Name: "crowdstrike_filevantage_policy",
+		F:    sweepFilevantagePolicies,
+	})
+
+	resource.AddTestSweepers("crowdstrike_filevantage_rule_group", &resource.Sweeper{
+		Name: "crowdstrike_filevantage_rule_group",
+		// rule groups can not be deleted while assigned to a policy.
+		Dependencies: []string{"crowdstrike_filevantage_policy"},
+		F:            sweepFilevantageRuleGroups,
+	})
+}
+
+func sweepFilevantagePolicies(_ string) error {
+	ctx := context.Background()
+	c, err := sweep.Client()
+	if err != nil {
+		return err
+	}
+
+	for _, policyType := range []string{"Windows", "Linux", "Mac"} {
+		ids, err := queryFilevantage(func(limit, offset *int64) (*models.MsaspecQueryResponse, error) {
+			res, err := c.Filevantage.QueryPolicies(&filevantage.QueryPoliciesParams{
+				Context: ctx,
+				Type:    policyType,
+				Limit:   limit,
+				Offset:  offset,
+			})
+			if err != nil {
+				return nil, err
+			}
+			return res.Payload, nil
+		})
+		if err != nil {
+			return fmt.Errorf("listing %s filevantage policies: %w", policyType, err)
+		}
+
+		if len(ids) == 0 {
+			continue
+		}
+
+		res, err := c.Filevantage.GetPolicies(&filevantage.GetPoliciesParams{
+			Context: ctx,
+			Ids:     ids,
+		})
+		if err != nil {
+			return fmt.Errorf("reading %s filevantage policies: %w", policyType, err)
+		}
+
+		for _, p := range res.Payload.Resources {
+			if !sweep.IsSweepable(&p.Name) {
+				continue
+			}
+
+			_, err := c.Filevantage.UpdatePolicies(&filevantage.UpdatePoliciesParams{
+				Context: ctx,
+				Body: &models.PoliciesUpdateRequest{
+					ID:          p.ID,
+					Enabled:     false,
+					Name:        p.Name,
+					Description: p.Description,
+				},
+			})
+			if err != nil {
+				return fmt.Errorf("disabling filevantage policy %s: %w", *p.ID, err)
+			}
+
+			_, err = c.Filevantage.DeletePolicies(&filevantage.DeletePoliciesParams{
+				Context: ctx,
+				Ids:     []string{*p.ID},
+			})
+			if err != nil {
+				return fmt.Errorf("deleting filevantage policy %s: %w", *p.ID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func sweepFilevantageRuleGroups(_ string) error {
+	ctx := context.Background()
+	c, err := sweep.Client()
+	if err != nil {
+		return err
+	}
+
+	for _, ruleGroupType := range []string{"WindowsFiles", "WindowsRegistry", "LinuxFiles", "MacFiles"} {
+		ids, err := queryFilevantage(func(limit, offset *int64) (*models.MsaspecQueryResponse, error) {
+			res, err := c.Filevantage.QueryRuleGroups(&filevantage.QueryRuleGroupsParams{
+				Context: ctx,
+				Type:    ruleGroupType,
+				Limit:   limit,
+				Offset:  offset,
+			})
+			if err != nil {
+				return nil, err
+			}
+			return res.Payload, nil
+		})
+		if err != nil {
+			return fmt.Errorf("listing %s filevantage rule groups: %w", ruleGroupType, err)
+		}
+
+		if len(ids) == 0 {
+			continue
+		}
+
+		res, err := c.Filevantage.GetRuleGroups(&filevantage.GetRuleGroupsParams{
+			Context: ctx,
+			Ids:     ids,
+		})
+		if err != nil {
+			return fmt.Errorf("reading %s filevantage rule groups: %w", ruleGroupType, err)
+		}
+
+		var toDelete []string
+		for _, rg := range res.Payload.Resources {
+			if sweep.IsSweepable(&rg.Name) && rg.ID != nil {
+				toDelete = append(toDelete, *rg.ID)
+			}
+		}
+
+		if len(toDelete) == 0 {
+			continue
+		}
+
+		_, err = c.Filevantage.DeleteRuleGroups(&filevantage.DeleteRuleGroupsParams{
+			Context: ctx,
+			Ids:     toDelete,
+		})
+		if err != nil {
+			return fmt.Errorf("deleting %s filevantage rule groups: %w", ruleGroupType, err)
+		}
+	}
+
+	return nil
+}
+
+// queryFilevantage collects every id returned by a paginated filevantage query.
+func queryFilevantage(
+	query func(limit, offset *int64) (*models.MsaspecQueryResponse, error),
+) ([]string, error) {
+	var ids []string
+	limit := sweep.QueryLimit
+	var offset int64
+
+	for {
+		res, err := query(&limit, &offset)
+		if err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, res.Resources...)
+
+		var more bool
+		offset, more = sweep.NextOffset(offset, len(res.Resources), res.Meta)
+		if !more {
+			break
+		}
+	}
+
+	return ids, nil
+}
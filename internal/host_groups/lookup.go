@@ -0,0 +1,44 @@
+package hostgroups
+
+import (
+	"context"
+
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/client/host_group"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/utils"
+)
+
+// lookupBatchSize is the maximum number of host group ids sent in a single get request.
+const lookupBatchSize = 100
+
+// ExistingIDs returns the ids of the host groups in ids that exist.
+func ExistingIDs(
+	ctx context.Context,
+	c *client.CrowdStrikeAPISpecification,
+	ids []string,
+) (map[string]bool, error) {
+	existing, err := utils.GetInBatches(ids, lookupBatchSize, func(batch []string) ([]string, error) {
+		res, err := c.HostGroup.GetHostGroups(&host_group.GetHostGroupsParams{
+			Context: ctx,
+			Ids:     batch,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var found []string
+		for _, group := range res.Payload.Resources {
+			if group != nil && group.ID != nil {
+				found = append(found, *group.ID)
+			}
+		}
+		return found, nil
+	})
+
+	found := make(map[string]bool, len(existing))
+	for _, id := range existing {
+		found[id] = true
+	}
+
+	return found, err
+}
@@ -0,0 +1,40 @@
+// Package identity provides the resource identity shared by resources that are
+// identified by a CrowdStrike API id.
+package identity
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Model maps the resource identity schema data.
+type Model struct {
+	ID types.String `tfsdk:"id"`
+}
+
+// Schema returns the identity schema for a resource identified by its API id.
+// The id is the only attribute that never changes for the lifetime of a resource,
+// names and descriptions can be updated in place so they are not part of the identity.
+func Schema() identityschema.Schema {
+	return identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "The CrowdStrike API id of the resource.",
+			},
+		},
+	}
+}
+
+// Set stores id as the identity of the resource.
+func Set(ctx context.Context, identity *tfsdk.ResourceIdentity, id types.String) diag.Diagnostics {
+	if identity == nil {
+		return nil
+	}
+
+	return identity.Set(ctx, Model{ID: id})
+}
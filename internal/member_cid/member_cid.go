@@ -0,0 +1,129 @@
+// Package membercid provides the member_cid attribute shared by resources that can be
+// managed in a Falcon Flight Control child CID other than the one of the provider.
+package membercid
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/crowdstrike/gofalcon/falcon"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// AccessTokenDetail explains why member_cid cannot be used with an access token.
+const AccessTokenDetail = "member_cid requires the provider to authenticate with client_id and client_secret. " +
+	"A pre-issued access_token is already scoped to a CID and cannot be requested for another one."
+
+// Clients creates the clients of resources that set member_cid. The clients share the
+// provider transport, and every resource in the same child CID reuses one client and its
+// oauth2 token.
+type Clients struct {
+	// apiConfig is the configuration of the provider client.
+	apiConfig falcon.ApiConfig
+
+	mu      sync.Mutex
+	clients map[string]*client.CrowdStrikeAPISpecification
+}
+
+// NewClients returns the member clients of the provider client created from apiConfig.
+func NewClients(apiConfig falcon.ApiConfig) *Clients {
+	return &Clients{
+		apiConfig: apiConfig,
+		clients:   map[string]*client.CrowdStrikeAPISpecification{},
+	}
+}
+
+// Resource is implemented by resources that support the member_cid attribute. The
+// provider calls SetMemberClients before Configure.
+type Resource interface {
+	SetMemberClients(m *Clients)
+}
+
+// Client returns the client scoped to memberCID, creating it on first use.
+func (m *Clients) Client(memberCID string) (*client.CrowdStrikeAPISpecification, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if m.apiConfig.AccessToken != "" {
+		diags.AddAttributeError(path.Root("member_cid"), "Invalid CrowdStrike Member CID", AccessTokenDetail)
+		return nil, diags
+	}
+
+	key := strings.ToLower(memberCID)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if c, ok := m.clients[key]; ok {
+		return c, diags
+	}
+
+	apiConfig := m.apiConfig
+	apiConfig.MemberCID = memberCID
+
+	c, err := falcon.NewClient(&apiConfig)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("member_cid"),
+			"Unable to Create CrowdStrike API Client",
+			fmt.Sprintf("Could not create the CrowdStrike API client for member CID %s: %s", memberCID, err.Error()),
+		)
+		return nil, diags
+	}
+
+	m.clients[key] = c
+
+	return c, diags
+}
+
+// ClientFor returns the client for the member_cid of a resource. The provider client is
+// returned when member_cid is not set.
+func ClientFor(
+	c *client.CrowdStrikeAPISpecification,
+	m *Clients,
+	memberCID types.String,
+) (*client.CrowdStrikeAPISpecification, diag.Diagnostics) {
+	if memberCID.IsNull() || memberCID.IsUnknown() || memberCID.ValueString() == "" || m == nil {
+		return c, nil
+	}
+
+	return m.Client(memberCID.ValueString())
+}
+
+// Attribute returns the member_cid attribute of a resource.
+func Attribute(resourceName string) schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional: true,
+		MarkdownDescription: fmt.Sprintf(
+			"CID of the child tenant to manage the %s in when using Falcon Flight Control, overriding the `member_cid` of the provider. "+
+				"The provider requests a token for the child CID, so one provider configuration can manage many child CIDs. "+
+				"Requires the provider to authenticate with client_id and client_secret. Changing this value forces a new resource.",
+			resourceName,
+		),
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+}
+
+// ImportID splits an import id of the form <member_cid>/<id>, used to import a resource of
+// a child CID. ok is false for other import ids, including the name:<name> form since a
+// name can contain a slash.
+func ImportID(importID string) (memberCID, id string, ok bool) {
+	if strings.HasPrefix(importID, "name:") {
+		return "", importID, false
+	}
+
+	memberCID, id, ok = strings.Cut(importID, "/")
+	if !ok {
+		return "", importID, false
+	}
+
+	return memberCID, id, true
+}
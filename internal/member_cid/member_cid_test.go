@@ -0,0 +1,31 @@
+package membercid
+
+import (
+	"testing"
+)
+
+func TestImportID(t *testing.T) {
+	tests := []struct {
+		importID  string
+		memberCID string
+		id        string
+		ok        bool
+	}{
+		{importID: "7fb858a949034a0cbca175f660f1e769", memberCID: "", id: "7fb858a949034a0cbca175f660f1e769", ok: false},
+		{importID: "child/7fb858a949034a0cbca175f660f1e769", memberCID: "child", id: "7fb858a949034a0cbca175f660f1e769", ok: true},
+		{importID: "child/name:my policy", memberCID: "child", id: "name:my policy", ok: true},
+		{importID: "name:a/b", memberCID: "", id: "name:a/b", ok: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.importID, func(t *testing.T) {
+			memberCID, id, ok := ImportID(tc.importID)
+			if memberCID != tc.memberCID || id != tc.id || ok != tc.ok {
+				t.Errorf(
+					"ImportID(%q) = %q, %q, %t; want %q, %q, %t",
+					tc.importID, memberCID, id, ok, tc.memberCID, tc.id, tc.ok,
+				)
+			}
+		})
+	}
+}
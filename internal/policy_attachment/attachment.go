@@ -0,0 +1,484 @@
+package policyattachment
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+	hostgroups "github.com/crowdstrike/terraform-provider-crowdstrike/internal/host_groups"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/identity"
+	membercid "github.com/crowdstrike/terraform-provider-crowdstrike/internal/member_cid"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/scopes"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/tferrors"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &policyAttachmentResource{}
+	_ resource.ResourceWithConfigure   = &policyAttachmentResource{}
+	_ resource.ResourceWithImportState = &policyAttachmentResource{}
+	_ resource.ResourceWithIdentity    = &policyAttachmentResource{}
+	_ membercid.Resource               = &policyAttachmentResource{}
+)
+
+// policyAttachmentResource is the resource implementation shared by every policy family.
+type policyAttachmentResource struct {
+	client        *client.CrowdStrikeAPISpecification
+	memberClients *membercid.Clients
+	family        policyFamily
+}
+
+// policyAttachmentResourceModel is the resource model.
+type policyAttachmentResourceModel struct {
+	ID          types.String   `tfsdk:"id"`
+	MemberCID   types.String   `tfsdk:"member_cid"`
+	PolicyID    types.String   `tfsdk:"policy_id"`
+	HostGroups  types.Set      `tfsdk:"host_groups"`
+	LastUpdated types.String   `tfsdk:"last_updated"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *policyAttachmentResource) Configure(
+	ctx context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.CrowdStrikeAPISpecification)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf(
+				"Expected *client.CrowdStrikeAPISpecification, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// SetMemberClients adds the clients used when member_cid is set.
+func (r *policyAttachmentResource) SetMemberClients(m *membercid.Clients) {
+	r.memberClients = m
+}
+
+// withMemberCID returns a copy of the resource that uses the client of memberCID.
+func (r *policyAttachmentResource) withMemberCID(memberCID types.String) (*policyAttachmentResource, diag.Diagnostics) {
+	c, diags := membercid.ClientFor(r.client, r.memberClients, memberCID)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	scoped := *r
+	scoped.client = c
+
+	return &scoped, diags
+}
+
+// Metadata returns the resource type name.
+func (r *policyAttachmentResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = fmt.Sprintf("%s_%s_attachment", req.ProviderTypeName, r.family.typeName)
+}
+
+// Schema defines the schema for the resource.
+func (r *policyAttachmentResource) Schema(
+	ctx context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: fmt.Sprintf(
+			"%s --- This resource attaches host groups to an existing %s. Only the host groups in this resource are managed, so attachments for the same policy can be split across configurations. When the policy is also managed by Terraform, add host_groups to the lifecycle ignore_changes of the policy resource.\n\n%s",
+			r.family.category,
+			r.family.label,
+			scopes.GenerateScopeDescription(r.family.scopes),
+		),
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: fmt.Sprintf("Identifier for the attachment, the id of the %s.", r.family.label),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"member_cid": membercid.Attribute(r.family.label + " attachment"),
+			"policy_id": schema.StringAttribute{
+				Required:    true,
+				Description: fmt.Sprintf("The id of the %s to attach host groups to.", r.family.label),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"host_groups": schema.SetAttribute{
+				Required:    true,
+				ElementType: types.StringType,
+				Description: fmt.Sprintf("Host Group ids to attach to the %s.", r.family.label),
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:    true,
+				Description: "Timestamp of the last Terraform update of the resource.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *policyAttachmentResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan policyAttachmentResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r, diags := r.withMemberCID(plan.MemberCID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	var hostGroupIDs []string
+	resp.Diagnostics.Append(plan.HostGroups.ElementsAs(ctx, &hostGroupIDs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(
+		r.updateHostGroups(ctx, hostgroups.AddHostGroup, hostGroupIDs, plan.PolicyID.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = plan.PolicyID
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, plan.ID)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *policyAttachmentResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state policyAttachmentResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r, diags := r.withMemberCID(state.MemberCID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	groups, found, err := r.family.getGroups(ctx, r.client, state.PolicyID.ValueString())
+	if tferrors.IsNotFound(err) || (err == nil && !found) {
+		tflog.Warn(
+			ctx,
+			fmt.Sprintf("%s not found, removing attachment from state", r.family.label),
+			map[string]interface{}{"id": state.PolicyID.ValueString()},
+		)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Error reading CrowdStrike %s", r.family.label),
+			fmt.Sprintf(
+				"Could not read %s (%s): %s",
+				r.family.label,
+				state.PolicyID.ValueString(),
+				tferrors.Message(err, r.family.scopes...),
+			),
+		)
+		return
+	}
+
+	managed := make(map[string]bool)
+	if !state.HostGroups.IsNull() {
+		var stateGroups []string
+		resp.Diagnostics.Append(state.HostGroups.ElementsAs(ctx, &stateGroups, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		for _, g := range stateGroups {
+			managed[g] = true
+		}
+	}
+
+	// only host groups managed by this resource are tracked, a null set means the resource was imported.
+	hostGroupIDs := []string{}
+	for _, g := range groups {
+		if g == nil || g.ID == nil {
+			continue
+		}
+
+		if state.HostGroups.IsNull() || managed[*g.ID] {
+			hostGroupIDs = append(hostGroupIDs, *g.ID)
+		}
+	}
+
+	hostGroups, diags := types.SetValueFrom(ctx, types.StringType, hostGroupIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.ID = state.PolicyID
+	state.HostGroups = hostGroups
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, state.ID)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *policyAttachmentResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var plan policyAttachmentResourceModel
+	var state policyAttachmentResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r, diags := r.withMemberCID(plan.MemberCID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	groupsToAdd, groupsToRemove, diags := utils.SetIDsToModify(ctx, plan.HostGroups, state.HostGroups)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(
+		r.updateHostGroups(ctx, hostgroups.AddHostGroup, groupsToAdd, plan.PolicyID.ValueString())...)
+	resp.Diagnostics.Append(
+		r.updateHostGroups(ctx, hostgroups.RemoveHostGroup, groupsToRemove, plan.PolicyID.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = plan.PolicyID
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, plan.ID)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *policyAttachmentResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var state policyAttachmentResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r, diags := r.withMemberCID(state.MemberCID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	var hostGroupIDs []string
+	resp.Diagnostics.Append(state.HostGroups.ElementsAs(ctx, &hostGroupIDs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = r.updateHostGroups(ctx, hostgroups.RemoveHostGroup, hostGroupIDs, state.PolicyID.ValueString())
+	if diags.HasError() {
+		// the policy may have been deleted outside of terraform, which also detaches the host groups.
+		_, found, err := r.family.getGroups(ctx, r.client, state.PolicyID.ValueString())
+		if tferrors.IsNotFound(err) || (err == nil && !found) {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(diags...)
+}
+
+// IdentitySchema defines the identity schema for the resource.
+func (r *policyAttachmentResource) IdentitySchema(
+	_ context.Context,
+	_ resource.IdentitySchemaRequest,
+	resp *resource.IdentitySchemaResponse,
+) {
+	resp.IdentitySchema = identity.Schema()
+}
+
+// ImportState implements the logic to support resource imports.
+func (r *policyAttachmentResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	// attachments of a child CID are imported as <member_cid>/<id>.
+	if memberCID, id, ok := membercid.ImportID(req.ID); ok {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("member_cid"), memberCID)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		req.ID = id
+	}
+
+	// Retrieve import ID or identity and save to policy_id attribute, every attached host group is imported.
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("policy_id"), path.Root("id"), req, resp)
+}
+
+// updateHostGroups will remove or add a slice of host groups to a policy.
+func (r *policyAttachmentResource) updateHostGroups(
+	ctx context.Context,
+	action hostgroups.HostGroupAction,
+	hostGroupIDs []string,
+	id string,
+) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if len(hostGroupIDs) == 0 {
+		return diags
+	}
+
+	actionMsg := "adding"
+	if action == hostgroups.RemoveHostGroup {
+		actionMsg = "removing"
+	}
+	name := "group_id"
+
+	var actionParams []*models.MsaspecActionParameter
+	for _, g := range hostGroupIDs {
+		gCopy := g
+		actionParams = append(actionParams, &models.MsaspecActionParameter{
+			Name:  &name,
+			Value: &gCopy,
+		})
+	}
+
+	apiErrors, err := r.family.performAction(ctx, r.client, action.String(), &models.MsaEntityActionRequestV2{
+		ActionParameters: actionParams,
+		Ids:              []string{id},
+	})
+
+	if err != nil {
+		diags.AddError(
+			fmt.Sprintf("Error updating %s host groups", r.family.label),
+			fmt.Sprintf(
+				"Could not %s %s (%s) host group (%s): %s",
+				actionMsg,
+				r.family.label,
+				id,
+				strings.Join(hostGroupIDs, ", "),
+				tferrors.Message(err, r.family.scopes...),
+			),
+		)
+		return diags
+	}
+
+	for _, apiErr := range apiErrors {
+		diags.AddError(
+			fmt.Sprintf("Error updating %s host groups", r.family.label),
+			fmt.Sprintf(
+				"Could not %s %s (%s) host group (%s): %s",
+				actionMsg,
+				r.family.label,
+				id,
+				apiErr.ID,
+				apiErr.String(),
+			),
+		)
+	}
+
+	return diags
+}
@@ -0,0 +1,134 @@
+package policyattachment_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/acctest"
+	sdkacctest "github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func testAccPreventionPolicyAttachmentConfig(rName string, groups string) string {
+	return acctest.ProviderConfig + fmt.Sprintf(`
+resource "crowdstrike_host_group" "first" {
+  name        = "%[1]s-first"
+  description = "made with terraform"
+  type        = "dynamic"
+}
+
+resource "crowdstrike_host_group" "second" {
+  name        = "%[1]s-second"
+  description = "made with terraform"
+  type        = "dynamic"
+}
+
+resource "crowdstrike_prevention_policy_linux" "test" {
+  name        = "%[1]s"
+  enabled     = false
+  description = "made with terraform"
+
+  lifecycle {
+    ignore_changes = [host_groups]
+  }
+}
+
+resource "crowdstrike_prevention_policy_attachment" "test" {
+  policy_id   = crowdstrike_prevention_policy_linux.test.id
+  host_groups = %[2]s
+}
+`, rName, groups)
+}
+
+func TestAccPreventionPolicyAttachmentResource(t *testing.T) {
+	rName := sdkacctest.RandomWithPrefix("tf-acceptance-test")
+	resourceName := "crowdstrike_prevention_policy_attachment.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPreventionPolicyAttachmentConfig(
+					rName,
+					"[crowdstrike_host_group.first.id]",
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						resourceName,
+						"policy_id",
+						"crowdstrike_prevention_policy_linux.test",
+						"id",
+					),
+					resource.TestCheckResourceAttr(resourceName, "host_groups.#", "1"),
+					resource.TestCheckResourceAttrSet(resourceName, "last_updated"),
+				),
+			},
+			{
+				Config: testAccPreventionPolicyAttachmentConfig(
+					rName,
+					"[crowdstrike_host_group.first.id, crowdstrike_host_group.second.id]",
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "host_groups.#", "2"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			{
+				Config: testAccPreventionPolicyAttachmentConfig(
+					rName,
+					"[crowdstrike_host_group.second.id]",
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "host_groups.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair(
+						resourceName,
+						"host_groups.*",
+						"crowdstrike_host_group.second",
+						"id",
+					),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPreventionPolicyAttachmentResource_identity(t *testing.T) {
+	rName := sdkacctest.RandomWithPrefix("tf-acceptance-test")
+	resourceName := "crowdstrike_prevention_policy_attachment.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPreventionPolicyAttachmentConfig(
+					rName,
+					"[crowdstrike_host_group.first.id]",
+				),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentityValueMatchesState(
+						resourceName,
+						tfjsonpath.New("id"),
+					),
+				},
+			},
+			{
+				ResourceName:    resourceName,
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+		},
+	})
+}
@@ -0,0 +1,331 @@
+package policyattachment
+
+import (
+	"context"
+
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/client/device_control_policies"
+	"github.com/crowdstrike/gofalcon/falcon/client/firewall_policies"
+	"github.com/crowdstrike/gofalcon/falcon/client/prevention_policies"
+	"github.com/crowdstrike/gofalcon/falcon/client/response_policies"
+	"github.com/crowdstrike/gofalcon/falcon/client/sensor_update_policies"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/scopes"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// policyFamily describes the api calls needed to attach host groups to a type of policy.
+type policyFamily struct {
+	// typeName is the resource type name without the provider prefix and _attachment suffix.
+	typeName string
+	// label is the human readable name of the policy type used in messages.
+	label string
+	// category is the documentation category of the resource.
+	category string
+	scopes   []scopes.Scope
+
+	// getGroups returns the host groups attached to the policy, found is false when the policy does not exist.
+	getGroups func(
+		ctx context.Context,
+		client *client.CrowdStrikeAPISpecification,
+		id string,
+	) (groups []*models.HostGroupsHostGroupV1, found bool, err error)
+
+	// performAction runs a policy action and returns the errors in the response payload.
+	performAction func(
+		ctx context.Context,
+		client *client.CrowdStrikeAPISpecification,
+		action string,
+		body *models.MsaEntityActionRequestV2,
+	) ([]*models.MsaAPIError, error)
+}
+
+var preventionPolicyFamily = policyFamily{
+	typeName: "prevention_policy",
+	label:    "prevention policy",
+	category: "Prevention Policy",
+	scopes: []scopes.Scope{
+		{
+			Name:  "Prevention policies",
+			Read:  true,
+			Write: true,
+		},
+	},
+	getGroups: func(
+		ctx context.Context,
+		client *client.CrowdStrikeAPISpecification,
+		id string,
+	) ([]*models.HostGroupsHostGroupV1, bool, error) {
+		res, err := client.PreventionPolicies.GetPreventionPolicies(
+			&prevention_policies.GetPreventionPoliciesParams{
+				Context: ctx,
+				Ids:     []string{id},
+			},
+		)
+		if err != nil {
+			return nil, false, err
+		}
+
+		if len(res.Payload.Resources) == 0 || res.Payload.Resources[0] == nil {
+			return nil, false, nil
+		}
+
+		return res.Payload.Resources[0].Groups, true, nil
+	},
+	performAction: func(
+		ctx context.Context,
+		client *client.CrowdStrikeAPISpecification,
+		action string,
+		body *models.MsaEntityActionRequestV2,
+	) ([]*models.MsaAPIError, error) {
+		res, err := client.PreventionPolicies.PerformPreventionPoliciesAction(
+			&prevention_policies.PerformPreventionPoliciesActionParams{
+				Context:    ctx,
+				ActionName: action,
+				Body:       body,
+			},
+		)
+		if err != nil || res.Payload == nil {
+			return nil, err
+		}
+
+		return res.Payload.Errors, nil
+	},
+}
+
+var sensorUpdatePolicyFamily = policyFamily{
+	typeName: "sensor_update_policy",
+	label:    "sensor update policy",
+	category: "Sensor Update Policy",
+	scopes: []scopes.Scope{
+		{
+			Name:  "Sensor update policies",
+			Read:  true,
+			Write: true,
+		},
+	},
+	getGroups: func(
+		ctx context.Context,
+		client *client.CrowdStrikeAPISpecification,
+		id string,
+	) ([]*models.HostGroupsHostGroupV1, bool, error) {
+		res, err := client.SensorUpdatePolicies.GetSensorUpdatePoliciesV2(
+			&sensor_update_policies.GetSensorUpdatePoliciesV2Params{
+				Context: ctx,
+				Ids:     []string{id},
+			},
+		)
+		if err != nil {
+			return nil, false, err
+		}
+
+		if len(res.Payload.Resources) == 0 || res.Payload.Resources[0] == nil {
+			return nil, false, nil
+		}
+
+		return res.Payload.Resources[0].Groups, true, nil
+	},
+	performAction: func(
+		ctx context.Context,
+		client *client.CrowdStrikeAPISpecification,
+		action string,
+		body *models.MsaEntityActionRequestV2,
+	) ([]*models.MsaAPIError, error) {
+		res, err := client.SensorUpdatePolicies.PerformSensorUpdatePoliciesAction(
+			&sensor_update_policies.PerformSensorUpdatePoliciesActionParams{
+				Context:    ctx,
+				ActionName: action,
+				Body:       body,
+			},
+		)
+		if err != nil || res.Payload == nil {
+			return nil, err
+		}
+
+		return res.Payload.Errors, nil
+	},
+}
+
+var responsePolicyFamily = policyFamily{
+	typeName: "response_policy",
+	label:    "response policy",
+	category: "Response Policy",
+	scopes: []scopes.Scope{
+		{
+			Name:  "Response policies",
+			Read:  true,
+			Write: true,
+		},
+	},
+	getGroups: func(
+		ctx context.Context,
+		client *client.CrowdStrikeAPISpecification,
+		id string,
+	) ([]*models.HostGroupsHostGroupV1, bool, error) {
+		res, err := client.ResponsePolicies.GetRTResponsePolicies(
+			&response_policies.GetRTResponsePoliciesParams{
+				Context: ctx,
+				Ids:     []string{id},
+			},
+		)
+		if err != nil {
+			return nil, false, err
+		}
+
+		if len(res.Payload.Resources) == 0 || res.Payload.Resources[0] == nil {
+			return nil, false, nil
+		}
+
+		return res.Payload.Resources[0].Groups, true, nil
+	},
+	performAction: func(
+		ctx context.Context,
+		client *client.CrowdStrikeAPISpecification,
+		action string,
+		body *models.MsaEntityActionRequestV2,
+	) ([]*models.MsaAPIError, error) {
+		res, err := client.ResponsePolicies.PerformRTResponsePoliciesAction(
+			&response_policies.PerformRTResponsePoliciesActionParams{
+				Context:    ctx,
+				ActionName: action,
+				Body:       body,
+			},
+		)
+		if err != nil || res.Payload == nil {
+			return nil, err
+		}
+
+		return res.Payload.Errors, nil
+	},
+}
+
+var firewallPolicyFamily = policyFamily{
+	typeName: "firewall_policy",
+	label:    "firewall policy",
+	category: "Firewall Policy",
+	scopes: []scopes.Scope{
+		{
+			Name:  "Firewall management",
+			Read:  true,
+			Write: true,
+		},
+	},
+	getGroups: func(
+		ctx context.Context,
+		client *client.CrowdStrikeAPISpecification,
+		id string,
+	) ([]*models.HostGroupsHostGroupV1, bool, error) {
+		res, err := client.FirewallPolicies.GetFirewallPolicies(
+			&firewall_policies.GetFirewallPoliciesParams{
+				Context: ctx,
+				Ids:     []string{id},
+			},
+		)
+		if err != nil {
+			return nil, false, err
+		}
+
+		if len(res.Payload.Resources) == 0 || res.Payload.Resources[0] == nil {
+			return nil, false, nil
+		}
+
+		return res.Payload.Resources[0].Groups, true, nil
+	},
+	performAction: func(
+		ctx context.Context,
+		client *client.CrowdStrikeAPISpecification,
+		action string,
+		body *models.MsaEntityActionRequestV2,
+	) ([]*models.MsaAPIError, error) {
+		res, err := client.FirewallPolicies.PerformFirewallPoliciesAction(
+			&firewall_policies.PerformFirewallPoliciesActionParams{
+				Context:    ctx,
+				ActionName: action,
+				Body:       body,
+			},
+		)
+		if err != nil || res.Payload == nil {
+			return nil, err
+		}
+
+		return res.Payload.Errors, nil
+	},
+}
+
+var deviceControlPolicyFamily = policyFamily{
+	typeName: "device_control_policy",
+	label:    "device control policy",
+	category: "Device Control Policy",
+	scopes: []scopes.Scope{
+		{
+			Name:  "Device control policies",
+			Read:  true,
+			Write: true,
+		},
+	},
+	getGroups: func(
+		ctx context.Context,
+		client *client.CrowdStrikeAPISpecification,
+		id string,
+	) ([]*models.HostGroupsHostGroupV1, bool, error) {
+		res, err := client.DeviceControlPolicies.GetDeviceControlPolicies(
+			&device_control_policies.GetDeviceControlPoliciesParams{
+				Context: ctx,
+				Ids:     []string{id},
+			},
+		)
+		if err != nil {
+			return nil, false, err
+		}
+
+		if len(res.Payload.Resources) == 0 || res.Payload.Resources[0] == nil {
+			return nil, false, nil
+		}
+
+		return res.Payload.Resources[0].Groups, true, nil
+	},
+	performAction: func(
+		ctx context.Context,
+		client *client.CrowdStrikeAPISpecification,
+		action string,
+		body *models.MsaEntityActionRequestV2,
+	) ([]*models.MsaAPIError, error) {
+		res, err := client.DeviceControlPolicies.PerformDeviceControlPoliciesAction(
+			&device_control_policies.PerformDeviceControlPoliciesActionParams{
+				Context:    ctx,
+				ActionName: action,
+				Body:       body,
+			},
+		)
+		if err != nil || res.Payload == nil {
+			return nil, err
+		}
+
+		return res.Payload.Errors, nil
+	},
+}
+
+// NewPreventionPolicyAttachmentResource is a helper function to simplify the provider implementation.
+func NewPreventionPolicyAttachmentResource() resource.Resource {
+	return &policyAttachmentResource{family: preventionPolicyFamily}
+}
+
+// NewSensorUpdatePolicyAttachmentResource is a helper function to simplify the provider implementation.
+func NewSensorUpdatePolicyAttachmentResource() resource.Resource {
+	return &policyAttachmentResource{family: sensorUpdatePolicyFamily}
+}
+
+// NewResponsePolicyAttachmentResource is a helper function to simplify the provider implementation.
+func NewResponsePolicyAttachmentResource() resource.Resource {
+	return &policyAttachmentResource{family: responsePolicyFamily}
+}
+
+// NewFirewallPolicyAttachmentResource is a helper function to simplify the provider implementation.
+func NewFirewallPolicyAttachmentResource() resource.Resource {
+	return &policyAttachmentResource{family: firewallPolicyFamily}
+}
+
+// NewDeviceControlPolicyAttachmentResource is a helper function to simplify the provider implementation.
+func NewDeviceControlPolicyAttachmentResource() resource.Resource {
+	return &policyAttachmentResource{family: deviceControlPolicyFamily}
+}
@@ -0,0 +1,8 @@
+package policyattachment
+
+import (
+	"time"
+)
+
+// defaultTimeout is used for an operation when the timeouts block does not configure one.
+const defaultTimeout = 20 * time.Minute
@@ -7,7 +7,12 @@ import (
 
 	"github.com/crowdstrike/gofalcon/falcon/client"
 	"github.com/crowdstrike/gofalcon/falcon/models"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/identity"
+	membercid "github.com/crowdstrike/terraform-provider-crowdstrike/internal/member_cid"
 	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/scopes"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/tferrors"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -16,6 +21,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -23,7 +29,10 @@ var (
 	_ resource.Resource                   = &preventionPolicyLinuxResource{}
 	_ resource.ResourceWithConfigure      = &preventionPolicyLinuxResource{}
 	_ resource.ResourceWithImportState    = &preventionPolicyLinuxResource{}
+	_ resource.ResourceWithIdentity       = &preventionPolicyLinuxResource{}
 	_ resource.ResourceWithValidateConfig = &preventionPolicyLinuxResource{}
+	_ resource.ResourceWithModifyPlan     = &preventionPolicyLinuxResource{}
+	_ membercid.Resource                  = &preventionPolicyLinuxResource{}
 )
 
 // NewPreventionPolicyLinuxResource is a helper function to simplify the provider implementation.
@@ -33,33 +42,42 @@ func NewPreventionPolicyLinuxResource() resource.Resource {
 
 // preventionPolicyLinuxResource is the resource implementation.
 type preventionPolicyLinuxResource struct {
-	client *client.CrowdStrikeAPISpecification
+	client        *client.CrowdStrikeAPISpecification
+	memberClients *membercid.Clients
 }
 
 // preventionPolicyLinuxResourceModel is the resource implementation.
 type preventionPolicyLinuxResourceModel struct {
-	ID                                 types.String `tfsdk:"id"`
-	Enabled                            types.Bool   `tfsdk:"enabled"`
-	Name                               types.String `tfsdk:"name"`
-	Description                        types.String `tfsdk:"description"`
-	HostGroups                         types.Set    `tfsdk:"host_groups"`
-	RuleGroups                         types.Set    `tfsdk:"ioa_rule_groups"`
-	LastUpdated                        types.String `tfsdk:"last_updated"`
-	CloudAntiMalware                   *mlSlider    `tfsdk:"cloud_anti_malware"`
-	OnSensorMLSlider                   *mlSlider    `tfsdk:"sensor_anti_malware"`
-	UnknownDetectionRelatedExecutables types.Bool   `tfsdk:"upload_unknown_detection_related_executables"`
-	UnknownExecutables                 types.Bool   `tfsdk:"upload_unknown_executables"`
-	ScriptBasedExecutionMonitoring     types.Bool   `tfsdk:"script_based_execution_monitoring"`
-	NextGenAV                          types.Bool   `tfsdk:"quarantine"`
-	CustomBlacklisting                 types.Bool   `tfsdk:"custom_blocking"`
-	PreventSuspiciousProcesses         types.Bool   `tfsdk:"prevent_suspicious_processes"`
-	DriftPrevention                    types.Bool   `tfsdk:"drift_prevention"`
-	FilesystemVisibility               types.Bool   `tfsdk:"filesystem_visibility"`
-	NetworkVisibility                  types.Bool   `tfsdk:"network_visibility"`
-	HTTPVisibility                     types.Bool   `tfsdk:"http_visibility"`
-	FTPVisibility                      types.Bool   `tfsdk:"ftp_visibility"`
-	TLSVisibility                      types.Bool   `tfsdk:"tls_visibility"`
-	EmailProtocolVisibility            types.Bool   `tfsdk:"email_protocol_visibility"`
+	ID                                 types.String   `tfsdk:"id"`
+	MemberCID                          types.String   `tfsdk:"member_cid"`
+	Enabled                            types.Bool     `tfsdk:"enabled"`
+	Name                               types.String   `tfsdk:"name"`
+	Description                        types.String   `tfsdk:"description"`
+	HostGroups                         types.Set      `tfsdk:"host_groups"`
+	Settings                           types.Map      `tfsdk:"settings"`
+	CloneFromID                        types.String   `tfsdk:"clone_from_id"`
+	ExportedSettingsJSON               types.String   `tfsdk:"exported_settings_json"`
+	RuleGroups                         types.Set      `tfsdk:"ioa_rule_groups"`
+	LastUpdated                        types.String   `tfsdk:"last_updated"`
+	DeletionProtection                 types.Bool     `tfsdk:"deletion_protection"`
+	AssignedHostCount                  types.Int64    `tfsdk:"assigned_host_count"`
+	AssignedHostCountByGroup           types.Map      `tfsdk:"assigned_host_count_by_group"`
+	CloudAntiMalware                   *mlSlider      `tfsdk:"cloud_anti_malware"`
+	OnSensorMLSlider                   *mlSlider      `tfsdk:"sensor_anti_malware"`
+	UnknownDetectionRelatedExecutables types.Bool     `tfsdk:"upload_unknown_detection_related_executables"`
+	UnknownExecutables                 types.Bool     `tfsdk:"upload_unknown_executables"`
+	ScriptBasedExecutionMonitoring     types.Bool     `tfsdk:"script_based_execution_monitoring"`
+	NextGenAV                          types.Bool     `tfsdk:"quarantine"`
+	CustomBlacklisting                 types.Bool     `tfsdk:"custom_blocking"`
+	PreventSuspiciousProcesses         types.Bool     `tfsdk:"prevent_suspicious_processes"`
+	DriftPrevention                    types.Bool     `tfsdk:"drift_prevention"`
+	FilesystemVisibility               types.Bool     `tfsdk:"filesystem_visibility"`
+	NetworkVisibility                  types.Bool     `tfsdk:"network_visibility"`
+	HTTPVisibility                     types.Bool     `tfsdk:"http_visibility"`
+	FTPVisibility                      types.Bool     `tfsdk:"ftp_visibility"`
+	TLSVisibility                      types.Bool     `tfsdk:"tls_visibility"`
+	EmailProtocolVisibility            types.Bool     `tfsdk:"email_protocol_visibility"`
+	Timeouts                           timeouts.Value `tfsdk:"timeouts"`
 }
 
 // Configure adds the provider configured client to the resource.
@@ -89,6 +107,24 @@ func (r *preventionPolicyLinuxResource) Configure(
 	r.client = client
 }
 
+// SetMemberClients adds the clients used when member_cid is set.
+func (r *preventionPolicyLinuxResource) SetMemberClients(m *membercid.Clients) {
+	r.memberClients = m
+}
+
+// withMemberCID returns a copy of the resource that uses the client of memberCID.
+func (r *preventionPolicyLinuxResource) withMemberCID(memberCID types.String) (*preventionPolicyLinuxResource, diag.Diagnostics) {
+	c, diags := membercid.ClientFor(r.client, r.memberClients, memberCID)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	scoped := *r
+	scoped.client = c
+
+	return &scoped, diags
+}
+
 // Metadata returns the resource type name.
 func (r *preventionPolicyLinuxResource) Metadata(
 	_ context.Context,
@@ -100,7 +136,7 @@ func (r *preventionPolicyLinuxResource) Metadata(
 
 // Schema defines the schema for the resource.
 func (r *preventionPolicyLinuxResource) Schema(
-	_ context.Context,
+	ctx context.Context,
 	_ resource.SchemaRequest,
 	resp *resource.SchemaResponse,
 ) {
@@ -117,10 +153,21 @@ func (r *preventionPolicyLinuxResource) Schema(
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"member_cid": membercid.Attribute("prevention policy"),
 			"last_updated": schema.StringAttribute{
 				Computed:    true,
 				Description: "Timestamp of the last Terraform update of the resource.",
 			},
+			"deletion_protection": utils.DeletionProtectionAttribute("prevention policy"),
+			"assigned_host_count": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The number of hosts assigned to the prevention policy.",
+			},
+			"assigned_host_count_by_group": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.Int64Type,
+				Description: "The number of hosts assigned to the prevention policy through each of its host groups, keyed by host group id.",
+			},
 			"name": schema.StringAttribute{
 				Required:    true,
 				Description: "Name of the prevention policy.",
@@ -136,6 +183,9 @@ func (r *preventionPolicyLinuxResource) Schema(
 				ElementType: types.StringType,
 				Description: "Host Group ids to attach to the prevention policy.",
 			},
+			"settings":               settingsAttribute(),
+			"clone_from_id":          cloneFromIDAttribute(),
+			"exported_settings_json": exportedSettingsAttribute(),
 			"ioa_rule_groups": schema.SetAttribute{
 				Optional:    true,
 				ElementType: types.StringType,
@@ -191,6 +241,14 @@ func (r *preventionPolicyLinuxResource) Schema(
 				"Allows the sensor to monitor SMTP, IMAP, and POP3 traffic for malicious patterns and improved detections.",
 			),
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -208,13 +266,36 @@ func (r *preventionPolicyLinuxResource) Create(
 		return
 	}
 
+	r, diags = r.withMemberCID(plan.MemberCID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	preventionSettings := r.generatePreventionSettings(plan)
+	additionalSettings, diags := generateAdditionalSettings(plan.Settings, preventionSettings)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	preventionSettings = append(preventionSettings, additionalSettings...)
+
 	res, diags := createPreventionPolicy(
 		ctx,
 		r.client,
 		plan.Name.ValueString(),
 		plan.Description.ValueString(),
 		linuxPlatformName,
+		plan.CloneFromID.ValueString(),
 		preventionSettings,
 	)
 
@@ -249,6 +330,18 @@ func (r *preventionPolicyLinuxResource) Create(
 
 	r.assignPreventionSettings(&plan, preventionPolicy.PreventionSettings)
 
+	plan.Settings, diags = assignAdditionalSettings(plan.Settings, preventionPolicy.PreventionSettings)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ExportedSettingsJSON, diags = exportSettings(preventionPolicy.PreventionSettings)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	emptySet, diags := types.SetValueFrom(ctx, types.StringType, []string{})
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -267,11 +360,24 @@ func (r *preventionPolicyLinuxResource) Create(
 		return
 	}
 
+	plan.AssignedHostCount, diags = getPolicyMemberCount(ctx, r.client, plan.ID.ValueString())
+	resp.Diagnostics.Append(diags...)
+
+	plan.AssignedHostCountByGroup, diags = getHostGroupMemberCounts(
+		ctx,
+		r.client,
+		plan.ID.ValueString(),
+		plan.HostGroups,
+	)
+	resp.Diagnostics.Append(diags...)
+
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, plan.ID)...)
 }
 
 // Read refreshes the Terraform state with the latest data.
@@ -287,7 +393,31 @@ func (r *preventionPolicyLinuxResource) Read(
 		return
 	}
 
-	policy, diags := getPreventionPolicy(ctx, r.client, state.ID.ValueString())
+	r, diags = r.withMemberCID(state.MemberCID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	policy, assignedHostCount, diags := getPreventionPolicyWithMemberCount(ctx, r.client, state.ID.ValueString())
+	if tferrors.HasNotFoundError(diags) {
+		tflog.Warn(
+			ctx,
+			"Prevention policy not found, removing from state",
+			map[string]interface{}{"id": state.ID.ValueString()},
+		)
+		resp.State.RemoveResource(ctx)
+		return
+	}
 
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -300,6 +430,18 @@ func (r *preventionPolicyLinuxResource) Read(
 	state.Enabled = types.BoolValue(*policy.Enabled)
 	r.assignPreventionSettings(&state, policy.PreventionSettings)
 
+	state.Settings, diags = assignAdditionalSettings(state.Settings, policy.PreventionSettings)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.ExportedSettingsJSON, diags = exportSettings(policy.PreventionSettings)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	resp.Diagnostics.Append(r.assignHostGroups(ctx, &state, policy.Groups)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -310,12 +452,29 @@ func (r *preventionPolicyLinuxResource) Read(
 		return
 	}
 
+	state.AssignedHostCount = assignedHostCount
+
+	state.AssignedHostCountByGroup, diags = getHostGroupMemberCounts(
+		ctx,
+		r.client,
+		state.ID.ValueString(),
+		state.HostGroups,
+	)
+	resp.Diagnostics.Append(diags...)
+
+	// deletion_protection is not stored in Falcon, imported resources use the default.
+	if state.DeletionProtection.IsNull() {
+		state.DeletionProtection = types.BoolValue(false)
+	}
+
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, state.ID)...)
 }
 
 // Update updates the resource and sets the updated Terraform state on success.
@@ -332,6 +491,21 @@ func (r *preventionPolicyLinuxResource) Update(
 		return
 	}
 
+	r, diags = r.withMemberCID(plan.MemberCID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	// Retrieve values from state
 	var state preventionPolicyLinuxResourceModel
 	diags = req.State.Get(ctx, &state)
@@ -353,6 +527,12 @@ func (r *preventionPolicyLinuxResource) Update(
 	}
 
 	preventionSettings := r.generatePreventionSettings(plan)
+	additionalSettings, diags := generateAdditionalSettings(plan.Settings, preventionSettings)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	preventionSettings = append(preventionSettings, additionalSettings...)
 
 	preventionPolicy, diags := updatePreventionPolicy(
 		ctx,
@@ -374,6 +554,18 @@ func (r *preventionPolicyLinuxResource) Update(
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 	r.assignPreventionSettings(&plan, preventionPolicy.PreventionSettings)
 
+	plan.Settings, diags = assignAdditionalSettings(plan.Settings, preventionPolicy.PreventionSettings)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ExportedSettingsJSON, diags = exportSettings(preventionPolicy.PreventionSettings)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	if plan.Enabled.ValueBool() != state.Enabled.ValueBool() {
 		actionResp, diags := updatePolicyEnabledState(
 			ctx,
@@ -401,6 +593,17 @@ func (r *preventionPolicyLinuxResource) Update(
 		return
 	}
 
+	plan.AssignedHostCount, diags = getPolicyMemberCount(ctx, r.client, plan.ID.ValueString())
+	resp.Diagnostics.Append(diags...)
+
+	plan.AssignedHostCountByGroup, diags = getHostGroupMemberCounts(
+		ctx,
+		r.client,
+		plan.ID.ValueString(),
+		plan.HostGroups,
+	)
+	resp.Diagnostics.Append(diags...)
+
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -412,6 +615,8 @@ func (r *preventionPolicyLinuxResource) Update(
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, plan.ID)...)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -427,19 +632,85 @@ func (r *preventionPolicyLinuxResource) Delete(
 		return
 	}
 
+	r, diags = r.withMemberCID(state.MemberCID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.DeletionProtection.ValueBool() {
+		resp.Diagnostics.Append(utils.DeletionProtectionError("prevention policy", state.ID.ValueString()))
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	id := state.ID.ValueString()
 
 	resp.Diagnostics.Append(deletePreventionPolicy(ctx, r.client, id)...)
 }
 
+// IdentitySchema defines the identity schema for the resource.
+func (r *preventionPolicyLinuxResource) IdentitySchema(
+	_ context.Context,
+	_ resource.IdentitySchemaRequest,
+	resp *resource.IdentitySchemaResponse,
+) {
+	resp.IdentitySchema = identity.Schema()
+}
+
 // ImportState implements the logic to support resource imports.
 func (r *preventionPolicyLinuxResource) ImportState(
 	ctx context.Context,
 	req resource.ImportStateRequest,
 	resp *resource.ImportStateResponse,
 ) {
-	// Retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	// prevention policies of a child CID are imported as <member_cid>/<id> or <member_cid>/name:<name>.
+	if memberCID, id, ok := membercid.ImportID(req.ID); ok {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("member_cid"), memberCID)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		scoped, diags := r.withMemberCID(types.StringValue(memberCID))
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		r = scoped
+		req.ID = id
+	}
+
+	if name, ok := utils.NameFromImportID(req.ID); ok {
+		id, diags := getPreventionPolicyIDByName(ctx, r.client, linuxPlatformName, name)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+		return
+	}
+
+	// Retrieve import ID or identity and save to id attribute
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
+}
+
+// ModifyPlan warns when the plan weakens prevention settings of the policy.
+func (r *preventionPolicyLinuxResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	resp.Diagnostics.Append(downgradeWarning(req.State.Raw, req.Plan.Raw)...)
 }
 
 // ValidateConfig runs during validate, plan, and apply
@@ -481,12 +752,18 @@ func (r *preventionPolicyLinuxResource) assignRuleGroups(
 ) diag.Diagnostics {
 
 	var ruleGroups []string
-	for _, hostGroup := range groups {
-		ruleGroups = append(ruleGroups, *hostGroup.ID)
+	for _, ruleGroup := range groups {
+		ruleGroups = append(ruleGroups, *ruleGroup.ID)
+	}
+
+	// keep an empty set in state when the configuration uses one, otherwise
+	// ioa_rule_groups = [] would show a diff against null on every plan.
+	if len(ruleGroups) == 0 && !config.RuleGroups.IsNull() {
+		ruleGroups = []string{}
 	}
 
-	hostGroupIDs, diags := types.SetValueFrom(ctx, types.StringType, ruleGroups)
-	config.RuleGroups = hostGroupIDs
+	ruleGroupIDs, diags := types.SetValueFrom(ctx, types.StringType, ruleGroups)
+	config.RuleGroups = ruleGroupIDs
 
 	return diags
 }
@@ -2,14 +2,19 @@ package preventionpolicy
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"reflect"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/crowdstrike/gofalcon/falcon/client"
 	"github.com/crowdstrike/gofalcon/falcon/client/prevention_policies"
 	"github.com/crowdstrike/gofalcon/falcon/models"
 	hostgroups "github.com/crowdstrike/terraform-provider-crowdstrike/internal/host_groups"
 	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/scopes"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/tferrors"
 	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/utils"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -18,10 +23,17 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"golang.org/x/sync/errgroup"
 )
 
+// defaultTimeout is used for an operation when the timeouts block does not configure one.
+const defaultTimeout = 20 * time.Minute
+
 var windowsPlatformName = "Windows"
 var linuxPlatformName = "Linux"
 var macPlatformName = "Mac"
@@ -120,7 +132,7 @@ func updateRuleGroups(
 			actionMsg,
 			id,
 			strings.Join(ruleGroupIDs, ", "),
-			err.Error(),
+			tferrors.Message(err, apiScopes...),
 		))
 	}
 
@@ -308,7 +320,7 @@ func updatePolicyEnabledState(
 			fmt.Sprintf(
 				"Could not %s prevention policy, unexpected error: \n\n %s",
 				state,
-				err.Error(),
+				tferrors.Message(err, apiScopes...),
 			),
 		)
 	}
@@ -426,7 +438,7 @@ func deletePreventionPolicy(
 	if err != nil {
 		diags.AddError(
 			"Error deleting prevention policy",
-			fmt.Sprintf("Could not delete prevention policy: %s \n\n %s", id, err.Error()),
+			fmt.Sprintf("Could not delete prevention policy: %s \n\n %s", id, tferrors.Message(err, apiScopes...)),
 		)
 		return diags
 	}
@@ -468,7 +480,7 @@ func updatePreventionPolicy(
 			"Error updating prevention policy",
 			fmt.Sprintf(
 				"Could not update prevention policy, unexpected error: \n\n%s",
-				err.Error(),
+				tferrors.Message(err, apiScopes...),
 			),
 		)
 		return preventionPolicy, diags
@@ -502,25 +514,25 @@ func getPreventionPolicy(
 		},
 	)
 
-	if err != nil {
-		diags.AddError(
+	if tferrors.IsNotFound(err) || (err == nil && len(res.GetPayload().Resources) == 0) {
+		diags.Append(tferrors.NewNotFoundError(
 			"Error reading CrowdStrike prevention policy",
 			fmt.Sprintf(
 				"Could not read CrowdStrike prevention policy: %s \n\n %s",
 				id,
-				err.Error(),
+				"No policy found",
 			),
-		)
+		))
 		return preventionPolicy, diags
 	}
 
-	if len(res.GetPayload().Resources) == 0 {
+	if err != nil {
 		diags.AddError(
 			"Error reading CrowdStrike prevention policy",
 			fmt.Sprintf(
 				"Could not read CrowdStrike prevention policy: %s \n\n %s",
 				id,
-				"No policy found",
+				tferrors.Message(err, apiScopes...),
 			),
 		)
 		return preventionPolicy, diags
@@ -530,11 +542,75 @@ func getPreventionPolicy(
 	return preventionPolicy, diags
 }
 
-// createPreventionPolicy creates a new prevention policy.
+// getPreventionPolicyWithMemberCount retrieves a prevention policy and the number of hosts
+// assigned to it. The requests are independent so they are made concurrently.
+func getPreventionPolicyWithMemberCount(
+	ctx context.Context,
+	client *client.CrowdStrikeAPISpecification,
+	id string,
+) (*models.PreventionPolicyV1, types.Int64, diag.Diagnostics) {
+	var policy *models.PreventionPolicyV1
+	var policyDiags diag.Diagnostics
+	var count types.Int64
+	var countDiags diag.Diagnostics
+
+	var g errgroup.Group
+	g.Go(func() error {
+		policy, policyDiags = getPreventionPolicy(ctx, client, id)
+		return nil
+	})
+	g.Go(func() error {
+		count, countDiags = getPolicyMemberCount(ctx, client, id)
+		return nil
+	})
+	_ = g.Wait()
+
+	// The member count of a missing policy is meaningless, return only the not found error.
+	if policyDiags.HasError() {
+		return policy, count, policyDiags
+	}
+
+	return policy, count, countDiags
+}
+
+// getPreventionPolicyIDByName looks up the id of the prevention policy with the given name and platform.
+func getPreventionPolicyIDByName(
+	ctx context.Context,
+	client *client.CrowdStrikeAPISpecification,
+	platformName string,
+	name string,
+) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	filter := fmt.Sprintf(
+		"name:%s+platform_name:%s",
+		utils.QuoteFQL(name),
+		utils.QuoteFQL(platformName),
+	)
+	res, err := client.PreventionPolicies.QueryPreventionPolicies(
+		&prevention_policies.QueryPreventionPoliciesParams{
+			Context: ctx,
+			Filter:  &filter,
+		},
+	)
+
+	if err != nil {
+		diags.AddError(
+			"Error importing CrowdStrike prevention policy",
+			"Could not look up prevention policy by name: "+tferrors.Message(err, apiScopes...),
+		)
+		return "", diags
+	}
+
+	return utils.IDFromNameLookup("prevention policy", name, res.Payload.Resources)
+}
+
+// createPreventionPolicy creates a new prevention policy. When cloneID is set the policy starts
+// with the settings of that policy, and preventionSettings are applied on top.
 func createPreventionPolicy(
 	ctx context.Context,
 	client *client.CrowdStrikeAPISpecification,
-	name, description, platformName string,
+	name, description, platformName, cloneID string,
 	preventionSettings []*models.PreventionSettingReqV1,
 ) (*prevention_policies.CreatePreventionPoliciesCreated, diag.Diagnostics) {
 	var diags diag.Diagnostics
@@ -548,6 +624,7 @@ func createPreventionPolicy(
 					Name:         &name,
 					Description:  description,
 					PlatformName: &platformName,
+					CloneID:      cloneID,
 				},
 			},
 		},
@@ -555,7 +632,11 @@ func createPreventionPolicy(
 
 	createParams.Body.Resources[0].Settings = preventionSettings
 
-	res, err := client.PreventionPolicies.CreatePreventionPolicies(&createParams)
+	err := utils.RetryOnConflict(ctx, func() error {
+		var err error
+		res, err = client.PreventionPolicies.CreatePreventionPolicies(&createParams)
+		return err
+	})
 
 	// todo: if we should handle scope and timeout errors instead of giving a vague error
 	if err != nil {
@@ -567,7 +648,7 @@ func createPreventionPolicy(
 		} else {
 			diags.AddError(
 				"Error creating prevention policy",
-				"Could not create prevention policy, unexpected error: "+err.Error(),
+				"Could not create prevention policy, unexpected error: "+tferrors.Message(err, apiScopes...),
 			)
 		}
 	}
@@ -627,7 +708,7 @@ func updateHostGroups(
 			actionMsg,
 			id,
 			strings.Join(hostGroupIDs, ", "),
-			err.Error(),
+			tferrors.Message(err, apiScopes...),
 		))
 	}
 
@@ -674,3 +755,429 @@ func syncHostGroups(
 
 	return diags
 }
+
+// getPolicyMemberCount returns the number of hosts assigned to a prevention policy. The
+// count is informational, so a failure to read it is a warning and the count is null
+// rather than an error that would leave an applied change out of the state.
+func getPolicyMemberCount(
+	ctx context.Context,
+	client *client.CrowdStrikeAPISpecification,
+	id string,
+) (types.Int64, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	total, err := queryPolicyMemberTotal(ctx, client, id, nil)
+	if err != nil {
+		diags.AddWarning(
+			"Unable to read CrowdStrike prevention policy members",
+			fmt.Sprintf(
+				"Could not read members of prevention policy (%s), assigned_host_count will be empty: %s",
+				id,
+				tferrors.Message(err, apiScopes...),
+			),
+		)
+		return types.Int64Null(), diags
+	}
+
+	return types.Int64Value(total), diags
+}
+
+// getHostGroupMemberCounts returns the number of hosts assigned to a prevention policy
+// through each of the host groups, keyed by host group id. Like getPolicyMemberCount, a
+// failure to read the counts is a warning and the counts are null.
+func getHostGroupMemberCounts(
+	ctx context.Context,
+	client *client.CrowdStrikeAPISpecification,
+	id string,
+	hostGroups types.Set,
+) (types.Map, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	counts := map[string]attr.Value{}
+
+	if hostGroups.IsNull() || hostGroups.IsUnknown() {
+		return types.MapValueMust(types.Int64Type, counts), diags
+	}
+
+	var groupIDs []string
+	diags.Append(hostGroups.ElementsAs(ctx, &groupIDs, false)...)
+	if diags.HasError() {
+		return types.MapNull(types.Int64Type), diags
+	}
+
+	totals := make([]int64, len(groupIDs))
+
+	var g errgroup.Group
+	for i, groupID := range groupIDs {
+		g.Go(func() (err error) {
+			filter := "groups:" + utils.QuoteFQL(groupID)
+			totals[i], err = queryPolicyMemberTotal(ctx, client, id, &filter)
+			return err
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		diags.AddWarning(
+			"Unable to read CrowdStrike prevention policy members",
+			fmt.Sprintf(
+				"Could not read members of prevention policy (%s) by host group, assigned_host_count_by_group will be empty: %s",
+				id,
+				tferrors.Message(err, apiScopes...),
+			),
+		)
+		return types.MapNull(types.Int64Type), diags
+	}
+
+	for i, groupID := range groupIDs {
+		counts[groupID] = types.Int64Value(totals[i])
+	}
+
+	return types.MapValueMust(types.Int64Type, counts), diags
+}
+
+// queryPolicyMemberTotal returns the number of hosts assigned to a prevention policy that
+// match filter.
+func queryPolicyMemberTotal(
+	ctx context.Context,
+	client *client.CrowdStrikeAPISpecification,
+	id string,
+	filter *string,
+) (int64, error) {
+	limit := int64(1)
+
+	res, err := client.PreventionPolicies.QueryPreventionPolicyMembers(
+		&prevention_policies.QueryPreventionPolicyMembersParams{
+			Context: ctx,
+			ID:      &id,
+			Filter:  filter,
+			Limit:   &limit,
+		},
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	if res.Payload.Meta == nil || res.Payload.Meta.Pagination == nil ||
+		res.Payload.Meta.Pagination.Total == nil {
+		return 0, nil
+	}
+
+	return *res.Payload.Meta.Pagination.Total, nil
+}
+
+// settingsAttribute returns the schema for prevention settings without a dedicated attribute.
+func settingsAttribute() schema.MapAttribute {
+	return schema.MapAttribute{
+		Optional:    true,
+		ElementType: types.StringType,
+		Description: "Prevention settings that do not have a dedicated attribute yet, keyed by the setting id. Each value is the json encoded setting value, for example jsonencode({ enabled = true }) for a toggle or jsonencode({ detection = \"MODERATE\", prevention = \"CAUTIOUS\" }) for a slider. The api validates the settings. Only the settings listed are managed.",
+	}
+}
+
+// cloneFromIDAttribute returns the schema for the policy a new prevention policy is cloned from.
+func cloneFromIDAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional:    true,
+		Description: "Id of an existing prevention policy of the same platform to clone when the policy is created. The new policy starts with the settings of the source policy, then the settings in the configuration are applied. Settings with a dedicated attribute always take the configured or default value, so only settings without a dedicated attribute that are not listed in settings keep the value of the source policy. Changing clone_from_id forces a new policy.",
+		Validators: []validator.String{
+			stringvalidator.LengthAtLeast(1),
+		},
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+}
+
+// generateAdditionalSettings maps the settings attribute to api params for create and update.
+// Settings that are already managed by a dedicated attribute are rejected.
+func generateAdditionalSettings(
+	settings types.Map,
+	managed []*models.PreventionSettingReqV1,
+) ([]*models.PreventionSettingReqV1, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	preventionSettings := []*models.PreventionSettingReqV1{}
+
+	if settings.IsNull() || settings.IsUnknown() {
+		return preventionSettings, diags
+	}
+
+	managedIDs := make(map[string]bool, len(managed))
+	for _, s := range managed {
+		managedIDs[*s.ID] = true
+	}
+
+	for k, v := range settings.Elements() {
+		kCopy := k
+		value, ok := v.(types.String)
+		if !ok || value.IsNull() || value.IsUnknown() {
+			continue
+		}
+
+		if managedIDs[k] {
+			diags.AddAttributeError(
+				path.Root("settings").AtMapKey(k),
+				"Prevention setting managed by another attribute",
+				fmt.Sprintf("The prevention setting %s has a dedicated attribute, use that attribute instead.", k),
+			)
+			continue
+		}
+
+		var settingValue interface{}
+		if err := json.Unmarshal([]byte(value.ValueString()), &settingValue); err != nil {
+			diags.AddAttributeError(
+				path.Root("settings").AtMapKey(k),
+				"Invalid prevention setting value",
+				fmt.Sprintf("The value of prevention setting %s must be valid json: %s", k, err.Error()),
+			)
+			continue
+		}
+
+		preventionSettings = append(preventionSettings, &models.PreventionSettingReqV1{
+			ID:    &kCopy,
+			Value: settingValue,
+		})
+	}
+
+	return preventionSettings, diags
+}
+
+// exportedSettingsAttribute returns the schema for the json export of every prevention setting.
+func exportedSettingsAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Computed:    true,
+		Description: "Every prevention setting of the policy reported by the api as a json object keyed by the setting id, including settings without a dedicated attribute. Keys are sorted so the value can be compared with a json baseline, for example jsondecode(exported_settings_json) == jsondecode(file(\"baseline.json\")).",
+	}
+}
+
+// exportSettings encodes every prevention setting as a json object keyed by setting id.
+func exportSettings(categories []*models.PreventionCategoryRespV1) (types.String, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	settings := map[string]interface{}{}
+	for _, c := range categories {
+		for _, s := range c.Settings {
+			if s.ID != nil {
+				settings[*s.ID] = s.Value
+			}
+		}
+	}
+
+	// encoding/json sorts map keys, so the export is stable between reads.
+	encoded, err := json.Marshal(settings)
+	if err != nil {
+		diags.AddError(
+			"Error exporting prevention settings",
+			"Could not encode the prevention settings: "+err.Error(),
+		)
+		return types.StringNull(), diags
+	}
+
+	return types.StringValue(string(encoded)), diags
+}
+
+// assignAdditionalSettings refreshes the settings attribute from the api. Only settings already
+// in prior are tracked, and prior values are kept when they are equal to the api value.
+func assignAdditionalSettings(
+	prior types.Map,
+	categories []*models.PreventionCategoryRespV1,
+) (types.Map, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if prior.IsNull() || prior.IsUnknown() {
+		return prior, diags
+	}
+
+	apiValues := map[string]interface{}{}
+	for _, c := range categories {
+		for _, s := range c.Settings {
+			if s.ID != nil {
+				apiValues[*s.ID] = s.Value
+			}
+		}
+	}
+
+	settings := map[string]attr.Value{}
+	for k, v := range prior.Elements() {
+		apiValue, ok := apiValues[k]
+		if !ok {
+			continue
+		}
+
+		if priorValue, ok := v.(types.String); ok {
+			var decoded interface{}
+			if err := json.Unmarshal([]byte(priorValue.ValueString()), &decoded); err == nil &&
+				reflect.DeepEqual(decoded, apiValue) {
+				settings[k] = priorValue
+				continue
+			}
+		}
+
+		encoded, err := json.Marshal(apiValue)
+		if err != nil {
+			diags.AddError(
+				"Error reading prevention setting",
+				fmt.Sprintf("Could not encode the value of prevention setting %s: %s", k, err.Error()),
+			)
+			continue
+		}
+
+		settings[k] = types.StringValue(string(encoded))
+	}
+
+	settingsMap, mapDiags := types.MapValue(types.StringType, settings)
+	diags.Append(mapDiags...)
+
+	return settingsMap, diags
+}
+
+// nonSettingToggles are the bool attributes of a prevention policy that are not prevention settings.
+var nonSettingToggles = map[string]bool{
+	"enabled":             true,
+	"deletion_protection": true,
+}
+
+// downgradeWarning returns a warning listing the prevention settings the plan weakens
+// compared to the prior state: toggles that are disabled and ml slider levels that are
+// lowered, whether they are set by a dedicated attribute or the settings map. Nothing is
+// returned for creates, deletes, or plans that do not weaken a setting.
+func downgradeWarning(prior, plan tftypes.Value) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if prior.IsNull() || plan.IsNull() || !prior.IsKnown() || !plan.IsKnown() {
+		return diags
+	}
+
+	var priorAttrs, planAttrs map[string]tftypes.Value
+	if prior.As(&priorAttrs) != nil || plan.As(&planAttrs) != nil {
+		return diags
+	}
+
+	names := make([]string, 0, len(planAttrs))
+	for name := range planAttrs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var downgrades []string
+	for _, name := range names {
+		priorValue, ok := priorAttrs[name]
+		if !ok {
+			continue
+		}
+
+		planValue := planAttrs[name]
+		if !priorValue.IsKnown() || !planValue.IsKnown() || priorValue.IsNull() || planValue.IsNull() {
+			continue
+		}
+
+		switch {
+		case planValue.Type().Is(tftypes.Bool):
+			var wasEnabled, enabled bool
+			if nonSettingToggles[name] || priorValue.As(&wasEnabled) != nil || planValue.As(&enabled) != nil {
+				continue
+			}
+
+			if wasEnabled && !enabled {
+				downgrades = append(downgrades, fmt.Sprintf("%s: enabled -> disabled", name))
+			}
+		case planValue.Type().Is(tftypes.Object{}):
+			downgrades = append(downgrades, mlSliderDowngrades(name, priorValue, planValue)...)
+		case name == "settings" && planValue.Type().Is(tftypes.Map{}):
+			downgrades = append(downgrades, additionalSettingsDowngrades(priorValue, planValue)...)
+		}
+	}
+
+	if len(downgrades) > 0 {
+		diags.AddWarning(
+			"Prevention settings will be downgraded",
+			fmt.Sprintf(
+				"This plan weakens the following prevention settings:\n\n  - %s\n\nReview the change before applying, hosts in the policy will be less protected.",
+				strings.Join(downgrades, "\n  - "),
+			),
+		)
+	}
+
+	return diags
+}
+
+// additionalSettingsDowngrades returns the toggles and ml slider levels of the settings
+// map that the plan weakens. Values are the json encoded setting values, so a toggle is
+// {"enabled": bool} and a slider is {"detection": level, "prevention": level}.
+func additionalSettingsDowngrades(prior, plan tftypes.Value) []string {
+	var priorSettings, planSettings map[string]tftypes.Value
+	if prior.As(&priorSettings) != nil || plan.As(&planSettings) != nil {
+		return nil
+	}
+
+	ids := make([]string, 0, len(planSettings))
+	for id := range planSettings {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var downgrades []string
+	for _, id := range ids {
+		var from, to map[string]interface{}
+		if !decodeSettingValue(priorSettings[id], &from) || !decodeSettingValue(planSettings[id], &to) {
+			continue
+		}
+
+		name := fmt.Sprintf("settings[%q]", id)
+
+		wasEnabled, fromOK := from["enabled"].(bool)
+		enabled, toOK := to["enabled"].(bool)
+		if fromOK && toOK && wasEnabled && !enabled {
+			downgrades = append(downgrades, fmt.Sprintf("%s: enabled -> disabled", name))
+		}
+
+		for _, level := range []string{"detection", "prevention"} {
+			fromLevel, fromOK := from[level].(string)
+			toLevel, toOK := to[level].(string)
+			if !fromOK || !toOK {
+				continue
+			}
+
+			fromRank, fromOK := mapMlSliderLevels[fromLevel]
+			toRank, toOK := mapMlSliderLevels[toLevel]
+			if fromOK && toOK && toRank < fromRank {
+				downgrades = append(downgrades, fmt.Sprintf("%s.%s: %s -> %s", name, level, fromLevel, toLevel))
+			}
+		}
+	}
+
+	return downgrades
+}
+
+// decodeSettingValue decodes a json encoded setting value of the settings map into v. It
+// returns false when the value is null, unknown, or not a json object.
+func decodeSettingValue(value tftypes.Value, v *map[string]interface{}) bool {
+	var encoded string
+	if !value.IsKnown() || value.IsNull() || value.As(&encoded) != nil {
+		return false
+	}
+
+	return json.Unmarshal([]byte(encoded), v) == nil
+}
+
+// mlSliderDowngrades returns the levels of a ml slider attribute that the plan lowers.
+func mlSliderDowngrades(name string, prior, plan tftypes.Value) []string {
+	var priorLevels, planLevels map[string]tftypes.Value
+	if prior.As(&priorLevels) != nil || plan.As(&planLevels) != nil {
+		return nil
+	}
+
+	var downgrades []string
+	for _, level := range []string{"detection", "prevention"} {
+		var from, to string
+		if priorLevels[level].As(&from) != nil || planLevels[level].As(&to) != nil {
+			continue
+		}
+
+		fromRank, fromOK := mapMlSliderLevels[from]
+		toRank, toOK := mapMlSliderLevels[to]
+		if fromOK && toOK && toRank < fromRank {
+			downgrades = append(downgrades, fmt.Sprintf("%s.%s: %s -> %s", name, level, from, to))
+		}
+	}
+
+	return downgrades
+}
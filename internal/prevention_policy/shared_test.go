@@ -0,0 +1,158 @@
+package preventionpolicy
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestDowngradeWarning(t *testing.T) {
+	sliderType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"detection":  tftypes.String,
+			"prevention": tftypes.String,
+		},
+	}
+	detectionSliderType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"detection": tftypes.String,
+		},
+	}
+	policyType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"enabled":                 tftypes.Bool,
+			"quarantine_on_write":     tftypes.Bool,
+			"detect_on_write":         tftypes.Bool,
+			"cloud_anti_malware":      sliderType,
+			"extended_user_mode_data": detectionSliderType,
+		},
+	}
+
+	policy := func(enabled, quarantine, detect bool, detection, prevention, extended string) tftypes.Value {
+		return tftypes.NewValue(policyType, map[string]tftypes.Value{
+			"enabled":             tftypes.NewValue(tftypes.Bool, enabled),
+			"quarantine_on_write": tftypes.NewValue(tftypes.Bool, quarantine),
+			"detect_on_write":     tftypes.NewValue(tftypes.Bool, detect),
+			"cloud_anti_malware": tftypes.NewValue(sliderType, map[string]tftypes.Value{
+				"detection":  tftypes.NewValue(tftypes.String, detection),
+				"prevention": tftypes.NewValue(tftypes.String, prevention),
+			}),
+			"extended_user_mode_data": tftypes.NewValue(detectionSliderType, map[string]tftypes.Value{
+				"detection": tftypes.NewValue(tftypes.String, extended),
+			}),
+		})
+	}
+
+	prior := policy(true, true, false, "EXTRA_AGGRESSIVE", "AGGRESSIVE", "MODERATE")
+
+	t.Run("downgrades are listed", func(t *testing.T) {
+		diags := downgradeWarning(prior, policy(false, false, true, "MODERATE", "AGGRESSIVE", "CAUTIOUS"))
+		if diags.WarningsCount() != 1 {
+			t.Fatalf("expected 1 warning, got %v", diags)
+		}
+
+		detail := diags.Warnings()[0].Detail()
+		for _, expected := range []string{
+			"quarantine_on_write: enabled -> disabled",
+			"cloud_anti_malware.detection: EXTRA_AGGRESSIVE -> MODERATE",
+			"extended_user_mode_data.detection: MODERATE -> CAUTIOUS",
+		} {
+			if !strings.Contains(detail, expected) {
+				t.Errorf("expected warning to contain %q, got: %s", expected, detail)
+			}
+		}
+
+		for _, unexpected := range []string{"enabled:", "detect_on_write", "prevention:"} {
+			if strings.Contains(detail, unexpected) {
+				t.Errorf("expected warning not to contain %q, got: %s", unexpected, detail)
+			}
+		}
+	})
+
+	t.Run("upgrades are not listed", func(t *testing.T) {
+		diags := downgradeWarning(prior, policy(true, true, true, "EXTRA_AGGRESSIVE", "EXTRA_AGGRESSIVE", "AGGRESSIVE"))
+		if len(diags) != 0 {
+			t.Errorf("expected no diagnostics, got %v", diags)
+		}
+	})
+
+	t.Run("creates are not checked", func(t *testing.T) {
+		diags := downgradeWarning(tftypes.NewValue(policyType, nil), prior)
+		if len(diags) != 0 {
+			t.Errorf("expected no diagnostics, got %v", diags)
+		}
+	})
+}
+
+func TestDowngradeWarning_settings(t *testing.T) {
+	settingsType := tftypes.Map{ElementType: tftypes.String}
+	policyType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"settings": settingsType,
+		},
+	}
+
+	policy := func(settings map[string]string) tftypes.Value {
+		values := map[string]tftypes.Value{}
+		for id, value := range settings {
+			values[id] = tftypes.NewValue(tftypes.String, value)
+		}
+		return tftypes.NewValue(policyType, map[string]tftypes.Value{
+			"settings": tftypes.NewValue(settingsType, values),
+		})
+	}
+
+	prior := policy(map[string]string{
+		"CloudAntiMalwareForMicrosoftOfficeFiles": `{"detection":"AGGRESSIVE","prevention":"MODERATE"}`,
+		"ScriptBasedExecutionMonitoring":          `{"enabled":true}`,
+		"InterpreterProtection":                   `{"enabled":false}`,
+	})
+
+	t.Run("downgrades are listed", func(t *testing.T) {
+		diags := downgradeWarning(prior, policy(map[string]string{
+			"CloudAntiMalwareForMicrosoftOfficeFiles": `{"detection":"CAUTIOUS","prevention":"AGGRESSIVE"}`,
+			"ScriptBasedExecutionMonitoring":          `{"enabled":false}`,
+			"InterpreterProtection":                   `{"enabled":true}`,
+		}))
+		if diags.WarningsCount() != 1 {
+			t.Fatalf("expected 1 warning, got %v", diags)
+		}
+
+		detail := diags.Warnings()[0].Detail()
+		for _, expected := range []string{
+			`settings["CloudAntiMalwareForMicrosoftOfficeFiles"].detection: AGGRESSIVE -> CAUTIOUS`,
+			`settings["ScriptBasedExecutionMonitoring"]: enabled -> disabled`,
+		} {
+			if !strings.Contains(detail, expected) {
+				t.Errorf("expected warning to contain %q, got: %s", expected, detail)
+			}
+		}
+
+		for _, unexpected := range []string{".prevention", "InterpreterProtection"} {
+			if strings.Contains(detail, unexpected) {
+				t.Errorf("expected warning not to contain %q, got: %s", unexpected, detail)
+			}
+		}
+	})
+
+	t.Run("added and removed settings are not listed", func(t *testing.T) {
+		diags := downgradeWarning(prior, policy(map[string]string{
+			"InterpreterProtection": `{"enabled":false}`,
+			"EngineFullVisibility":  `{"enabled":false}`,
+		}))
+		if len(diags) != 0 {
+			t.Errorf("expected no diagnostics, got %v", diags)
+		}
+	})
+
+	t.Run("invalid values are ignored", func(t *testing.T) {
+		diags := downgradeWarning(prior, policy(map[string]string{
+			"CloudAntiMalwareForMicrosoftOfficeFiles": `not json`,
+			"ScriptBasedExecutionMonitoring":          `{"enabled":"no"}`,
+		}))
+		if len(diags) != 0 {
+			t.Errorf("expected no diagnostics, got %v", diags)
+		}
+	})
+}
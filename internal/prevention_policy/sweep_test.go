@@ -0,0 +1,86 @@
+package preventionpolicy_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/crowdstrike/gofalcon/falcon/client/prevention_policies"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/sweep"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestMain(m *testing.M) {
+	resource.TestMain(m)
+}
+
+func init() {
+	resource.AddTestSweepers("crowdstrike_prevention_policy", &resource.Sweeper{
+		Name: "crowdstrike_prevention_policy",
+		F:    sweepPreventionPolicies,
+	})
+}
+
+func sweepPreventionPolicies(_ string) error {
+	ctx := context.Background()
+	c, err := sweep.Client()
+	if err != nil {
+		return err
+	}
+
+	var ids []string
+	limit := sweep.QueryLimit
+	var offset int64
+
+	for {
+		res, err := c.PreventionPolicies.QueryCombinedPreventionPolicies(
+			&prevention_policies.QueryCombinedPreventionPoliciesParams{
+				Context: ctx,
+				Filter:  &sweep.NameFilter,
+				Limit:   &limit,
+				Offset:  &offset,
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("listing prevention policies: %w", err)
+		}
+
+		for _, p := range res.Payload.Resources {
+			if sweep.IsSweepable(p.Name) {
+				ids = append(ids, *p.ID)
+			}
+		}
+
+		var more bool
+		offset, more = sweep.NextOffset(offset, len(res.Payload.Resources), res.Payload.Meta)
+		if !more {
+			break
+		}
+	}
+
+	for _, id := range ids {
+		_, err := c.PreventionPolicies.PerformPreventionPoliciesAction(
+			&prevention_policies.PerformPreventionPoliciesActionParams{
+				Context:    ctx,
+				ActionName: "disable",
+				Body:       &models.MsaEntityActionRequestV2{Ids: []string{id}},
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("disabling prevention policy %s: %w", id, err)
+		}
+
+		_, err = c.PreventionPolicies.DeletePreventionPolicies(
+			&prevention_policies.DeletePreventionPoliciesParams{
+				Context: ctx,
+				Ids:     []string{id},
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("deleting prevention policy %s: %w", id, err)
+		}
+	}
+
+	return nil
+}
@@ -86,6 +86,9 @@ func TestAccPreventionPolicyWindowsResource(t *testing.T) {
 					// Verify dynamic values have any value set in the state.
 					resource.TestCheckResourceAttrSet(resourceName, "id"),
 					resource.TestCheckResourceAttrSet(resourceName, "last_updated"),
+					resource.TestCheckResourceAttrSet(resourceName, "assigned_host_count"),
+					resource.TestCheckResourceAttr(resourceName, "assigned_host_count_by_group.%", "1"),
+					resource.TestCheckResourceAttrSet(resourceName, "exported_settings_json"),
 				),
 			},
 			{
@@ -121,3 +124,41 @@ func TestAccPreventionPolicyWindowsResource(t *testing.T) {
 		},
 	})
 }
+
+func TestAccPreventionPolicyWindowsResource_cloneFromID(t *testing.T) {
+	rName := sdkacctest.RandomWithPrefix("tf-acceptance-test")
+	resourceName := "crowdstrike_prevention_policy_windows.clone"
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPreventionPolicyWindowsConfig_basic(rName, false) + fmt.Sprintf(`
+resource "crowdstrike_prevention_policy_windows" "clone" {
+  name                      = "%s-clone"
+  description               = "cloned with terraform"
+  clone_from_id             = crowdstrike_prevention_policy_windows.test.id
+  additional_user_mode_data = true
+}
+`, rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						resourceName,
+						"clone_from_id",
+						"crowdstrike_prevention_policy_windows.test",
+						"id",
+					),
+					resource.TestCheckResourceAttr(resourceName, "additional_user_mode_data", "true"),
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated", "clone_from_id"},
+			},
+		},
+	})
+}
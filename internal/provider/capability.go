@@ -0,0 +1,210 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/client/cloud_snapshots"
+	"github.com/crowdstrike/gofalcon/falcon/client/filevantage"
+	"github.com/crowdstrike/gofalcon/falcon/client/workflows"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/scopes"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/tferrors"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// capability is a licensed Falcon module that resources depend on.
+type capability struct {
+	module string
+	scopes []scopes.Scope
+	// probe makes the cheapest read request of the module.
+	probe func(ctx context.Context, c *client.CrowdStrikeAPISpecification) error
+}
+
+var probeLimit int64 = 1
+
+var (
+	fileVantageCapability = &capability{
+		module: "Falcon FileVantage",
+		scopes: []scopes.Scope{
+			{
+				Name:  "Falcon FileVantage",
+				Read:  true,
+				Write: false,
+			},
+		},
+		probe: func(ctx context.Context, c *client.CrowdStrikeAPISpecification) error {
+			_, err := c.Filevantage.QueryPolicies(&filevantage.QueryPoliciesParams{
+				Context: ctx,
+				Type:    "Windows",
+				Limit:   &probeLimit,
+			})
+			return err
+		},
+	}
+
+	workflowCapability = &capability{
+		module: "Falcon Fusion SOAR",
+		scopes: []scopes.Scope{
+			{
+				Name:  "Workflow",
+				Read:  true,
+				Write: false,
+			},
+		},
+		probe: func(ctx context.Context, c *client.CrowdStrikeAPISpecification) error {
+			_, err := c.Workflows.WorkflowDefinitionsCombined(&workflows.WorkflowDefinitionsCombinedParams{
+				Context: ctx,
+				Filter:  "name:'terraform-capability-check'",
+				Limit:   &probeLimit,
+			})
+			return err
+		},
+	}
+
+	cloudSnapshotsCapability = &capability{
+		module: "Falcon Cloud Security snapshot scanning",
+		scopes: snapshotScanJobScopes,
+		probe: func(ctx context.Context, c *client.CrowdStrikeAPISpecification) error {
+			_, err := c.CloudSnapshots.ReadDeploymentsCombined(&cloud_snapshots.ReadDeploymentsCombinedParams{
+				Context: ctx,
+				Limit:   &probeLimit,
+			})
+			return err
+		},
+	}
+)
+
+// resourceCapabilities maps the resource types that belong to a licensed module to the
+// module. Resources of the core platform are not listed and are never checked.
+var resourceCapabilities = map[string]*capability{
+	"crowdstrike_filevantage_policy":     fileVantageCapability,
+	"crowdstrike_filevantage_rule_group": fileVantageCapability,
+	"crowdstrike_workflow_schedule":      workflowCapability,
+	"crowdstrike_snapshot_scan_job":      cloudSnapshotsCapability,
+}
+
+// capabilityResults holds the result of each probe per client, so a module is checked once
+// per plugin process no matter how many of its resources are planned. Only definitive
+// results are kept, a probe that failed for another reason is tried again on the next check.
+var capabilityResults = struct {
+	sync.Mutex
+	entries map[capabilityKey]*capabilityResult
+}{
+	entries: map[capabilityKey]*capabilityResult{},
+}
+
+type capabilityKey struct {
+	client     *client.CrowdStrikeAPISpecification
+	capability *capability
+}
+
+type capabilityResult struct {
+	mu         sync.Mutex
+	definitive bool
+	diags      diag.Diagnostics
+}
+
+// checkCapability returns an error when the module of typeName is not available in the CID
+// of the client. Resource types that do not belong to a licensed module are not checked.
+func checkCapability(
+	ctx context.Context,
+	c *client.CrowdStrikeAPISpecification,
+	typeName string,
+) diag.Diagnostics {
+	required, ok := resourceCapabilities[typeName]
+	if !ok {
+		return nil
+	}
+
+	capabilityResults.Lock()
+	key := capabilityKey{client: c, capability: required}
+	result, ok := capabilityResults.entries[key]
+	if !ok {
+		result = &capabilityResult{}
+		capabilityResults.entries[key] = result
+	}
+	capabilityResults.Unlock()
+
+	result.mu.Lock()
+	if !result.definitive {
+		result.diags, result.definitive = required.check(ctx, c)
+	}
+	resultDiags := result.diags
+	result.mu.Unlock()
+
+	var diags diag.Diagnostics
+	for _, d := range resultDiags {
+		// the result is shared, so the resource type is added to the copy returned.
+		detail := fmt.Sprintf("%s requires %s. %s", typeName, required.module, d.Detail())
+		if d.Severity() == diag.SeverityError {
+			diags.AddError(d.Summary(), detail)
+		} else {
+			diags.AddWarning(d.Summary(), detail)
+		}
+	}
+
+	return diags
+}
+
+// check runs the probe of the module and turns the response into diagnostics. definitive is
+// false when the probe failed for a reason that says nothing about the entitlement, such as a
+// timeout or a server error.
+func (c *capability) check(
+	ctx context.Context,
+	apiClient *client.CrowdStrikeAPISpecification,
+) (diags diag.Diagnostics, definitive bool) {
+	err := c.probe(ctx, apiClient)
+
+	switch {
+	case err == nil:
+		return diags, true
+	case probeFailedWith(err, http.StatusNotFound):
+		diags.AddError(
+			"Module not entitled in this CID",
+			fmt.Sprintf(
+				"The %s api is not available, the module is not entitled in this CID. "+
+					"Contact your CrowdStrike account team to enable it, or remove these resources from the configuration.",
+				c.module,
+			),
+		)
+	case probeFailedWith(err, http.StatusForbidden):
+		diags.AddError(
+			"Module not entitled in this CID",
+			fmt.Sprintf(
+				"Access to the %s api was denied, either the module is not entitled in this CID or the API client is missing its scopes.\n\n%s",
+				c.module,
+				tferrors.Message(err, c.scopes...),
+			),
+		)
+	default:
+		// the check only replaces a confusing apply failure, so other errors do not block the plan.
+		tflog.Warn(ctx, "Unable to check module entitlement, skipping", map[string]any{
+			"module": c.module,
+			"error":  err.Error(),
+		})
+		diags.AddWarning(
+			"Unable to check module entitlement",
+			fmt.Sprintf("Could not check whether %s is entitled in this CID: %s", c.module, tferrors.Message(err)),
+		)
+		return diags, false
+	}
+
+	return diags, true
+}
+
+// probeFailedWith reports whether the probe failed with the status code. A module that is
+// not entitled can answer with a status the api spec does not declare, which gofalcon
+// returns as a generic error without the status in the error type.
+func probeFailedWith(err error, code int) bool {
+	if tferrors.StatusCode(err) == code {
+		return true
+	}
+
+	var coder interface{ IsCode(int) bool }
+	return errors.As(err, &coder) && coder.IsCode(code)
+}
@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func TestCheckCapability_mock(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		severity diag.Severity
+		detail   string
+		probes   int
+	}{
+		{
+			name:     "entitled",
+			status:   http.StatusOK,
+			severity: diag.SeverityInvalid,
+			probes:   1,
+		},
+		{
+			name:     "not entitled",
+			status:   http.StatusNotFound,
+			severity: diag.SeverityError,
+			detail:   "not entitled in this CID",
+			probes:   1,
+		},
+		{
+			name:     "forbidden",
+			status:   http.StatusForbidden,
+			severity: diag.SeverityError,
+			detail:   "missing its scopes",
+			probes:   1,
+		},
+		{
+			name:     "server error",
+			status:   http.StatusInternalServerError,
+			severity: diag.SeverityWarning,
+			detail:   "Could not check whether Falcon FileVantage is entitled",
+			// the failure says nothing about the entitlement, so it is not cached.
+			probes: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newMockFalcon(t)
+			m.handle("GET /filevantage/queries/policies/v1", func(w http.ResponseWriter, r *http.Request) {
+				if tt.status != http.StatusOK {
+					writeAPIError(w, tt.status, "probe failed")
+					return
+				}
+				writeJSON(w, http.StatusOK, map[string]any{"resources": []string{}})
+			})
+
+			c := m.client(t)
+
+			// both resource types share the module, so a definitive result is only probed once.
+			var diags diag.Diagnostics
+			diags.Append(checkCapability(context.Background(), c, "crowdstrike_filevantage_policy")...)
+			diags.Append(checkCapability(context.Background(), c, "crowdstrike_filevantage_rule_group")...)
+			diags.Append(checkCapability(context.Background(), c, "crowdstrike_host_group")...)
+
+			if got := m.calls("GET /filevantage/queries/policies/v1"); got != tt.probes {
+				t.Errorf("expected the module to be probed %d times, got %d calls", tt.probes, got)
+			}
+
+			if tt.severity == diag.SeverityInvalid {
+				if len(diags) != 0 {
+					t.Fatalf("expected no diagnostics, got: %v", diags)
+				}
+				return
+			}
+
+			if len(diags) != 2 {
+				t.Fatalf("expected a diagnostic per filevantage resource, got: %v", diags)
+			}
+
+			for _, d := range diags {
+				if d.Severity() != tt.severity {
+					t.Errorf("expected severity %s, got %s: %s", tt.severity, d.Severity(), d.Detail())
+				}
+				if !strings.Contains(d.Detail(), tt.detail) {
+					t.Errorf("expected detail to contain %q, got: %s", tt.detail, d.Detail())
+				}
+			}
+		})
+	}
+}
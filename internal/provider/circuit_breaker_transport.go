@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// circuitBreakerThreshold is the number of server errors in a row that opens the circuit
+// of an endpoint.
+const circuitBreakerThreshold = 5
+
+// circuitBreakerCooldown is how long requests to a degraded endpoint fail fast before one
+// request is let through to check whether the endpoint recovered.
+var circuitBreakerCooldown = 30 * time.Second
+
+// circuitBreakerTransport is a http.RoundTripper that fails fast when an endpoint keeps
+// returning 5xx responses. An endpoint is a method and path, so a degraded api does not
+// block requests to the others. Without it every resource waits on the degraded endpoint
+// for the length of its timeout.
+type circuitBreakerTransport struct {
+	next http.RoundTripper
+
+	mu        sync.Mutex
+	endpoints map[string]*endpointCircuit
+}
+
+// endpointCircuit tracks the recent server errors of a single endpoint.
+type endpointCircuit struct {
+	failures  int
+	openUntil time.Time
+}
+
+func newCircuitBreakerTransport(next http.RoundTripper) *circuitBreakerTransport {
+	return &circuitBreakerTransport{
+		next:      next,
+		endpoints: map[string]*endpointCircuit{},
+	}
+}
+
+func (t *circuitBreakerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	endpoint := req.Method + " " + req.URL.Path
+
+	if err := t.allow(endpoint); err != nil {
+		return nil, err
+	}
+
+	res, err := t.next.RoundTrip(req)
+
+	// network errors are left to the caller, only responses from the api count as failures.
+	if err == nil {
+		if t.record(endpoint, res.StatusCode >= http.StatusInternalServerError) {
+			tflog.Warn(req.Context(), "CrowdStrike API endpoint is degraded, failing requests fast", map[string]any{
+				"http_method": req.Method,
+				"http_path":   req.URL.Path,
+				"cooldown":    circuitBreakerCooldown.String(),
+			})
+		}
+	}
+
+	return res, err
+}
+
+// allow returns an error while the circuit of endpoint is open.
+func (t *circuitBreakerTransport) allow(endpoint string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	circuit, ok := t.endpoints[endpoint]
+	if !ok || circuit.failures < circuitBreakerThreshold {
+		return nil
+	}
+
+	if wait := time.Until(circuit.openUntil); wait > 0 {
+		return fmt.Errorf(
+			"CrowdStrike API endpoint %s is degraded, it returned %d server errors in a row. "+
+				"Requests to it fail without being sent for the next %s, check the CrowdStrike status page and try again later",
+			endpoint,
+			circuit.failures,
+			wait.Round(time.Second),
+		)
+	}
+
+	// the cooldown has passed, let this request through and reopen the circuit until it
+	// completes so concurrent requests do not all hit the endpoint at once.
+	circuit.openUntil = time.Now().Add(circuitBreakerCooldown)
+
+	return nil
+}
+
+// record updates the circuit of endpoint with the result of a request and returns true
+// when the request opened the circuit.
+func (t *circuitBreakerTransport) record(endpoint string, failed bool) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !failed {
+		delete(t.endpoints, endpoint)
+		return false
+	}
+
+	circuit, ok := t.endpoints[endpoint]
+	if !ok {
+		circuit = &endpointCircuit{}
+		t.endpoints[endpoint] = circuit
+	}
+
+	circuit.failures++
+	if circuit.failures < circuitBreakerThreshold {
+		return false
+	}
+
+	circuit.openUntil = time.Now().Add(circuitBreakerCooldown)
+
+	return circuit.failures == circuitBreakerThreshold
+}
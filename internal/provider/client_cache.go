@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	membercid "github.com/crowdstrike/terraform-provider-crowdstrike/internal/member_cid"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// clientCache holds the API clients created by the plugin process. Terraform can
+// configure the provider more than once per process, reusing the client reuses its
+// oauth2 token and idle connections instead of authenticating again.
+var clientCache = struct {
+	sync.Mutex
+	entries map[string]clientCacheEntry
+}{
+	entries: map[string]clientCacheEntry{},
+}
+
+type clientCacheEntry struct {
+	client        *client.CrowdStrikeAPISpecification
+	ephemeralData *ephemeralResourceData
+	// memberClients creates the clients of resources that set member_cid, it is nil
+	// for those clients.
+	memberClients *membercid.Clients
+	// diags are the warnings returned when the client was created.
+	diags diag.Diagnostics
+}
+
+// clientCacheKey returns the cache key for the values used to create a client.
+// The values are hashed so credentials are not held in the key.
+func clientCacheKey(values ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(values, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,325 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// cloudHosts maps the supported Falcon clouds to their API hosts.
+var cloudHosts = map[string]string{
+	"us-1":     "api.crowdstrike.com",
+	"us-2":     "api.us-2.crowdstrike.com",
+	"eu-1":     "api.eu-1.crowdstrike.com",
+	"us-gov-1": "api.laggar.gcw.crowdstrike.com",
+	"us-gov-2": "api.us-gov-2.crowdstrike.mil",
+}
+
+// discoveryOrder is the order clouds are tried in when autodiscovering.
+// Commercial clouds redirect to the correct region from us-1, GovCloud credentials
+// are only accepted by their own cloud so they need to be tried directly.
+var discoveryOrder = []string{"us-1", "us-gov-1", "us-gov-2"}
+
+// cloudBaseURL returns the base url of the API for the given cloud.
+func cloudBaseURL(cloud string) string {
+	return "https://" + cloudHosts[cloud]
+}
+
+// resolveCloud verifies the client credentials against the configured cloud and returns
+// the home cloud of the API client. When the cloud is autodiscover, or the configured cloud
+// rejects the credentials, the supported clouds are tried in turn and a warning is returned
+// if the home cloud does not match the configured cloud.
+func resolveCloud(
+	ctx context.Context,
+	httpClient *http.Client,
+	baseURL func(string) string,
+	cloud, clientId, clientSecret string,
+) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	cloud = strings.ToLower(cloud)
+
+	if cloud != "autodiscover" {
+		region, err := discoverCloud(ctx, httpClient, baseURL(cloud), cloud, clientId, clientSecret)
+		if err == nil {
+			if _, ok := cloudHosts[region]; !ok || region == cloud {
+				return cloud, diags
+			}
+
+			diags.Append(cloudMismatchWarning(cloud, region))
+			return region, diags
+		}
+
+		var discoveryErr *cloudDiscoveryError
+		if !errors.As(err, &discoveryErr) || !isAuthFailure(discoveryErr.StatusCode) {
+			diags.AddAttributeError(
+				path.Root("cloud"),
+				"Unable to Authenticate to CrowdStrike API Cloud",
+				fmt.Sprintf(
+					"The provider could not authenticate to the %s cloud. "+
+						"Verify the cloud value and network connectivity to %s.\n\n%s",
+					cloud,
+					cloudHosts[cloud],
+					err.Error(),
+				),
+			)
+			return cloud, diags
+		}
+
+		tflog.Debug(ctx, "Configured cloud rejected credentials, attempting autodiscovery", map[string]any{
+			"cloud": cloud,
+			"error": err.Error(),
+		})
+
+		region, errs := autodiscoverCloud(ctx, httpClient, baseURL, cloud, clientId, clientSecret)
+		if region == "" {
+			diags.AddAttributeError(
+				path.Root("cloud"),
+				"Unable to Authenticate to CrowdStrike API Cloud",
+				fmt.Sprintf(
+					"The provider could not authenticate to the %s cloud and the credentials were not accepted by any other supported cloud. "+
+						"Verify the client_id and client_secret are correct and the API client has not been deleted.\n\n%s",
+					cloud,
+					strings.Join(append([]string{err.Error()}, errs...), "\n"),
+				),
+			)
+			return cloud, diags
+		}
+
+		diags.Append(cloudMismatchWarning(cloud, region))
+		return region, diags
+	}
+
+	region, errs := autodiscoverCloud(ctx, httpClient, baseURL, "", clientId, clientSecret)
+	if region == "" {
+		diags.AddAttributeError(
+			path.Root("cloud"),
+			"Unable to Autodiscover CrowdStrike API Cloud",
+			"The provider could not determine the cloud of the API client. "+
+				"Verify the client_id and client_secret are correct, or set the cloud value in the configuration or use the FALCON_CLOUD environment variable.\n\n"+
+				strings.Join(errs, "\n"),
+		)
+		return cloud, diags
+	}
+
+	return region, diags
+}
+
+// autodiscoverCloud tries each cloud in discoveryOrder, skipping the given cloud, and
+// returns the home cloud of the first one that accepts the credentials.
+func autodiscoverCloud(
+	ctx context.Context,
+	httpClient *http.Client,
+	baseURL func(string) string,
+	skip, clientId, clientSecret string,
+) (string, []string) {
+	var errs []string
+
+	for _, c := range discoveryOrder {
+		if c == skip {
+			continue
+		}
+
+		region, err := discoverCloud(ctx, httpClient, baseURL(c), c, clientId, clientSecret)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+
+		if _, ok := cloudHosts[region]; !ok {
+			region = c
+		}
+
+		if region != skip {
+			return region, errs
+		}
+	}
+
+	return "", errs
+}
+
+func cloudMismatchWarning(cloud, region string) diag.Diagnostic {
+	return diag.NewAttributeWarningDiagnostic(
+		path.Root("cloud"),
+		"CrowdStrike API Cloud Mismatch",
+		fmt.Sprintf(
+			"The provider is configured for the %s cloud, but the API client belongs to the %s cloud. "+
+				"The provider will use the %s cloud. Set cloud to %q to remove this warning.",
+			cloud,
+			region,
+			region,
+			region,
+		),
+	)
+}
+
+func isAuthFailure(statusCode int) bool {
+	return statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden
+}
+
+// cloudDiscoveryError is returned when the oauth2 token endpoint rejects the credentials.
+type cloudDiscoveryError struct {
+	Cloud      string
+	StatusCode int
+	Message    string
+}
+
+func (e *cloudDiscoveryError) Error() string {
+	return fmt.Sprintf(
+		"%s (%s) returned status %d requesting an oauth2 token: %s",
+		e.Cloud,
+		cloudHosts[e.Cloud],
+		e.StatusCode,
+		e.Message,
+	)
+}
+
+// oauthToken is an oauth2 token issued by the CrowdStrike API.
+type oauthToken struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// requestToken requests an oauth2 token from the given cloud. The home cloud of the
+// API client reported by the X-Cs-Region header is returned with the token.
+func requestToken(
+	ctx context.Context,
+	httpClient *http.Client,
+	baseURL, cloud, clientId, clientSecret, memberCID string,
+) (oauthToken, string, error) {
+	var token oauthToken
+
+	form := url.Values{}
+	form.Set("client_id", clientId)
+	form.Set("client_secret", clientSecret)
+
+	if memberCID != "" {
+		form.Set("member_cid", memberCID)
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		baseURL+"/oauth2/token",
+		strings.NewReader(form.Encode()),
+	)
+	if err != nil {
+		return token, "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return token, "", fmt.Errorf("unable to reach %s (%s): %w", cloud, cloudHosts[cloud], err)
+	}
+	defer res.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(res.Body, 1<<20))
+
+	if res.StatusCode != http.StatusCreated && res.StatusCode != http.StatusOK {
+		return token, "", &cloudDiscoveryError{
+			Cloud:      cloud,
+			StatusCode: res.StatusCode,
+			Message:    tokenErrorMessage(body),
+		}
+	}
+
+	region := strings.ToLower(res.Header.Get("X-Cs-Region"))
+	if region == "" {
+		region = cloud
+	}
+
+	if err := json.Unmarshal(body, &token); err != nil {
+		return token, region, fmt.Errorf("unable to parse oauth2 token response: %w", err)
+	}
+
+	return token, region, nil
+}
+
+// discoverCloud requests an oauth2 token from the given cloud and returns the home
+// cloud of the API client reported by the X-Cs-Region header. The token is revoked afterwards.
+func discoverCloud(
+	ctx context.Context,
+	httpClient *http.Client,
+	baseURL, cloud, clientId, clientSecret string,
+) (string, error) {
+	token, region, err := requestToken(ctx, httpClient, baseURL, cloud, clientId, clientSecret, "")
+	if err != nil {
+		var discoveryErr *cloudDiscoveryError
+		if errors.As(err, &discoveryErr) || region == "" {
+			return "", err
+		}
+
+		// The credentials were accepted, only the token could not be parsed.
+		return region, nil
+	}
+
+	if token.AccessToken != "" {
+		revokeBaseURL := baseURL
+		if region != cloud {
+			if host, ok := cloudHosts[region]; ok {
+				revokeBaseURL = "https://" + host
+			}
+		}
+		revokeToken(ctx, httpClient, revokeBaseURL, clientId, clientSecret, token.AccessToken)
+	}
+
+	return region, nil
+}
+
+// revokeToken revokes an oauth2 token, errors are ignored because the token expires on its own.
+func revokeToken(
+	ctx context.Context,
+	httpClient *http.Client,
+	baseURL, clientId, clientSecret, token string,
+) {
+	form := url.Values{}
+	form.Set("token", token)
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		baseURL+"/oauth2/revoke",
+		strings.NewReader(form.Encode()),
+	)
+	if err != nil {
+		return
+	}
+	req.SetBasicAuth(clientId, clientSecret)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return
+	}
+	res.Body.Close()
+}
+
+// tokenErrorMessage extracts the error message from an oauth2 token error response.
+func tokenErrorMessage(body []byte) string {
+	var payload struct {
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+
+	if err := json.Unmarshal(body, &payload); err == nil && len(payload.Errors) > 0 {
+		messages := make([]string, 0, len(payload.Errors))
+		for _, e := range payload.Errors {
+			messages = append(messages, e.Message)
+		}
+		return strings.Join(messages, ", ")
+	}
+
+	return strings.TrimSpace(string(body))
+}
@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newCloudServer returns a test server that emulates the oauth2 endpoints of each cloud.
+// accepts maps a cloud to the home cloud it reports for the test credentials, clouds
+// missing from the map reject the credentials.
+func newCloudServer(t *testing.T, accepts map[string]string) (*httptest.Server, func(string) string) {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/"), "/", 2)
+		cloud, endpoint := parts[0], parts[1]
+
+		if endpoint == "oauth2/revoke" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		region, ok := accepts[cloud]
+		if !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"errors":[{"code":401,"message":"access denied, invalid bearer token"}]}`))
+			return
+		}
+
+		w.Header().Set("X-Cs-Region", region)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"access_token":"token","expires_in":1799}`))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func(cloud string) string { return server.URL + "/" + cloud }
+}
+
+func TestResolveCloud(t *testing.T) {
+	tests := []struct {
+		name        string
+		cloud       string
+		accepts     map[string]string
+		wantCloud   string
+		wantWarning bool
+		wantError   bool
+	}{
+		{
+			name:      "matching cloud",
+			cloud:     "us-2",
+			accepts:   map[string]string{"us-2": "us-2"},
+			wantCloud: "us-2",
+		},
+		{
+			name:        "mismatched commercial cloud",
+			cloud:       "us-1",
+			accepts:     map[string]string{"us-1": "eu-1"},
+			wantCloud:   "eu-1",
+			wantWarning: true,
+		},
+		{
+			name:        "commercial cloud configured for govcloud client",
+			cloud:       "us-1",
+			accepts:     map[string]string{"us-gov-2": "us-gov-2"},
+			wantCloud:   "us-gov-2",
+			wantWarning: true,
+		},
+		{
+			name:      "autodiscover commercial cloud",
+			cloud:     "autodiscover",
+			accepts:   map[string]string{"us-1": "us-2"},
+			wantCloud: "us-2",
+		},
+		{
+			name:      "autodiscover govcloud",
+			cloud:     "autodiscover",
+			accepts:   map[string]string{"us-gov-1": "us-gov-1"},
+			wantCloud: "us-gov-1",
+		},
+		{
+			name:      "invalid credentials",
+			cloud:     "eu-1",
+			accepts:   map[string]string{},
+			wantError: true,
+		},
+		{
+			name:      "autodiscover invalid credentials",
+			cloud:     "autodiscover",
+			accepts:   map[string]string{},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, baseURL := newCloudServer(t, tt.accepts)
+
+			cloud, diags := resolveCloud(
+				context.Background(),
+				server.Client(),
+				baseURL,
+				tt.cloud,
+				"id",
+				"secret",
+			)
+
+			if diags.HasError() != tt.wantError {
+				t.Fatalf("resolveCloud() error = %v, wantError %v", diags, tt.wantError)
+			}
+
+			if tt.wantError {
+				return
+			}
+
+			if cloud != tt.wantCloud {
+				t.Errorf("resolveCloud() = %q, want %q", cloud, tt.wantCloud)
+			}
+
+			if gotWarning := diags.WarningsCount() > 0; gotWarning != tt.wantWarning {
+				t.Errorf("resolveCloud() warnings = %v, wantWarning %v", diags, tt.wantWarning)
+			}
+		})
+	}
+}
@@ -0,0 +1,257 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/client/container_images"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/scopes"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/tferrors"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &containerImagesDataSource{}
+	_ datasource.DataSourceWithConfigure = &containerImagesDataSource{}
+)
+
+var containerImagesScopes = []scopes.Scope{
+	{
+		Name:  "Falcon Container Image",
+		Read:  true,
+		Write: false,
+	},
+}
+
+// containerImagesQueryLimit is the page size used when querying images, the API allows at most 100.
+const containerImagesQueryLimit int64 = 100
+
+// NewContainerImagesDataSource is a helper function to simplify the provider implementation.
+func NewContainerImagesDataSource() datasource.DataSource {
+	return &containerImagesDataSource{}
+}
+
+// containerImagesDataSource is the data source implementation.
+type containerImagesDataSource struct {
+	client *client.CrowdStrikeAPISpecification
+}
+
+// containerImagesDataSourceModel maps the data source schema data.
+type containerImagesDataSourceModel struct {
+	Filter types.String     `tfsdk:"filter"`
+	Images []containerImage `tfsdk:"images"`
+}
+
+// containerImage maps a single assessed container image.
+type containerImage struct {
+	ImageID                      types.String `tfsdk:"image_id"`
+	ImageDigest                  types.String `tfsdk:"image_digest"`
+	Registry                     types.String `tfsdk:"registry"`
+	Repository                   types.String `tfsdk:"repository"`
+	Tag                          types.String `tfsdk:"tag"`
+	BaseOS                       types.String `tfsdk:"base_os"`
+	Vulnerabilities              types.Int64  `tfsdk:"vulnerabilities"`
+	HighestVulnerabilitySeverity types.String `tfsdk:"highest_vulnerability_severity"`
+	Detections                   types.Int64  `tfsdk:"detections"`
+	HighestDetectionSeverity     types.String `tfsdk:"highest_detection_severity"`
+	Containers                   types.Int64  `tfsdk:"containers"`
+	FirstSeen                    types.String `tfsdk:"first_seen"`
+	LastSeen                     types.String `tfsdk:"last_seen"`
+}
+
+// Metadata returns the data source type name.
+func (d *containerImagesDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_container_images"
+}
+
+// Schema defines the schema for the data source.
+func (d *containerImagesDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: fmt.Sprintf(
+			"Container Security --- This data source returns the assessed container images matching an FQL filter, e.g. to block promoting an image with critical vulnerabilities.\n\n%s",
+			scopes.GenerateScopeDescription(containerImagesScopes),
+		),
+		Attributes: map[string]schema.Attribute{
+			"filter": schema.StringAttribute{
+				Optional:    true,
+				Description: "FQL filter selecting the images to return, e.g. registry:'docker.io'+repository:'library/nginx'+tag:'1.27'. Supports container_id, container_running_status, cve_id, detection_name, detection_severity, first_seen, image_digest, image_id, registry, repository, tag, and vulnerability_severity. All images are returned when left blank.",
+			},
+			"images": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The images matching the filter.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"image_id": schema.StringAttribute{
+							Computed:    true,
+							Description: "Identifier for the image.",
+						},
+						"image_digest": schema.StringAttribute{
+							Computed:    true,
+							Description: "Digest of the image.",
+						},
+						"registry": schema.StringAttribute{
+							Computed:    true,
+							Description: "Registry of the image.",
+						},
+						"repository": schema.StringAttribute{
+							Computed:    true,
+							Description: "Repository of the image.",
+						},
+						"tag": schema.StringAttribute{
+							Computed:    true,
+							Description: "Tag of the image.",
+						},
+						"base_os": schema.StringAttribute{
+							Computed:    true,
+							Description: "Base operating system of the image.",
+						},
+						"vulnerabilities": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Number of vulnerabilities found in the image.",
+						},
+						"highest_vulnerability_severity": schema.StringAttribute{
+							Computed:    true,
+							Description: "Highest severity of the vulnerabilities found in the image.",
+						},
+						"detections": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Number of detections found in the image.",
+						},
+						"highest_detection_severity": schema.StringAttribute{
+							Computed:    true,
+							Description: "Highest severity of the detections found in the image.",
+						},
+						"containers": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Number of containers running the image.",
+						},
+						"first_seen": schema.StringAttribute{
+							Computed:    true,
+							Description: "Timestamp the image was first seen.",
+						},
+						"last_seen": schema.StringAttribute{
+							Computed:    true,
+							Description: "Timestamp the image was last seen.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *containerImagesDataSource) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var state containerImagesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Images = []containerImage{}
+	limit := containerImagesQueryLimit
+	var offset int64
+
+	for {
+		params := &container_images.GetCombinedImagesParams{
+			Context: ctx,
+			Limit:   &limit,
+			Offset:  &offset,
+		}
+
+		if state.Filter.ValueString() != "" {
+			params.Filter = state.Filter.ValueStringPointer()
+		}
+
+		res, err := d.client.ContainerImages.GetCombinedImages(params)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to read container images",
+				tferrors.Message(err, containerImagesScopes...),
+			)
+			return
+		}
+
+		for _, image := range res.Payload.Resources {
+			state.Images = append(state.Images, newContainerImage(image))
+		}
+
+		var more bool
+		offset, more = nextPageOffset(offset, len(res.Payload.Resources), res.Payload.Meta)
+		if !more {
+			break
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// newContainerImage maps an image returned by the API.
+func newContainerImage(image *models.ModelsExtAPIImageCombined) containerImage {
+	return containerImage{
+		ImageID:                      types.StringPointerValue(image.ImageID),
+		ImageDigest:                  types.StringPointerValue(image.ImageDigest),
+		Registry:                     types.StringPointerValue(image.Registry),
+		Repository:                   types.StringPointerValue(image.Repository),
+		Tag:                          types.StringPointerValue(image.Tag),
+		BaseOS:                       types.StringPointerValue(image.BaseOs),
+		Vulnerabilities:              int32Value(image.Vulnerabilities),
+		HighestVulnerabilitySeverity: types.StringPointerValue(image.HighestVulnerabilitySeverity),
+		Detections:                   int32Value(image.Detections),
+		HighestDetectionSeverity:     types.StringPointerValue(image.HighestDetectionSeverity),
+		Containers:                   types.Int64PointerValue(image.Containers),
+		FirstSeen:                    types.StringPointerValue(image.FirstSeen),
+		LastSeen:                     types.StringPointerValue(image.LastSeen),
+	}
+}
+
+// int32Value returns an int64 value for an optional int32 returned by the API.
+func int32Value(v *int32) types.Int64 {
+	if v == nil {
+		return types.Int64Null()
+	}
+
+	return types.Int64Value(int64(*v))
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *containerImagesDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.CrowdStrikeAPISpecification)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf(
+				"Expected *client.CrowdStrikeAPISpecification, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+		return
+	}
+
+	d.client = client
+}
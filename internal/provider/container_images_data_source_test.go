@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccContainerImagesDataSource(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+data "crowdstrike_container_images" "critical" {
+  filter = "vulnerability_severity:'CRITICAL'"
+}
+
+data "crowdstrike_container_images" "none" {
+  filter = "repository:'tf-acceptance-test-does-not-exist'"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(
+						"data.crowdstrike_container_images.critical",
+						"images.#",
+					),
+					resource.TestCheckResourceAttr(
+						"data.crowdstrike_container_images.none",
+						"images.#",
+						"0",
+					),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,218 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/client/custom_ioa"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/scopes"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/tferrors"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &customIOARuleGroupsDataSource{}
+	_ datasource.DataSourceWithConfigure = &customIOARuleGroupsDataSource{}
+)
+
+var customIOARuleGroupsScopes = []scopes.Scope{
+	{
+		Name:  "Custom IOA rules",
+		Read:  true,
+		Write: false,
+	},
+}
+
+// NewCustomIOARuleGroupsDataSource is a helper function to simplify the provider implementation.
+func NewCustomIOARuleGroupsDataSource() datasource.DataSource {
+	return &customIOARuleGroupsDataSource{}
+}
+
+// customIOARuleGroupsDataSource is the data source implementation.
+type customIOARuleGroupsDataSource struct {
+	client *client.CrowdStrikeAPISpecification
+}
+
+// customIOARuleGroupsDataSourceModel maps the data source schema data.
+type customIOARuleGroupsDataSourceModel struct {
+	Name       types.String         `tfsdk:"name"`
+	Platform   types.String         `tfsdk:"platform"`
+	RuleGroups []customIOARuleGroup `tfsdk:"rule_groups"`
+}
+
+// customIOARuleGroup maps a single custom IOA rule group.
+type customIOARuleGroup struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	Platform    types.String `tfsdk:"platform"`
+	Enabled     types.Bool   `tfsdk:"enabled"`
+}
+
+// Metadata returns the data source type name.
+func (d *customIOARuleGroupsDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_custom_ioa_rule_groups"
+}
+
+// Schema defines the schema for the data source.
+func (d *customIOARuleGroupsDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: fmt.Sprintf(
+			"Custom IOA --- This data source looks up Custom IOA rule groups by name and platform, e.g. to attach rule groups managed outside of Terraform to a prevention policy.\n\n%s",
+			scopes.GenerateScopeDescription(customIOARuleGroupsScopes),
+		),
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Name of the rule groups to return.",
+			},
+			"platform": schema.StringAttribute{
+				Optional:    true,
+				Description: "Platform of the rule groups to return. (windows, mac, linux)",
+				Validators: []validator.String{
+					stringvalidator.OneOfCaseInsensitive("windows", "mac", "linux"),
+				},
+			},
+			"rule_groups": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The rule groups matching name and platform.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "Identifier for the rule group.",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "Name of the rule group.",
+						},
+						"description": schema.StringAttribute{
+							Computed:    true,
+							Description: "Description of the rule group.",
+						},
+						"platform": schema.StringAttribute{
+							Computed:    true,
+							Description: "Platform of the rule group.",
+						},
+						"enabled": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the rule group is enabled.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *customIOARuleGroupsDataSource) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var state customIOARuleGroupsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var filters []string
+	if state.Name.ValueString() != "" {
+		filters = append(filters, "name:"+utils.QuoteFQL(state.Name.ValueString()))
+	}
+	if state.Platform.ValueString() != "" {
+		filters = append(filters, "platform:"+utils.QuoteFQL(strings.ToLower(state.Platform.ValueString())))
+	}
+	filter := strings.Join(filters, "+")
+
+	state.RuleGroups = []customIOARuleGroup{}
+	limit := policiesQueryLimit
+	var offset int64
+
+	for {
+		offsetStr := strconv.FormatInt(offset, 10)
+		params := &custom_ioa.QueryRuleGroupsFullParams{
+			Context: ctx,
+			Limit:   &limit,
+			Offset:  &offsetStr,
+		}
+
+		if filter != "" {
+			params.Filter = &filter
+		}
+
+		res, err := d.client.CustomIoa.QueryRuleGroupsFull(params)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to read custom IOA rule groups",
+				tferrors.Message(err, customIOARuleGroupsScopes...),
+			)
+			return
+		}
+
+		for _, g := range res.Payload.Resources {
+			if g == nil || (g.Deleted != nil && *g.Deleted) {
+				continue
+			}
+
+			state.RuleGroups = append(state.RuleGroups, customIOARuleGroup{
+				ID:          types.StringPointerValue(g.ID),
+				Name:        types.StringPointerValue(g.Name),
+				Description: types.StringPointerValue(g.Description),
+				Platform:    types.StringPointerValue(g.Platform),
+				Enabled:     types.BoolPointerValue(g.Enabled),
+			})
+		}
+
+		var more bool
+		offset, more = nextPageOffset(offset, len(res.Payload.Resources), res.Payload.Meta)
+		if !more {
+			break
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *customIOARuleGroupsDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.CrowdStrikeAPISpecification)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf(
+				"Expected *client.CrowdStrikeAPISpecification, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+		return
+	}
+
+	d.client = client
+}
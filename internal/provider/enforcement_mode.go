@@ -0,0 +1,354 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	membercid "github.com/crowdstrike/terraform-provider-crowdstrike/internal/member_cid"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const (
+	// enforcementModeEnforce applies changes to the CrowdStrike APIs.
+	enforcementModeEnforce = "enforce"
+	// enforcementModeReport refreshes and plans resources, but skips updates and deletes.
+	enforcementModeReport = "report"
+)
+
+// resourceData is the provider data passed to resources. Resources are wrapped by
+// withEnforcementMode, which unwraps the client before configuring the resource.
+type resourceData struct {
+	client             *client.CrowdStrikeAPISpecification
+	memberClients      *membercid.Clients
+	enforcementMode    string
+	validateReferences bool
+}
+
+// withEnforcementMode wraps a resource so updates and deletes are skipped with a
+// warning when the provider enforcement_mode is report. The wrapper implements the
+// same optional interfaces as the resource it wraps.
+func withEnforcementMode(newResource func() resource.Resource) func() resource.Resource {
+	return func() resource.Resource {
+		r := &enforcedResource{resource: newResource()}
+
+		_, importable := r.resource.(resource.ResourceWithImportState)
+		identity, hasIdentity := r.resource.(resource.ResourceWithIdentity)
+
+		switch {
+		case hasIdentity:
+			return &enforcedIdentityResource{enforcedImportableResource{r}, identity}
+		case importable:
+			return &enforcedImportableResource{r}
+		default:
+			return r
+		}
+	}
+}
+
+var (
+	_ resource.Resource                   = &enforcedResource{}
+	_ resource.ResourceWithConfigure      = &enforcedResource{}
+	_ resource.ResourceWithModifyPlan     = &enforcedResource{}
+	_ resource.ResourceWithValidateConfig = &enforcedResource{}
+	_ resource.ResourceWithImportState    = &enforcedImportableResource{}
+	_ resource.ResourceWithIdentity       = &enforcedIdentityResource{}
+)
+
+// enforcedResource forwards every call to the wrapped resource, except updates and
+// deletes in report mode. Planned references are checked when validate_references is set,
+// and creates of resources that belong to a licensed module check the module is entitled.
+type enforcedResource struct {
+	resource           resource.Resource
+	client             *client.CrowdStrikeAPISpecification
+	memberClients      *membercid.Clients
+	reportOnly         bool
+	validateReferences bool
+}
+
+// typeName returns the type name of the wrapped resource for diagnostics.
+func (r *enforcedResource) typeName(ctx context.Context) string {
+	var resp resource.MetadataResponse
+	r.resource.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "crowdstrike"}, &resp)
+	return resp.TypeName
+}
+
+func (r *enforcedResource) Metadata(
+	ctx context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	r.resource.Metadata(ctx, req, resp)
+}
+
+func (r *enforcedResource) Schema(
+	ctx context.Context,
+	req resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	r.resource.Schema(ctx, req, resp)
+}
+
+func (r *enforcedResource) Configure(
+	ctx context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if data, ok := req.ProviderData.(*resourceData); ok {
+		r.client = data.client
+		r.memberClients = data.memberClients
+		r.reportOnly = data.enforcementMode == enforcementModeReport
+		r.validateReferences = data.validateReferences
+		req.ProviderData = data.client
+
+		if res, ok := r.resource.(membercid.Resource); ok {
+			res.SetMemberClients(data.memberClients)
+		}
+	}
+
+	if res, ok := r.resource.(resource.ResourceWithConfigure); ok {
+		res.Configure(ctx, req, resp)
+	}
+}
+
+func (r *enforcedResource) ValidateConfig(
+	ctx context.Context,
+	req resource.ValidateConfigRequest,
+	resp *resource.ValidateConfigResponse,
+) {
+	if res, ok := r.resource.(resource.ResourceWithValidateConfig); ok {
+		res.ValidateConfig(ctx, req, resp)
+	}
+}
+
+func (r *enforcedResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	if res, ok := r.resource.(resource.ResourceWithModifyPlan); ok {
+		res.ModifyPlan(ctx, req, resp)
+	}
+
+	// resources in a child CID are checked against the entitlements and objects of that CID.
+	c, diags := r.planClient(ctx, resp.Plan)
+	resp.Diagnostics.Append(diags...)
+
+	if c != nil && req.State.Raw.IsNull() && !req.Plan.Raw.IsNull() && !resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(checkCapability(ctx, c, r.typeName(ctx))...)
+	}
+
+	if r.validateReferences && c != nil && !resp.Plan.Raw.IsNull() && !resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(validatePlanReferences(ctx, c, resp.Plan.Raw)...)
+	}
+
+	if !r.reportOnly || req.State.Raw.IsNull() || resp.Diagnostics.HasError() {
+		return
+	}
+
+	if req.Plan.Raw.IsNull() {
+		resp.Diagnostics.AddWarning(
+			"Delete Will Be Skipped",
+			fmt.Sprintf(
+				"The provider enforcement_mode is %s, %s will be removed from the Terraform state but will not be deleted in Falcon.",
+				enforcementModeReport,
+				r.typeName(ctx),
+			),
+		)
+		return
+	}
+
+	var schemaResp resource.SchemaResponse
+	r.resource.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	replacements := append(
+		slices.Clone(resp.RequiresReplace),
+		plannedReplacements(ctx, schemaResp.Schema, req.Config, resp.Plan, req.State)...,
+	)
+
+	if len(replacements) > 0 {
+		// a replacement runs the skipped delete and then a real create, which would leave a
+		// second copy of the object in Falcon.
+		resp.Diagnostics.AddError(
+			"Replacement Not Allowed",
+			fmt.Sprintf(
+				"The provider enforcement_mode is %s, the planned changes to %s require replacing it, which would create a new object in Falcon without deleting the current one. "+
+					"Revert the changes to %s or set enforcement_mode to %s.",
+				enforcementModeReport,
+				r.typeName(ctx),
+				attributePaths(replacements),
+				enforcementModeEnforce,
+			),
+		)
+		return
+	}
+
+	if !resp.Plan.Raw.Equal(req.State.Raw) {
+		resp.Diagnostics.AddWarning(
+			"Update Will Be Skipped",
+			fmt.Sprintf(
+				"The provider enforcement_mode is %s, the planned changes to %s show drift from the configuration but will not be applied in Falcon.",
+				enforcementModeReport,
+				r.typeName(ctx),
+			),
+		)
+	}
+}
+
+func (r *enforcedResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	r.resource.Create(ctx, req, resp)
+}
+
+func (r *enforcedResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	r.resource.Read(ctx, req, resp)
+}
+
+func (r *enforcedResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	if !r.reportOnly {
+		r.resource.Update(ctx, req, resp)
+		return
+	}
+
+	// The planned values are saved so Terraform accepts the apply, the next refresh
+	// reads the values from Falcon and shows the drift again.
+	state, err := planWithPriorState(req.Plan.Raw, req.State.Raw)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to skip update",
+			fmt.Sprintf("Could not build the state of %s from the plan: %s", r.typeName(ctx), err),
+		)
+		return
+	}
+
+	resp.State = tfsdk.State{Schema: resp.State.Schema, Raw: state}
+	resp.Diagnostics.AddWarning(
+		"Update Skipped",
+		fmt.Sprintf(
+			"The provider enforcement_mode is %s, the changes to %s were not applied in Falcon.",
+			enforcementModeReport,
+			r.typeName(ctx),
+		),
+	)
+}
+
+func (r *enforcedResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	if !r.reportOnly {
+		r.resource.Delete(ctx, req, resp)
+		return
+	}
+
+	resp.Diagnostics.AddWarning(
+		"Delete Skipped",
+		fmt.Sprintf(
+			"The provider enforcement_mode is %s, %s was removed from the Terraform state but was not deleted in Falcon.",
+			enforcementModeReport,
+			r.typeName(ctx),
+		),
+	)
+}
+
+// enforcedImportableResource wraps a resource that supports import.
+type enforcedImportableResource struct {
+	*enforcedResource
+}
+
+func (r *enforcedImportableResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	res, ok := r.resource.(resource.ResourceWithImportState)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Resource Import Not Implemented",
+			fmt.Sprintf("%s does not support import.", r.typeName(ctx)),
+		)
+		return
+	}
+
+	res.ImportState(ctx, req, resp)
+}
+
+// enforcedIdentityResource wraps a resource that supports resource identity.
+type enforcedIdentityResource struct {
+	enforcedImportableResource
+	identity resource.ResourceWithIdentity
+}
+
+func (r *enforcedIdentityResource) IdentitySchema(
+	ctx context.Context,
+	req resource.IdentitySchemaRequest,
+	resp *resource.IdentitySchemaResponse,
+) {
+	r.identity.IdentitySchema(ctx, req, resp)
+}
+
+// planClient returns the client of the member_cid planned for the resource, or the
+// provider client when the resource has no member_cid attribute or it is not set.
+func (r *enforcedResource) planClient(
+	ctx context.Context,
+	plan tfsdk.Plan,
+) (*client.CrowdStrikeAPISpecification, diag.Diagnostics) {
+	if plan.Raw.IsNull() {
+		return r.client, nil
+	}
+
+	var memberCID types.String
+	if diags := plan.GetAttribute(ctx, path.Root("member_cid"), &memberCID); diags.HasError() {
+		return r.client, nil
+	}
+
+	return membercid.ClientFor(r.client, r.memberClients, memberCID)
+}
+
+// attributePaths returns the paths as a comma separated list for diagnostics.
+func attributePaths(paths path.Paths) string {
+	names := make([]string, 0, len(paths))
+	for _, p := range paths {
+		names = append(names, p.String())
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// planWithPriorState returns the planned value with unknown values replaced by the
+// prior state, or null when the prior state has no value at the same path.
+func planWithPriorState(plan, prior tftypes.Value) (tftypes.Value, error) {
+	return tftypes.Transform(plan, func(p *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
+		if v.IsKnown() {
+			return v, nil
+		}
+
+		priorValue, _, err := tftypes.WalkAttributePath(prior, p)
+		if err == nil {
+			if value, ok := priorValue.(tftypes.Value); ok && value.Type().Equal(v.Type()) {
+				return value, nil
+			}
+		}
+
+		return tftypes.NewValue(v.Type(), nil), nil
+	})
+}
@@ -0,0 +1,399 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	membercid "github.com/crowdstrike/terraform-provider-crowdstrike/internal/member_cid"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestPlanWithPriorState(t *testing.T) {
+	objectType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id":           tftypes.String,
+			"name":         tftypes.String,
+			"last_updated": tftypes.String,
+			"host_groups":  tftypes.Set{ElementType: tftypes.String},
+		},
+	}
+
+	prior := tftypes.NewValue(objectType, map[string]tftypes.Value{
+		"id":           tftypes.NewValue(tftypes.String, "abc123"),
+		"name":         tftypes.NewValue(tftypes.String, "console name"),
+		"last_updated": tftypes.NewValue(tftypes.String, "yesterday"),
+		"host_groups":  tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, nil),
+	})
+
+	plan := tftypes.NewValue(objectType, map[string]tftypes.Value{
+		"id":           tftypes.NewValue(tftypes.String, "abc123"),
+		"name":         tftypes.NewValue(tftypes.String, "terraform name"),
+		"last_updated": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"host_groups": tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		}),
+	})
+
+	got, err := planWithPriorState(plan, prior)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := tftypes.NewValue(objectType, map[string]tftypes.Value{
+		"id":           tftypes.NewValue(tftypes.String, "abc123"),
+		"name":         tftypes.NewValue(tftypes.String, "terraform name"),
+		"last_updated": tftypes.NewValue(tftypes.String, "yesterday"),
+		"host_groups": tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, nil),
+		}),
+	})
+
+	if !got.Equal(want) {
+		t.Errorf("planWithPriorState() = %s, want %s", got, want)
+	}
+}
+
+func TestEnforcementModeReport_mock(t *testing.T) {
+	t.Setenv("FALCON_ENFORCEMENT_MODE", enforcementModeReport)
+
+	m := newMockFalcon(t)
+	mockHostGroups(m)
+
+	mockUnitTest(t, m, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: m.providerConfig() + `
+resource "crowdstrike_host_group" "test" {
+  name            = "mock"
+  description     = "made with terraform"
+  type            = "dynamic"
+  assignment_rule = "platform_name:'Linux'"
+}
+`,
+				Check: resource.TestCheckResourceAttr("crowdstrike_host_group.test", "id", "mock-host-group-1"),
+			},
+			{
+				Config: m.providerConfig() + `
+resource "crowdstrike_host_group" "test" {
+  name            = "mock-updated"
+  description     = "made with terraform"
+  type            = "dynamic"
+  assignment_rule = "platform_name:'Linux'"
+}
+`,
+				// The update is skipped, so the refresh after apply shows the drift again.
+				ExpectNonEmptyPlan: true,
+				Check: func(_ *terraform.State) error {
+					if n := m.calls("PATCH /devices/entities/host-groups/v1"); n != 0 {
+						return fmt.Errorf("expected the host group not to be updated, got %d updates", n)
+					}
+					return nil
+				},
+			},
+		},
+		CheckDestroy: func(_ *terraform.State) error {
+			if n := m.calls("DELETE /devices/entities/host-groups/v1"); n != 0 {
+				return fmt.Errorf("expected the host group not to be deleted, got %d deletes", n)
+			}
+			return nil
+		},
+	})
+}
+
+func TestEnforcementModeReport_replace_mock(t *testing.T) {
+	t.Setenv("FALCON_ENFORCEMENT_MODE", enforcementModeReport)
+
+	m := newMockFalcon(t)
+
+	m.handle("GET /snapshots/combined/deployments/v1", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]any{"resources": []any{}})
+	})
+
+	m.handle("POST /snapshots/entities/deployments/v1", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]any{"resources": []any{
+			map[string]any{"id": "job-1", "asset_identifier": "i-0123456789abcdef0", "status": "pending"},
+		}})
+	})
+
+	m.handle("GET /snapshots/entities/deployments/v1", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]any{"resources": []any{
+			map[string]any{"id": "job-1", "status": "completed"},
+		}})
+	})
+
+	config := func(region string) string {
+		return m.providerConfig() + fmt.Sprintf(`
+resource "crowdstrike_snapshot_scan_job" "test" {
+  cloud_provider = "aws"
+  account_id     = "123456789012"
+  region         = %q
+  instance_ids   = ["i-0123456789abcdef0"]
+}
+`, region)
+	}
+
+	mockUnitTest(t, m, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: config("us-east-1"),
+			},
+			{
+				// region requires replacement, which report mode would turn into a second scan.
+				Config:      config("us-west-2"),
+				ExpectError: regexp.MustCompile(`Replacement Not Allowed`),
+				Check: func(_ *terraform.State) error {
+					if n := m.calls("POST /snapshots/entities/deployments/v1"); n != 1 {
+						return fmt.Errorf("expected a single scan to be started, got %d", n)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+// stubResource is a resource that does nothing, used to test the enforcement wrapper.
+type stubResource struct{}
+
+func (stubResource) Metadata(_ context.Context, req fwresource.MetadataRequest, resp *fwresource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_stub"
+}
+
+func (stubResource) Schema(_ context.Context, _ fwresource.SchemaRequest, resp *fwresource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Optional: true,
+			},
+			"settings": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"region": schema.StringAttribute{
+						Required: true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (stubResource) Create(context.Context, fwresource.CreateRequest, *fwresource.CreateResponse) {}
+
+func (stubResource) Read(context.Context, fwresource.ReadRequest, *fwresource.ReadResponse) {}
+
+func (stubResource) Update(context.Context, fwresource.UpdateRequest, *fwresource.UpdateResponse) {}
+
+func (stubResource) Delete(context.Context, fwresource.DeleteRequest, *fwresource.DeleteResponse) {}
+
+// stubProvider serves stubResource wrapped for enforcement_mode.
+type stubProvider struct {
+	enforcementMode string
+}
+
+func (p *stubProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "crowdstrike"
+}
+
+func (p *stubProvider) Schema(context.Context, provider.SchemaRequest, *provider.SchemaResponse) {}
+
+func (p *stubProvider) Configure(_ context.Context, _ provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	resp.ResourceData = &resourceData{enforcementMode: p.enforcementMode}
+}
+
+func (p *stubProvider) Resources(context.Context) []func() fwresource.Resource {
+	return []func() fwresource.Resource{
+		withEnforcementMode(func() fwresource.Resource { return stubResource{} }),
+	}
+}
+
+func (p *stubProvider) DataSources(context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+func TestEnforcedResourcePlan_replace(t *testing.T) {
+	settingsType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"region": tftypes.String}}
+	objectType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"id":       tftypes.String,
+		"name":     tftypes.String,
+		"settings": settingsType,
+	}}
+
+	value := func(id any, name, region string) tftypes.Value {
+		return tftypes.NewValue(objectType, map[string]tftypes.Value{
+			"id":   tftypes.NewValue(tftypes.String, id),
+			"name": tftypes.NewValue(tftypes.String, name),
+			"settings": tftypes.NewValue(settingsType, map[string]tftypes.Value{
+				"region": tftypes.NewValue(tftypes.String, region),
+			}),
+		})
+	}
+
+	dynamicValue := func(v tftypes.Value) *tfprotov6.DynamicValue {
+		dv, err := tfprotov6.NewDynamicValue(objectType, v)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		return &dv
+	}
+
+	tests := []struct {
+		name            string
+		enforcementMode string
+		planName        string
+		planRegion      string
+		wantError       bool
+		wantWarning     bool
+	}{
+		{
+			name:            "report replace",
+			enforcementMode: enforcementModeReport,
+			planName:        "stub",
+			planRegion:      "us-west-2",
+			wantError:       true,
+		},
+		{
+			name:            "report update",
+			enforcementMode: enforcementModeReport,
+			planName:        "renamed",
+			planRegion:      "us-east-1",
+			wantWarning:     true,
+		},
+		{
+			name:            "enforce replace",
+			enforcementMode: enforcementModeEnforce,
+			planName:        "stub",
+			planRegion:      "us-west-2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			server := providerserver.NewProtocol6(&stubProvider{enforcementMode: tt.enforcementMode})()
+
+			emptyConfig, err := tfprotov6.NewDynamicValue(tftypes.Object{}, tftypes.NewValue(tftypes.Object{}, map[string]tftypes.Value{}))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			configureResp, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{Config: &emptyConfig})
+			if err != nil || len(configureResp.Diagnostics) != 0 {
+				t.Fatalf("unable to configure the provider: %v %v", err, configureResp.Diagnostics)
+			}
+
+			resp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+				TypeName:         "crowdstrike_stub",
+				PriorState:       dynamicValue(value("abc123", "stub", "us-east-1")),
+				ProposedNewState: dynamicValue(value("abc123", tt.planName, tt.planRegion)),
+				Config:           dynamicValue(value(nil, tt.planName, tt.planRegion)),
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			var gotError, gotWarning bool
+			for _, d := range resp.Diagnostics {
+				switch d.Severity {
+				case tfprotov6.DiagnosticSeverityError:
+					gotError = gotError || d.Summary == "Replacement Not Allowed"
+				case tfprotov6.DiagnosticSeverityWarning:
+					gotWarning = gotWarning || d.Summary == "Update Will Be Skipped"
+				}
+			}
+
+			if gotError != tt.wantError {
+				t.Errorf("expected replacement error %t, got diagnostics %v", tt.wantError, resp.Diagnostics)
+			}
+			if gotWarning != tt.wantWarning {
+				t.Errorf("expected update warning %t, got diagnostics %v", tt.wantWarning, resp.Diagnostics)
+			}
+			if !tt.wantError && tt.planRegion != "us-east-1" && len(resp.RequiresReplace) == 0 {
+				t.Errorf("expected the region change to require replacement")
+			}
+		})
+	}
+}
+
+func TestEnforcedResourcePlanClient(t *testing.T) {
+	m := newMockFalcon(t)
+	members := membercid.NewClients(m.apiConfig())
+	provider := m.client(t)
+	r := &enforcedResource{client: provider, memberClients: members}
+
+	ctx := context.Background()
+	withMemberCID := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":         schema.StringAttribute{Computed: true},
+			"member_cid": membercid.Attribute("stub"),
+		},
+	}
+	objectType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"id":         tftypes.String,
+		"member_cid": tftypes.String,
+	}}
+
+	plan := func(memberCID any) tfsdk.Plan {
+		return tfsdk.Plan{
+			Schema: withMemberCID,
+			Raw: tftypes.NewValue(objectType, map[string]tftypes.Value{
+				"id":         tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+				"member_cid": tftypes.NewValue(tftypes.String, memberCID),
+			}),
+		}
+	}
+
+	childA, _ := members.Client("child-a")
+
+	c, diags := r.planClient(ctx, plan("child-a"))
+	if diags.HasError() || c != childA {
+		t.Errorf("expected the member CID client when member_cid is planned, got %v", diags)
+	}
+
+	c, diags = r.planClient(ctx, plan(nil))
+	if diags.HasError() || c != provider {
+		t.Errorf("expected the provider client when member_cid is not set, got %v", diags)
+	}
+
+	var stub fwresource.SchemaResponse
+	stubResource{}.Schema(ctx, fwresource.SchemaRequest{}, &stub)
+	stubType, ok := stub.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	if !ok {
+		t.Fatal("expected the stub schema to be an object")
+	}
+	stubValues := map[string]tftypes.Value{}
+	for name, attrType := range stubType.AttributeTypes {
+		stubValues[name] = tftypes.NewValue(attrType, nil)
+	}
+	stubPlan := tfsdk.Plan{
+		Schema: stub.Schema,
+		Raw:    tftypes.NewValue(stubType, stubValues),
+	}
+
+	c, diags = r.planClient(ctx, stubPlan)
+	if diags.HasError() || c != provider {
+		t.Errorf("expected the provider client for a resource without member_cid, got %v", diags)
+	}
+}
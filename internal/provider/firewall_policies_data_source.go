@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/client/firewall_policies"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/scopes"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/tferrors"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &firewallPoliciesDataSource{}
+	_ datasource.DataSourceWithConfigure = &firewallPoliciesDataSource{}
+)
+
+var firewallPoliciesScopes = []scopes.Scope{
+	{
+		Name:  "Firewall management",
+		Read:  true,
+		Write: false,
+	},
+}
+
+// NewFirewallPoliciesDataSource is a helper function to simplify the provider implementation.
+func NewFirewallPoliciesDataSource() datasource.DataSource {
+	return &firewallPoliciesDataSource{}
+}
+
+// firewallPoliciesDataSource is the data source implementation.
+type firewallPoliciesDataSource struct {
+	client *client.CrowdStrikeAPISpecification
+}
+
+// Metadata returns the data source type name.
+func (d *firewallPoliciesDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_firewall_policies"
+}
+
+// Schema defines the schema for the data source.
+func (d *firewallPoliciesDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: fmt.Sprintf(
+			"Firewall Policy --- This data source returns the firewall policies matching an FQL filter.\n\n%s",
+			scopes.GenerateScopeDescription(firewallPoliciesScopes),
+		),
+		Attributes: policiesDataSourceAttributes("firewall"),
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *firewallPoliciesDataSource) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var state policiesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Policies = []policyDataModel{}
+	limit := policiesQueryLimit
+	var offset int64
+
+	for {
+		params := &firewall_policies.QueryCombinedFirewallPoliciesParams{
+			Context: ctx,
+			Limit:   &limit,
+			Offset:  &offset,
+		}
+
+		if state.Filter.ValueString() != "" {
+			params.Filter = state.Filter.ValueStringPointer()
+		}
+
+		res, err := d.client.FirewallPolicies.QueryCombinedFirewallPolicies(params)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to read firewall policies",
+				tferrors.Message(err, firewallPoliciesScopes...),
+			)
+			return
+		}
+
+		for _, p := range res.Payload.Resources {
+			policy, diags := newPolicyDataModel(
+				ctx,
+				p.ID,
+				p.Name,
+				p.Description,
+				p.PlatformName,
+				p.Enabled,
+				p.Groups,
+			)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			state.Policies = append(state.Policies, policy)
+		}
+
+		var more bool
+		offset, more = nextPageOffset(offset, len(res.Payload.Resources), res.Payload.Meta)
+		if !more {
+			break
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *firewallPoliciesDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.CrowdStrikeAPISpecification)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf(
+				"Expected *client.CrowdStrikeAPISpecification, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+		return
+	}
+
+	d.client = client
+}
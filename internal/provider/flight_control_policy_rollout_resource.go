@@ -0,0 +1,781 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/client/sensor_update_policies"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+	membercid "github.com/crowdstrike/terraform-provider-crowdstrike/internal/member_cid"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/scopes"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/tferrors"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource               = &policyRolloutResource{}
+	_ resource.ResourceWithConfigure  = &policyRolloutResource{}
+	_ resource.ResourceWithModifyPlan = &policyRolloutResource{}
+	_ membercid.Resource              = &policyRolloutResource{}
+)
+
+const (
+	// rolloutInSync is the status of a copy that matches the policy definition.
+	rolloutInSync = "in_sync"
+	// rolloutDrifted is the status of a copy that was changed outside of Terraform.
+	rolloutDrifted = "drifted"
+	// rolloutMissing is the status of a copy that was deleted outside of Terraform.
+	rolloutMissing = "missing"
+	// rolloutFailed is the status of a copy that could not be created or updated.
+	rolloutFailed = "failed"
+)
+
+// policyRolloutAttrTypes are the attribute types of an element of the policies attribute.
+var policyRolloutAttrTypes = map[string]attr.Type{
+	"policy_id": types.StringType,
+	"status":    types.StringType,
+}
+
+// NewPolicyRolloutResource is a helper function to simplify the provider implementation.
+func NewPolicyRolloutResource() resource.Resource {
+	return &policyRolloutResource{}
+}
+
+// policyRolloutResource is the resource implementation.
+type policyRolloutResource struct {
+	memberClients *membercid.Clients
+}
+
+// policyRolloutResourceModel maps the resource schema data.
+type policyRolloutResourceModel struct {
+	ID                 types.String                    `tfsdk:"id"`
+	MemberCIDs         types.Set                       `tfsdk:"member_cids"`
+	SensorUpdatePolicy policyRolloutSensorUpdatePolicy `tfsdk:"sensor_update_policy"`
+	Policies           types.Map                       `tfsdk:"policies"`
+	LastUpdated        types.String                    `tfsdk:"last_updated"`
+	Timeouts           timeouts.Value                  `tfsdk:"timeouts"`
+}
+
+// policyRolloutSensorUpdatePolicy is the sensor update policy copied to each member CID.
+type policyRolloutSensorUpdatePolicy struct {
+	Name                types.String `tfsdk:"name"`
+	Description         types.String `tfsdk:"description"`
+	PlatformName        types.String `tfsdk:"platform_name"`
+	Build               types.String `tfsdk:"build"`
+	BuildArm64          types.String `tfsdk:"build_arm64"`
+	Enabled             types.Bool   `tfsdk:"enabled"`
+	UninstallProtection types.Bool   `tfsdk:"uninstall_protection"`
+}
+
+// policyRolloutCopy is the copy of the policy in a member CID.
+type policyRolloutCopy struct {
+	PolicyID types.String `tfsdk:"policy_id"`
+	Status   types.String `tfsdk:"status"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *policyRolloutResource) Configure(
+	ctx context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	if _, ok := req.ProviderData.(*client.CrowdStrikeAPISpecification); !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf(
+				"Expected *client.CrowdStrikeAPISpecification, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+	}
+}
+
+// SetMemberClients adds the clients used for each member CID.
+func (r *policyRolloutResource) SetMemberClients(m *membercid.Clients) {
+	r.memberClients = m
+}
+
+// Metadata returns the resource type name.
+func (r *policyRolloutResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_flight_control_policy_rollout"
+}
+
+// Schema defines the schema for the resource.
+func (r *policyRolloutResource) Schema(
+	ctx context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: fmt.Sprintf(
+			"Flight Control Policy Rollout --- This resource creates a copy of a sensor update policy in each child CID of a Falcon Flight Control parent and keeps the copies in sync with the definition. "+
+				"The provider requests a token for each child CID, so a single provider configuration replaces a provider alias per child. "+
+				"Copies that drift from the definition or are deleted outside of Terraform are updated or recreated on the next apply. "+
+				"Requires the provider to authenticate with client_id and client_secret of the parent CID.\n\n%s",
+			scopes.GenerateScopeDescription(sensorUpdatePolicyScopes),
+		),
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Identifier for the rollout.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:    true,
+				Description: "Timestamp of the last Terraform update of the resource.",
+			},
+			"member_cids": schema.SetAttribute{
+				Required:    true,
+				ElementType: types.StringType,
+				Description: "CIDs of the child tenants to create a copy of the policy in. The copy is deleted from a CID removed from the set.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"sensor_update_policy": schema.SingleNestedAttribute{
+				Required:    true,
+				Description: "The sensor update policy created in each member CID.",
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						Required:    true,
+						Description: "Name of the sensor update policy.",
+					},
+					"description": schema.StringAttribute{
+						Optional:    true,
+						Description: "Description of the sensor update policy.",
+					},
+					"platform_name": schema.StringAttribute{
+						Required:    true,
+						Description: "Platform for the sensor update policy to manage. (Windows, Mac, Linux) Changing this value forces a new resource.",
+						Validators: []validator.String{
+							stringvalidator.OneOf("Windows", "Linux", "Mac"),
+						},
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
+					"build": schema.StringAttribute{
+						Required:    true,
+						Description: "Sensor build to use for the sensor update policy.",
+					},
+					"build_arm64": schema.StringAttribute{
+						Optional:    true,
+						Description: "Sensor arm64 build to use for the sensor update policy (Linux only). Required if platform_name is Linux.",
+					},
+					"enabled": schema.BoolAttribute{
+						Optional:    true,
+						Computed:    true,
+						Description: "Enable the sensor update policy. Defaults to true.",
+						Default:     booldefault.StaticBool(true),
+					},
+					"uninstall_protection": schema.BoolAttribute{
+						Optional:    true,
+						Computed:    true,
+						Description: "Enable uninstall protection. Windows and Mac only. Defaults to false.",
+						Default:     booldefault.StaticBool(false),
+					},
+				},
+			},
+			"policies": schema.MapNestedAttribute{
+				Computed:    true,
+				Description: "The copy of the policy in each member CID, keyed by member CID.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"policy_id": schema.StringAttribute{
+							Computed:    true,
+							Description: "Identifier of the sensor update policy in the member CID.",
+						},
+						"status": schema.StringAttribute{
+							Computed: true,
+							Description: fmt.Sprintf(
+								"Status of the copy as of the last refresh. One of %s, %s (changed outside of Terraform), %s (deleted outside of Terraform) or %s (the last apply could not create or update it). Copies that are not %s are updated on the next apply.",
+								rolloutInSync, rolloutDrifted, rolloutMissing, rolloutFailed, rolloutInSync,
+							),
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// ModifyPlan plans an update when a copy is not in sync with the definition.
+func (r *policyRolloutResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var policies types.Map
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("policies"), &policies)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	copies, diags := policyRolloutCopies(ctx, policies)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, c := range copies {
+		if c.Status.ValueString() != rolloutInSync {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("policies"), types.MapUnknown(types.ObjectType{AttrTypes: policyRolloutAttrTypes}))...)
+			return
+		}
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *policyRolloutResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan policyRolloutResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	id, err := utils.NewRandomID()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating Flight Control policy rollout",
+			"Could not generate an id for the rollout: "+err.Error(),
+		)
+		return
+	}
+	plan.ID = types.StringValue(id)
+
+	resp.Diagnostics.Append(r.sync(ctx, &plan, map[string]policyRolloutCopy{})...)
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	// the state is set even when a copy failed, so copies that were created are tracked.
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *policyRolloutResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state policyRolloutResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	copies, diags := policyRolloutCopies(ctx, state.Policies)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for memberCID, c := range copies {
+		if c.PolicyID.IsNull() {
+			continue
+		}
+
+		apiClient, diags := r.client(memberCID)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		policy, err := getRolloutPolicy(ctx, apiClient, c.PolicyID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading CrowdStrike sensor update policy",
+				fmt.Sprintf(
+					"Could not read sensor update policy %s in member CID %s: %s",
+					c.PolicyID.ValueString(),
+					memberCID,
+					tferrors.Message(err, sensorUpdatePolicyScopes...),
+				),
+			)
+			return
+		}
+
+		status := rolloutMissing
+		if policy != nil {
+			status = rolloutPolicyStatus(state.SensorUpdatePolicy, policy)
+		}
+
+		if status != c.Status.ValueString() {
+			tflog.Info(ctx, "Flight Control policy rollout copy changed", map[string]any{
+				"member_cid": memberCID,
+				"policy_id":  c.PolicyID.ValueString(),
+				"status":     status,
+			})
+		}
+
+		c.Status = types.StringValue(status)
+		copies[memberCID] = c
+	}
+
+	state.Policies, diags = types.MapValueFrom(ctx, types.ObjectType{AttrTypes: policyRolloutAttrTypes}, copies)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *policyRolloutResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var plan, state policyRolloutResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	copies, diags := policyRolloutCopies(ctx, state.Policies)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.sync(ctx, &plan, copies)...)
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *policyRolloutResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var state policyRolloutResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	copies, diags := policyRolloutCopies(ctx, state.Policies)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for memberCID, c := range copies {
+		resp.Diagnostics.Append(r.deleteCopy(ctx, memberCID, c)...)
+	}
+}
+
+// sync creates or updates the copy of the policy in every member CID of plan and deletes
+// the copies of member CIDs that were removed. plan.Policies is set to the result, a copy
+// that could not be created or updated has the failed status and an error is returned.
+func (r *policyRolloutResource) sync(
+	ctx context.Context,
+	plan *policyRolloutResourceModel,
+	prior map[string]policyRolloutCopy,
+) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var memberCIDs []string
+	diags.Append(plan.MemberCIDs.ElementsAs(ctx, &memberCIDs, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	copies := map[string]policyRolloutCopy{}
+
+	for memberCID, c := range prior {
+		if slices.Contains(memberCIDs, memberCID) {
+			continue
+		}
+
+		deleteDiags := r.deleteCopy(ctx, memberCID, c)
+		diags.Append(deleteDiags...)
+		if deleteDiags.HasError() {
+			// keep the copy so the delete is retried on the next apply.
+			c.Status = types.StringValue(rolloutFailed)
+			copies[memberCID] = c
+		}
+	}
+
+	for _, memberCID := range memberCIDs {
+		c, ok := prior[memberCID]
+		if !ok || c.PolicyID.IsNull() || c.Status.ValueString() == rolloutMissing {
+			c = policyRolloutCopy{PolicyID: types.StringNull()}
+		}
+
+		policyID, syncDiags := r.syncCopy(ctx, memberCID, c.PolicyID.ValueString(), plan.SensorUpdatePolicy)
+		diags.Append(syncDiags...)
+
+		if policyID != "" {
+			c.PolicyID = types.StringValue(policyID)
+		}
+
+		c.Status = types.StringValue(rolloutInSync)
+		if syncDiags.HasError() {
+			c.Status = types.StringValue(rolloutFailed)
+		}
+
+		copies[memberCID] = c
+	}
+
+	policies, mapDiags := types.MapValueFrom(ctx, types.ObjectType{AttrTypes: policyRolloutAttrTypes}, copies)
+	diags.Append(mapDiags...)
+	plan.Policies = policies
+
+	return diags
+}
+
+// syncCopy creates the policy in the member CID when policyID is empty, or updates it
+// otherwise, and returns the id of the policy. The id is returned when the policy was
+// created even if it could not be enabled.
+func (r *policyRolloutResource) syncCopy(
+	ctx context.Context,
+	memberCID string,
+	policyID string,
+	definition policyRolloutSensorUpdatePolicy,
+) (string, diag.Diagnostics) {
+	apiClient, diags := r.client(memberCID)
+	if diags.HasError() {
+		return policyID, diags
+	}
+
+	settings := rolloutPolicySettings(definition)
+
+	if policyID == "" {
+		var res *sensor_update_policies.CreateSensorUpdatePoliciesV2Created
+		err := utils.RetryOnConflict(ctx, func() error {
+			var err error
+			res, err = apiClient.SensorUpdatePolicies.CreateSensorUpdatePoliciesV2(
+				&sensor_update_policies.CreateSensorUpdatePoliciesV2Params{
+					Context: ctx,
+					Body: &models.SensorUpdateCreatePoliciesReqV2{
+						Resources: []*models.SensorUpdateCreatePolicyReqV2{
+							{
+								Name:         definition.Name.ValueStringPointer(),
+								PlatformName: definition.PlatformName.ValueStringPointer(),
+								Description:  definition.Description.ValueString(),
+								Settings:     settings,
+							},
+						},
+					},
+				},
+			)
+			return err
+		})
+		if err != nil {
+			diags.AddError(
+				"Error creating sensor update policy",
+				fmt.Sprintf("Could not create sensor update policy in member CID %s: %s", memberCID, tferrors.Message(err, sensorUpdatePolicyScopes...)),
+			)
+			return "", diags
+		}
+
+		policyID = *res.Payload.Resources[0].ID
+	} else {
+		_, err := apiClient.SensorUpdatePolicies.UpdateSensorUpdatePoliciesV2(
+			&sensor_update_policies.UpdateSensorUpdatePoliciesV2Params{
+				Context: ctx,
+				Body: &models.SensorUpdateUpdatePoliciesReqV2{
+					Resources: []*models.SensorUpdateUpdatePolicyReqV2{
+						{
+							ID:          &policyID,
+							Name:        definition.Name.ValueString(),
+							Description: definition.Description.ValueString(),
+							Settings:    settings,
+						},
+					},
+				},
+			},
+		)
+		if err != nil {
+			diags.AddError(
+				"Error updating sensor update policy",
+				fmt.Sprintf("Could not update sensor update policy %s in member CID %s: %s", policyID, memberCID, tferrors.Message(err, sensorUpdatePolicyScopes...)),
+			)
+			return policyID, diags
+		}
+	}
+
+	if err := setRolloutPolicyEnabled(ctx, apiClient, policyID, definition.Enabled.ValueBool()); err != nil {
+		diags.AddError(
+			"Error enabling sensor update policy",
+			fmt.Sprintf("Could not set the enabled state of sensor update policy %s in member CID %s: %s", policyID, memberCID, tferrors.Message(err, sensorUpdatePolicyScopes...)),
+		)
+	}
+
+	return policyID, diags
+}
+
+// deleteCopy disables and deletes the copy of the policy in the member CID. A copy that
+// is already deleted is ignored.
+func (r *policyRolloutResource) deleteCopy(
+	ctx context.Context,
+	memberCID string,
+	c policyRolloutCopy,
+) diag.Diagnostics {
+	if c.PolicyID.IsNull() || c.Status.ValueString() == rolloutMissing {
+		return nil
+	}
+
+	apiClient, diags := r.client(memberCID)
+	if diags.HasError() {
+		return diags
+	}
+
+	policyID := c.PolicyID.ValueString()
+
+	// the policy must be disabled before it can be deleted.
+	err := setRolloutPolicyEnabled(ctx, apiClient, policyID, false)
+	if err == nil {
+		_, err = apiClient.SensorUpdatePolicies.DeleteSensorUpdatePolicies(
+			&sensor_update_policies.DeleteSensorUpdatePoliciesParams{
+				Context: ctx,
+				Ids:     []string{policyID},
+			},
+		)
+	}
+
+	if err != nil && !tferrors.IsNotFound(err) {
+		diags.AddError(
+			"Error deleting sensor update policy",
+			fmt.Sprintf("Could not delete sensor update policy %s in member CID %s: %s", policyID, memberCID, tferrors.Message(err, sensorUpdatePolicyScopes...)),
+		)
+	}
+
+	return diags
+}
+
+// client returns the client of the member CID.
+func (r *policyRolloutResource) client(memberCID string) (*client.CrowdStrikeAPISpecification, diag.Diagnostics) {
+	if r.memberClients == nil {
+		var diags diag.Diagnostics
+		diags.AddError(
+			"Unconfigured CrowdStrike Provider",
+			"The provider must be configured before crowdstrike_flight_control_policy_rollout can be used.",
+		)
+		return nil, diags
+	}
+
+	c, diags := r.memberClients.Client(memberCID)
+	if diags.HasError() {
+		// the member clients report errors against the member_cid attribute of a resource.
+		var rolloutDiags diag.Diagnostics
+		for _, d := range diags {
+			rolloutDiags.AddAttributeError(path.Root("member_cids"), d.Summary(), d.Detail())
+		}
+		return nil, rolloutDiags
+	}
+
+	return c, diags
+}
+
+// policyRolloutCopies returns the copies of the policies attribute keyed by member CID.
+func policyRolloutCopies(ctx context.Context, policies types.Map) (map[string]policyRolloutCopy, diag.Diagnostics) {
+	copies := map[string]policyRolloutCopy{}
+	if policies.IsNull() || policies.IsUnknown() {
+		return copies, nil
+	}
+
+	diags := policies.ElementsAs(ctx, &copies, false)
+	return copies, diags
+}
+
+// rolloutPolicySettings returns the settings of the policy definition.
+func rolloutPolicySettings(definition policyRolloutSensorUpdatePolicy) *models.SensorUpdateSettingsReqV2 {
+	uninstallProtection := "DISABLED"
+	if definition.UninstallProtection.ValueBool() {
+		uninstallProtection = "ENABLED"
+	}
+
+	timezone := "Etc/UTC"
+	schedulerEnabled := false
+
+	settings := &models.SensorUpdateSettingsReqV2{
+		Build:               definition.Build.ValueString(),
+		UninstallProtection: uninstallProtection,
+		Scheduler: &models.PolicySensorUpdateScheduler{
+			Enabled:  &schedulerEnabled,
+			Timezone: &timezone,
+		},
+	}
+
+	if strings.EqualFold(definition.PlatformName.ValueString(), "linux") {
+		settings.Variants = linuxVariants(sensorUpdatePolicyResourceModel{
+			BuildArm64:  definition.BuildArm64,
+			BuildZLinux: types.StringNull(),
+		})
+	}
+
+	return settings
+}
+
+// rolloutPolicyStatus returns whether the policy matches the definition.
+func rolloutPolicyStatus(definition policyRolloutSensorUpdatePolicy, policy *models.SensorUpdatePolicyV2) string {
+	deref := func(s *string) string {
+		if s == nil {
+			return ""
+		}
+		return *s
+	}
+
+	uninstallProtection := policy.Settings != nil && deref(policy.Settings.UninstallProtection) == "ENABLED"
+
+	var build, buildArm64 string
+	if policy.Settings != nil {
+		build = deref(policy.Settings.Build)
+		for _, v := range policy.Settings.Variants {
+			if v != nil && strings.EqualFold(deref(v.Platform), linuxArm64Varient) {
+				buildArm64 = deref(v.Build)
+			}
+		}
+	}
+
+	inSync := deref(policy.Name) == definition.Name.ValueString() &&
+		deref(policy.Description) == definition.Description.ValueString() &&
+		build == definition.Build.ValueString() &&
+		(policy.Enabled != nil && *policy.Enabled) == definition.Enabled.ValueBool() &&
+		uninstallProtection == definition.UninstallProtection.ValueBool()
+
+	if strings.EqualFold(definition.PlatformName.ValueString(), "linux") {
+		inSync = inSync && buildArm64 == definition.BuildArm64.ValueString()
+	}
+
+	if inSync {
+		return rolloutInSync
+	}
+
+	return rolloutDrifted
+}
+
+// getRolloutPolicy returns the sensor update policy, or nil when it does not exist.
+func getRolloutPolicy(
+	ctx context.Context,
+	apiClient *client.CrowdStrikeAPISpecification,
+	policyID string,
+) (*models.SensorUpdatePolicyV2, error) {
+	res, err := apiClient.SensorUpdatePolicies.GetSensorUpdatePoliciesV2(
+		&sensor_update_policies.GetSensorUpdatePoliciesV2Params{
+			Context: ctx,
+			Ids:     []string{policyID},
+		},
+	)
+
+	if tferrors.IsNotFound(err) || (err == nil && len(res.Payload.Resources) == 0) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Payload.Resources[0], nil
+}
+
+// setRolloutPolicyEnabled enables or disables a sensor update policy.
+func setRolloutPolicyEnabled(
+	ctx context.Context,
+	apiClient *client.CrowdStrikeAPISpecification,
+	policyID string,
+	enabled bool,
+) error {
+	action := "disable"
+	if enabled {
+		action = "enable"
+	}
+
+	_, err := apiClient.SensorUpdatePolicies.PerformSensorUpdatePoliciesAction(
+		&sensor_update_policies.PerformSensorUpdatePoliciesActionParams{
+			ActionName: action,
+			Context:    ctx,
+			Body: &models.MsaEntityActionRequestV2{
+				Ids: []string{policyID},
+			},
+		},
+	)
+
+	return err
+}
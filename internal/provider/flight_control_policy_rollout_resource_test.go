@@ -0,0 +1,319 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/crowdstrike/gofalcon/falcon/models"
+	membercid "github.com/crowdstrike/terraform-provider-crowdstrike/internal/member_cid"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// mockSensorUpdatePolicies serves the sensor update policy endpoints, keeping the policies
+// of each member CID apart. It returns the policies keyed by member CID and policy id.
+func mockSensorUpdatePolicies(m *mockFalcon) func() map[string]map[string]map[string]any {
+	var mu sync.Mutex
+	policies := map[string]map[string]map[string]any{}
+	next := 0
+
+	write := func(w http.ResponseWriter, status int, resources ...map[string]any) {
+		writeJSON(w, status, map[string]any{"meta": map[string]any{}, "resources": resources, "errors": []any{}})
+	}
+
+	m.handle("POST /policy/entities/sensor-update/v2", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Resources []map[string]any `json:"resources"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		memberCID := requestMemberCID(r)
+		if policies[memberCID] == nil {
+			policies[memberCID] = map[string]map[string]any{}
+		}
+
+		next++
+		policy := body.Resources[0]
+		policy["id"] = fmt.Sprintf("mock-policy-%d", next)
+		policy["enabled"] = false
+		policies[memberCID][policy["id"].(string)] = policy
+
+		write(w, http.StatusCreated, policy)
+	})
+
+	m.handle("PATCH /policy/entities/sensor-update/v2", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Resources []map[string]any `json:"resources"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		update := body.Resources[0]
+		policy, ok := policies[requestMemberCID(r)][update["id"].(string)]
+		if !ok {
+			writeAPIError(w, http.StatusNotFound, "policy not found")
+			return
+		}
+
+		for k, v := range update {
+			policy[k] = v
+		}
+
+		write(w, http.StatusOK, policy)
+	})
+
+	m.handle("GET /policy/entities/sensor-update/v2", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		policy, ok := policies[requestMemberCID(r)][r.URL.Query().Get("ids")]
+		if !ok {
+			writeAPIError(w, http.StatusNotFound, "policy not found")
+			return
+		}
+
+		write(w, http.StatusOK, policy)
+	})
+
+	m.handle("POST /policy/entities/sensor-update-actions/v1", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Ids []string `json:"ids"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		policy, ok := policies[requestMemberCID(r)][body.Ids[0]]
+		if !ok {
+			writeAPIError(w, http.StatusNotFound, "policy not found")
+			return
+		}
+
+		policy["enabled"] = r.URL.Query().Get("action_name") == "enable"
+		write(w, http.StatusOK, policy)
+	})
+
+	m.handle("DELETE /policy/entities/sensor-update/v1", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		delete(policies[requestMemberCID(r)], r.URL.Query().Get("ids"))
+		write(w, http.StatusOK)
+	})
+
+	return func() map[string]map[string]map[string]any {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return policies
+	}
+}
+
+func TestPolicyRolloutResource_mock(t *testing.T) {
+	m := newMockFalcon(t)
+	policies := mockSensorUpdatePolicies(m)
+
+	config := func(memberCIDs string) string {
+		return m.providerConfig() + fmt.Sprintf(`
+resource "crowdstrike_flight_control_policy_rollout" "test" {
+  member_cids = %s
+
+  sensor_update_policy = {
+    name          = "mock"
+    platform_name = "Windows"
+    build         = "18110|n-1|tagged|1"
+  }
+}
+`, memberCIDs)
+	}
+
+	mockUnitTest(t, m, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: config(`["child-a", "child-b"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("crowdstrike_flight_control_policy_rollout.test", "policies.%", "2"),
+					resource.TestCheckResourceAttr("crowdstrike_flight_control_policy_rollout.test", "policies.child-a.status", rolloutInSync),
+					resource.TestCheckResourceAttr("crowdstrike_flight_control_policy_rollout.test", "policies.child-b.status", rolloutInSync),
+				),
+			},
+			{
+				Config: config(`["child-a"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("crowdstrike_flight_control_policy_rollout.test", "policies.%", "1"),
+					func(_ *terraform.State) error {
+						if n := len(policies()["child-b"]); n != 0 {
+							return fmt.Errorf("expected the copy in child-b to be deleted, got %d policies", n)
+						}
+						return nil
+					},
+				),
+			},
+		},
+		CheckDestroy: func(_ *terraform.State) error {
+			if n := len(policies()["child-a"]); n != 0 {
+				return fmt.Errorf("expected the copy in child-a to be deleted, got %d policies", n)
+			}
+			return nil
+		},
+	})
+}
+
+func TestPolicyRolloutResource_sync(t *testing.T) {
+	m := newMockFalcon(t)
+	policies := mockSensorUpdatePolicies(m)
+
+	r := &policyRolloutResource{memberClients: membercid.NewClients(m.apiConfig())}
+
+	ctx := context.Background()
+	plan := policyRolloutResourceModel{
+		MemberCIDs: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("child-a"), types.StringValue("child-b")}),
+		SensorUpdatePolicy: policyRolloutSensorUpdatePolicy{
+			Name:                types.StringValue("mock"),
+			Description:         types.StringNull(),
+			PlatformName:        types.StringValue("Windows"),
+			Build:               types.StringValue("18110|n-1|tagged|1"),
+			BuildArm64:          types.StringNull(),
+			Enabled:             types.BoolValue(true),
+			UninstallProtection: types.BoolValue(false),
+		},
+	}
+
+	if diags := r.sync(ctx, &plan, map[string]policyRolloutCopy{}); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	copies, _ := policyRolloutCopies(ctx, plan.Policies)
+	for _, memberCID := range []string{"child-a", "child-b"} {
+		c, ok := copies[memberCID]
+		if !ok || c.Status.ValueString() != rolloutInSync {
+			t.Fatalf("expected an in sync copy in %s, got %+v", memberCID, c)
+		}
+
+		policy, ok := policies()[memberCID][c.PolicyID.ValueString()]
+		if !ok {
+			t.Fatalf("expected policy %s to be created in %s", c.PolicyID.ValueString(), memberCID)
+		}
+		if policy["enabled"] != true {
+			t.Errorf("expected the policy in %s to be enabled", memberCID)
+		}
+	}
+
+	// a copy deleted outside of Terraform is recreated, a removed member CID is deleted.
+	delete(policies()["child-a"], copies["child-a"].PolicyID.ValueString())
+	prior := map[string]policyRolloutCopy{
+		"child-a": {PolicyID: copies["child-a"].PolicyID, Status: types.StringValue(rolloutMissing)},
+		"child-b": copies["child-b"],
+	}
+	plan.MemberCIDs = types.SetValueMust(types.StringType, []attr.Value{types.StringValue("child-a")})
+
+	if diags := r.sync(ctx, &plan, prior); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	copies, _ = policyRolloutCopies(ctx, plan.Policies)
+	if len(copies) != 1 || copies["child-a"].Status.ValueString() != rolloutInSync {
+		t.Fatalf("expected only an in sync copy in child-a, got %+v", copies)
+	}
+	if len(policies()["child-a"]) != 1 {
+		t.Errorf("expected the copy in child-a to be recreated, got %d policies", len(policies()["child-a"]))
+	}
+	if len(policies()["child-b"]) != 0 {
+		t.Errorf("expected the copy in child-b to be deleted, got %d policies", len(policies()["child-b"]))
+	}
+}
+
+func TestRolloutPolicyStatus(t *testing.T) {
+	definition := policyRolloutSensorUpdatePolicy{
+		Name:                types.StringValue("mock"),
+		Description:         types.StringNull(),
+		PlatformName:        types.StringValue("Linux"),
+		Build:               types.StringValue("18110|n-1|tagged|1"),
+		BuildArm64:          types.StringValue("18110|n-1|tagged|2"),
+		Enabled:             types.BoolValue(true),
+		UninstallProtection: types.BoolValue(false),
+	}
+
+	policy := func(modify func(p *models.SensorUpdatePolicyV2)) *models.SensorUpdatePolicyV2 {
+		p := &models.SensorUpdatePolicyV2{
+			Name:        ptr("mock"),
+			Description: ptr(""),
+			Enabled:     ptr(true),
+			Settings: &models.SensorUpdateSettingsRespV2{
+				Build:               ptr("18110|n-1|tagged|1"),
+				UninstallProtection: ptr("DISABLED"),
+				Variants: []*models.SensorUpdateBuildRespV1{
+					{Platform: ptr(linuxArm64Varient), Build: ptr("18110|n-1|tagged|2")},
+					{Platform: ptr(zLinuxVarient), Build: ptr("")},
+				},
+			},
+		}
+		if modify != nil {
+			modify(p)
+		}
+		return p
+	}
+
+	tests := []struct {
+		name   string
+		policy *models.SensorUpdatePolicyV2
+		want   string
+	}{
+		{
+			name:   "in sync",
+			policy: policy(nil),
+			want:   rolloutInSync,
+		},
+		{
+			name:   "renamed",
+			policy: policy(func(p *models.SensorUpdatePolicyV2) { p.Name = ptr("renamed") }),
+			want:   rolloutDrifted,
+		},
+		{
+			name:   "disabled",
+			policy: policy(func(p *models.SensorUpdatePolicyV2) { p.Enabled = ptr(false) }),
+			want:   rolloutDrifted,
+		},
+		{
+			name:   "build changed",
+			policy: policy(func(p *models.SensorUpdatePolicyV2) { p.Settings.Build = ptr("18110|n|tagged|1") }),
+			want:   rolloutDrifted,
+		},
+		{
+			name:   "arm64 build changed",
+			policy: policy(func(p *models.SensorUpdatePolicyV2) { p.Settings.Variants[0].Build = ptr("") }),
+			want:   rolloutDrifted,
+		},
+		{
+			name: "uninstall protection enabled",
+			policy: policy(func(p *models.SensorUpdatePolicyV2) {
+				p.Settings.UninstallProtection = ptr("ENABLED")
+			}),
+			want: rolloutDrifted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rolloutPolicyStatus(definition, tt.policy); got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,184 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &fqlFunction{}
+
+// fqlFieldPattern matches the field names FQL accepts, e.g. platform_name or device.hostname.
+var fqlFieldPattern = regexp.MustCompile(`^[A-Za-z0-9_.]+$`)
+
+// NewFQLFunction is a helper function to simplify the provider implementation.
+func NewFQLFunction() function.Function {
+	return &fqlFunction{}
+}
+
+// fqlFunction is the fql function implementation.
+type fqlFunction struct{}
+
+// Metadata returns the function name.
+func (f *fqlFunction) Metadata(
+	_ context.Context,
+	_ function.MetadataRequest,
+	resp *function.MetadataResponse,
+) {
+	resp.Name = "fql"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *fqlFunction) Definition(
+	_ context.Context,
+	_ function.DefinitionRequest,
+	resp *function.DefinitionResponse,
+) {
+	resp.Definition = function.Definition{
+		Summary: "Build an FQL filter from a map of fields",
+		MarkdownDescription: "Builds a Falcon Query Language (FQL) filter from a map of field names to values. " +
+			"Fields are combined with AND. A field with a list of values matches any of the values (OR). " +
+			"Strings are quoted and escaped, numbers and booleans are used as is. " +
+			"Fields are sorted by name so the result is stable.",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:                "filters",
+				MarkdownDescription: "Map or object of FQL field names to a string, number, bool, or a list of those.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run builds the FQL filter.
+func (f *fqlFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var filters types.Dynamic
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &filters))
+	if resp.Error != nil {
+		return
+	}
+
+	filter, err := buildFQL(filters.UnderlyingValue())
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, filter))
+}
+
+// buildFQL joins each field of filters with AND.
+func buildFQL(filters attr.Value) (string, error) {
+	var fields map[string]attr.Value
+
+	switch v := filters.(type) {
+	case types.Object:
+		fields = v.Attributes()
+	case types.Map:
+		fields = v.Elements()
+	default:
+		return "", errors.New("filters must be a map or object of field names to values")
+	}
+
+	if len(fields) == 0 {
+		return "", errors.New("filters must contain at least one field")
+	}
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	expressions := make([]string, 0, len(names))
+	for _, name := range names {
+		if !fqlFieldPattern.MatchString(name) {
+			return "", fmt.Errorf(
+				"field %q is not a valid FQL field name, only letters, digits, underscores and dots are allowed",
+				name,
+			)
+		}
+
+		values, err := fqlValues(name, fields[name])
+		if err != nil {
+			return "", err
+		}
+
+		if len(values) == 1 {
+			expressions = append(expressions, name+":"+values[0])
+		} else {
+			expressions = append(expressions, name+":["+strings.Join(values, ",")+"]")
+		}
+	}
+
+	return strings.Join(expressions, "+"), nil
+}
+
+// fqlValues returns the FQL literals for the value of a field.
+// A list of values is flattened, nested lists are not supported.
+func fqlValues(name string, value attr.Value) ([]string, error) {
+	var elements []attr.Value
+
+	switch v := value.(type) {
+	case types.List:
+		elements = v.Elements()
+	case types.Set:
+		elements = v.Elements()
+	case types.Tuple:
+		elements = v.Elements()
+	case types.Dynamic:
+		return fqlValues(name, v.UnderlyingValue())
+	default:
+		literal, err := fqlLiteral(name, value)
+		if err != nil {
+			return nil, err
+		}
+		return []string{literal}, nil
+	}
+
+	if len(elements) == 0 {
+		return nil, fmt.Errorf("field %q has an empty list of values", name)
+	}
+
+	values := make([]string, 0, len(elements))
+	for _, element := range elements {
+		literal, err := fqlLiteral(name, element)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, literal)
+	}
+
+	return values, nil
+}
+
+// fqlLiteral returns the FQL literal for a single string, number, or bool value.
+func fqlLiteral(name string, value attr.Value) (string, error) {
+	if value == nil || value.IsNull() {
+		return "", fmt.Errorf("field %q has a null value", name)
+	}
+
+	switch v := value.(type) {
+	case types.String:
+		return utils.QuoteFQL(v.ValueString()), nil
+	case types.Number:
+		return v.ValueBigFloat().Text('f', -1), nil
+	case types.Bool:
+		return strconv.FormatBool(v.ValueBool()), nil
+	case types.Dynamic:
+		return fqlLiteral(name, v.UnderlyingValue())
+	default:
+		return "", fmt.Errorf("field %q must be a string, number, bool, or a list of those", name)
+	}
+}
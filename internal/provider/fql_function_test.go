@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestBuildFQL(t *testing.T) {
+	tests := []struct {
+		name     string
+		filters  attr.Value
+		expected string
+		wantErr  bool
+	}{
+		{
+			name: "single string",
+			filters: types.MapValueMust(types.StringType, map[string]attr.Value{
+				"platform_name": types.StringValue("Windows"),
+			}),
+			expected: "platform_name:'Windows'",
+		},
+		{
+			name: "fields are sorted and joined with and",
+			filters: types.ObjectValueMust(
+				map[string]attr.Type{
+					"name":          types.StringType,
+					"enabled":       types.BoolType,
+					"build_version": types.NumberType,
+				},
+				map[string]attr.Value{
+					"name":          types.StringValue("it's a policy"),
+					"enabled":       types.BoolValue(true),
+					"build_version": types.NumberValue(big.NewFloat(17104)),
+				},
+			),
+			expected: "build_version:17104+enabled:true+name:'it\\'s a policy'",
+		},
+		{
+			name: "list values are or'd",
+			filters: types.ObjectValueMust(
+				map[string]attr.Type{
+					"platform_name": types.TupleType{ElemTypes: []attr.Type{types.StringType, types.StringType}},
+				},
+				map[string]attr.Value{
+					"platform_name": types.TupleValueMust(
+						[]attr.Type{types.StringType, types.StringType},
+						[]attr.Value{types.StringValue("Windows"), types.StringValue("Mac")},
+					),
+				},
+			),
+			expected: "platform_name:['Windows','Mac']",
+		},
+		{
+			name:    "empty",
+			filters: types.MapValueMust(types.StringType, map[string]attr.Value{}),
+			wantErr: true,
+		},
+		{
+			name: "invalid field name",
+			filters: types.MapValueMust(types.StringType, map[string]attr.Value{
+				"platform name": types.StringValue("Windows"),
+			}),
+			wantErr: true,
+		},
+		{
+			name: "null value",
+			filters: types.MapValueMust(types.StringType, map[string]attr.Value{
+				"name": types.StringNull(),
+			}),
+			wantErr: true,
+		},
+		{
+			name: "empty list",
+			filters: types.MapValueMust(types.ListType{ElemType: types.StringType}, map[string]attr.Value{
+				"name": types.ListValueMust(types.StringType, []attr.Value{}),
+			}),
+			wantErr: true,
+		},
+		{
+			name:    "not a map",
+			filters: types.StringValue("name:'example'"),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			filter, err := buildFQL(tc.filters)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got filter %q", filter)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if filter != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, filter)
+			}
+		})
+	}
+}
@@ -3,7 +3,7 @@ package provider
 import (
 	"context"
 	"fmt"
-	"strings"
+	"net/http"
 	"time"
 
 	"github.com/crowdstrike/gofalcon/falcon/client"
@@ -14,16 +14,23 @@ import (
 	"github.com/crowdstrike/gofalcon/falcon/client/response_policies"
 	"github.com/crowdstrike/gofalcon/falcon/client/sensor_update_policies"
 	"github.com/crowdstrike/gofalcon/falcon/models"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/identity"
+	membercid "github.com/crowdstrike/terraform-provider-crowdstrike/internal/member_cid"
 	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/scopes"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/tferrors"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -31,6 +38,8 @@ var (
 	_ resource.Resource                = &hostGroupResource{}
 	_ resource.ResourceWithConfigure   = &hostGroupResource{}
 	_ resource.ResourceWithImportState = &hostGroupResource{}
+	_ resource.ResourceWithIdentity    = &hostGroupResource{}
+	_ membercid.Resource               = &hostGroupResource{}
 )
 
 // NewHostGroupResource is a helper function to simplify the provider implementation.
@@ -40,17 +49,22 @@ func NewHostGroupResource() resource.Resource {
 
 // hostGroupResource is the resource implementation.
 type hostGroupResource struct {
-	client *client.CrowdStrikeAPISpecification
+	client        *client.CrowdStrikeAPISpecification
+	memberClients *membercid.Clients
 }
 
 // hostGroupResourceModel maps the resource schema data.
 type hostGroupResourceModel struct {
-	ID             types.String `tfsdk:"id"`
-	Name           types.String `tfsdk:"name"`
-	AssignmentRule types.String `tfsdk:"assignment_rule"`
-	Description    types.String `tfsdk:"description"`
-	GroupType      types.String `tfsdk:"type"`
-	LastUpdated    types.String `tfsdk:"last_updated"`
+	ID                  types.String   `tfsdk:"id"`
+	Name                types.String   `tfsdk:"name"`
+	AssignmentRule      types.String   `tfsdk:"assignment_rule"`
+	Description         types.String   `tfsdk:"description"`
+	GroupType           types.String   `tfsdk:"type"`
+	LastUpdated         types.String   `tfsdk:"last_updated"`
+	DeletionProtection  types.Bool     `tfsdk:"deletion_protection"`
+	ForceDetachPolicies types.Bool     `tfsdk:"force_detach_policies"`
+	MemberCID           types.String   `tfsdk:"member_cid"`
+	Timeouts            timeouts.Value `tfsdk:"timeouts"`
 }
 
 // Configure adds the provider configured client to the resource.
@@ -80,6 +94,21 @@ func (r *hostGroupResource) Configure(
 	r.client = client
 }
 
+// SetMemberClients adds the clients used when member_cid is set.
+func (r *hostGroupResource) SetMemberClients(m *membercid.Clients) {
+	r.memberClients = m
+}
+
+// withMemberCID returns a copy of the resource that uses the client of memberCID.
+func (r *hostGroupResource) withMemberCID(memberCID types.String) (*hostGroupResource, diag.Diagnostics) {
+	c, diags := membercid.ClientFor(r.client, r.memberClients, memberCID)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	return &hostGroupResource{client: c, memberClients: r.memberClients}, diags
+}
+
 // Metadata returns the resource type name.
 func (r *hostGroupResource) Metadata(
 	_ context.Context,
@@ -124,7 +153,7 @@ var apiScopes = []scopes.Scope{
 
 // Schema defines the schema for the resource.
 func (r *hostGroupResource) Schema(
-	_ context.Context,
+	ctx context.Context,
 	_ resource.SchemaRequest,
 	resp *resource.SchemaResponse,
 ) {
@@ -147,6 +176,13 @@ func (r *hostGroupResource) Schema(
 				Computed:    true,
 				Description: "Timestamp of the last Terraform update of the resource.",
 			},
+			"deletion_protection": utils.DeletionProtectionAttribute("host group"),
+			"force_detach_policies": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				Description: "Remove the host group from every sensor update, device control, firewall, prevention, and response policy before it is deleted. When false, deleting a host group that is still assigned to a policy fails. Defaults to true.",
+			},
 			"name": schema.StringAttribute{
 				Required:    true,
 				Description: "Name of the host group.",
@@ -169,6 +205,15 @@ func (r *hostGroupResource) Schema(
 				Optional:    true,
 				Description: "Description of the host group.",
 			},
+			"member_cid": membercid.Attribute("host group"),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 }
@@ -187,6 +232,21 @@ func (r *hostGroupResource) Create(
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r, diags = r.withMemberCID(plan.MemberCID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	hostGroupParams := host_group.CreateHostGroupsParams{
 		Context: ctx,
 		Body: &models.HostGroupsCreateGroupsReqV1{
@@ -211,13 +271,18 @@ func (r *hostGroupResource) Create(
 
 	hostGroupParams.Body.Resources[0].AssignmentRule = plan.AssignmentRule.ValueString()
 
-	hostGroup, err := r.client.HostGroup.CreateHostGroups(&hostGroupParams)
+	var hostGroup *host_group.CreateHostGroupsCreated
+	err := utils.RetryOnConflict(ctx, func() error {
+		var err error
+		hostGroup, err = r.client.HostGroup.CreateHostGroups(&hostGroupParams)
+		return err
+	})
 
 	// todo: if we should handle scope and timeout errors instead of giving a vague error
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating host group",
-			"Could not create host group, unexpected error: "+err.Error(),
+			"Could not create host group, unexpected error: "+tferrors.Message(err, apiScopes...),
 		)
 		return
 	}
@@ -236,6 +301,8 @@ func (r *hostGroupResource) Create(
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, plan.ID)...)
 }
 
 // Read refreshes the Terraform state with the latest data.
@@ -251,6 +318,21 @@ func (r *hostGroupResource) Read(
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r, diags = r.withMemberCID(state.MemberCID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	hostGroup, err := r.client.HostGroup.GetHostGroups(
 		&host_group.GetHostGroupsParams{
 			Context: ctx,
@@ -258,10 +340,20 @@ func (r *hostGroupResource) Read(
 		},
 	)
 
+	if tferrors.IsNotFound(err) || (err == nil && len(hostGroup.Payload.Resources) == 0) {
+		tflog.Warn(
+			ctx,
+			"Host group not found, removing from state",
+			map[string]interface{}{"id": state.ID.ValueString()},
+		)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading CrowdStrike host group",
-			"Could not read CrowdStrike host group: "+state.ID.ValueString()+": "+err.Error(),
+			"Could not read CrowdStrike host group: "+state.ID.ValueString()+": "+tferrors.Message(err, apiScopes...),
 		)
 		return
 	}
@@ -274,12 +366,24 @@ func (r *hostGroupResource) Read(
 	state.AssignmentRule = types.StringValue(hostGroupResource.AssignmentRule)
 	state.GroupType = types.StringValue(hostGroupResource.GroupType)
 
+	// deletion_protection is not stored in Falcon, imported resources use the default.
+	if state.DeletionProtection.IsNull() {
+		state.DeletionProtection = types.BoolValue(false)
+	}
+
+	// force_detach_policies is not stored in Falcon, imported resources use the default.
+	if state.ForceDetachPolicies.IsNull() {
+		state.ForceDetachPolicies = types.BoolValue(true)
+	}
+
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, state.ID)...)
 }
 
 // Update updates the resource and sets the updated Terraform state on success.
@@ -296,6 +400,21 @@ func (r *hostGroupResource) Update(
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r, diags = r.withMemberCID(plan.MemberCID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	hostGroupParams := host_group.UpdateHostGroupsParams{
 		Context: ctx,
 		Body: &models.HostGroupsUpdateGroupsReqV1{
@@ -325,7 +444,7 @@ func (r *hostGroupResource) Update(
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating CrowdStrike host group",
-			"Could not update host group with ID: "+plan.ID.ValueString()+": "+err.Error(),
+			"Could not update host group with ID: "+plan.ID.ValueString()+": "+tferrors.Message(err, apiScopes...),
 		)
 		return
 	}
@@ -344,6 +463,8 @@ func (r *hostGroupResource) Update(
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, plan.ID)...)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -359,35 +480,37 @@ func (r *hostGroupResource) Delete(
 		return
 	}
 
-	// all assinged policies must be removed before we are able to delete the host group
-	resp.Diagnostics.Append(r.purgeSensorUpdatePolicies(ctx, state.ID.ValueString())...)
-	if resp.Diagnostics.HasError() {
+	if state.DeletionProtection.ValueBool() {
+		resp.Diagnostics.Append(utils.DeletionProtectionError("host group", state.ID.ValueString()))
 		return
 	}
 
-	resp.Diagnostics.Append(r.purgeUSBDeviceControlPolicies(ctx, state.ID.ValueString())...)
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	resp.Diagnostics.Append(r.purgeFirewallPolicies(ctx, state.ID.ValueString())...)
+	r, diags = r.withMemberCID(state.MemberCID)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	resp.Diagnostics.Append(r.purgePreventionPolicies(ctx, state.ID.ValueString())...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
 
-	resp.Diagnostics.Append(r.purgeResponsePolicies(ctx, state.ID.ValueString())...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
+	// all assinged policies must be removed before we are able to delete the host group
+	if state.ForceDetachPolicies.ValueBool() {
+		resp.Diagnostics.Append(r.detachPolicies(ctx, state.ID.ValueString())...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 
-	// removal of assigned policies return before the host group is ready to be deleted
-	// adding a simple sleep.
-	time.Sleep(10 * time.Second)
+		// removal of assigned policies return before the host group is ready to be deleted
+		// adding a simple sleep.
+		time.Sleep(10 * time.Second)
+	}
 
 	_, err := r.client.HostGroup.DeleteHostGroups(
 		&host_group.DeleteHostGroupsParams{
@@ -397,29 +520,125 @@ func (r *hostGroupResource) Delete(
 	)
 
 	if err != nil {
-		if strings.Contains(err.Error(), "409") {
+		if tferrors.StatusCode(err) == http.StatusConflict {
+			detail := "Please remove all assigned policies (firewall policies, prevention policies, etc) and try again. "
+			if !state.ForceDetachPolicies.ValueBool() {
+				detail = "The host group is still assigned to policies. Remove it from the policies or set force_detach_policies to true and try again. "
+			}
 			resp.Diagnostics.AddError(
 				"Error deleting CrowdStrike host group",
-				"Please remove all assigned policies (firewall policies, prevention policies, etc) and try again. "+err.Error(),
+				detail+tferrors.Message(err, apiScopes...),
 			)
 		} else {
 			resp.Diagnostics.AddError(
 				"Error deleting CrowdStrike host group",
-				"Could not delete host group, unexpected error: "+err.Error(),
+				"Could not delete host group, unexpected error: "+tferrors.Message(err, apiScopes...),
 			)
 		}
 		return
 	}
 }
 
+// IdentitySchema defines the identity schema for the resource.
+func (r *hostGroupResource) IdentitySchema(
+	_ context.Context,
+	_ resource.IdentitySchemaRequest,
+	resp *resource.IdentitySchemaResponse,
+) {
+	resp.IdentitySchema = identity.Schema()
+}
+
 // ImportState implements the logic to support resource imports.
 func (r *hostGroupResource) ImportState(
 	ctx context.Context,
 	req resource.ImportStateRequest,
 	resp *resource.ImportStateResponse,
 ) {
-	// Retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	// host groups of a child CID are imported as <member_cid>/<id> or <member_cid>/name:<name>.
+	if memberCID, id, ok := membercid.ImportID(req.ID); ok {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("member_cid"), memberCID)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		scoped, diags := r.withMemberCID(types.StringValue(memberCID))
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		r = scoped
+		req.ID = id
+	}
+
+	if name, ok := utils.NameFromImportID(req.ID); ok {
+		id, diags := r.hostGroupIDByName(ctx, name)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+		return
+	}
+
+	// Retrieve import ID or identity and save to id attribute
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
+}
+
+// hostGroupIDByName looks up the id of the host group with the given name.
+func (r *hostGroupResource) hostGroupIDByName(
+	ctx context.Context,
+	name string,
+) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	filter := "name:" + utils.QuoteFQL(name)
+	res, err := r.client.HostGroup.QueryHostGroups(
+		&host_group.QueryHostGroupsParams{
+			Context: ctx,
+			Filter:  &filter,
+		},
+	)
+
+	if err != nil {
+		diags.AddError(
+			"Error importing CrowdStrike host group",
+			"Could not look up host group by name: "+tferrors.Message(err, apiScopes...),
+		)
+		return "", diags
+	}
+
+	return utils.IDFromNameLookup("host group", name, res.Payload.Resources)
+}
+
+// detachPolicies removes the host group from every policy it is assigned to.
+func (r *hostGroupResource) detachPolicies(ctx context.Context, hostGroupID string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	diags.Append(r.purgeSensorUpdatePolicies(ctx, hostGroupID)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	diags.Append(r.purgeUSBDeviceControlPolicies(ctx, hostGroupID)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	diags.Append(r.purgeFirewallPolicies(ctx, hostGroupID)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	diags.Append(r.purgePreventionPolicies(ctx, hostGroupID)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	diags.Append(r.purgeResponsePolicies(ctx, hostGroupID)...)
+
+	return diags
 }
 
 // purgeSensorUpdatePolicies removes all sensor update policies from a host group.
@@ -440,7 +659,7 @@ func (r *hostGroupResource) purgeSensorUpdatePolicies(
 	if err != nil {
 		diags.AddError(
 			"Error deleting CrowdStrike host group",
-			"Unable to read assigned sensor update policies "+err.Error(),
+			"Unable to read assigned sensor update policies "+tferrors.Message(err, apiScopes...),
 		)
 		return diags
 	}
@@ -471,7 +690,7 @@ func (r *hostGroupResource) purgeSensorUpdatePolicies(
 	if err != nil {
 		diags.AddError(
 			"Error deleting CrowdStrike host group",
-			"Unable to remove assigned sensor update policies "+err.Error(),
+			"Unable to remove assigned sensor update policies "+tferrors.Message(err, apiScopes...),
 		)
 		return diags
 	}
@@ -497,7 +716,7 @@ func (r *hostGroupResource) purgeUSBDeviceControlPolicies(
 	if err != nil {
 		diags.AddError(
 			"Error deleting CrowdStrike host group",
-			"Unable to read assigned usb device control policies "+err.Error(),
+			"Unable to read assigned usb device control policies "+tferrors.Message(err, apiScopes...),
 		)
 		return diags
 	}
@@ -528,7 +747,7 @@ func (r *hostGroupResource) purgeUSBDeviceControlPolicies(
 	if err != nil {
 		diags.AddError(
 			"Error deleting CrowdStrike host group",
-			"Unable to remove assigned usb device control policies "+err.Error(),
+			"Unable to remove assigned usb device control policies "+tferrors.Message(err, apiScopes...),
 		)
 		return diags
 	}
@@ -554,7 +773,7 @@ func (r *hostGroupResource) purgePreventionPolicies(
 	if err != nil {
 		diags.AddError(
 			"Error deleting CrowdStrike host group",
-			"Unable to read assigned prevention policies "+err.Error(),
+			"Unable to read assigned prevention policies "+tferrors.Message(err, apiScopes...),
 		)
 		return diags
 	}
@@ -585,7 +804,7 @@ func (r *hostGroupResource) purgePreventionPolicies(
 	if err != nil {
 		diags.AddError(
 			"Error deleting CrowdStrike host group",
-			"Unable to remove assigned prevention policies "+err.Error(),
+			"Unable to remove assigned prevention policies "+tferrors.Message(err, apiScopes...),
 		)
 		return diags
 	}
@@ -611,7 +830,7 @@ func (r *hostGroupResource) purgeFirewallPolicies(
 	if err != nil {
 		diags.AddError(
 			"Error deleting CrowdStrike host group",
-			"Unable to read assigned firewall prevention policies "+err.Error(),
+			"Unable to read assigned firewall prevention policies "+tferrors.Message(err, apiScopes...),
 		)
 		return diags
 	}
@@ -642,7 +861,7 @@ func (r *hostGroupResource) purgeFirewallPolicies(
 	if err != nil {
 		diags.AddError(
 			"Error deleting CrowdStrike host group",
-			"Unable to remove assigned firewall prevention policies "+err.Error(),
+			"Unable to remove assigned firewall prevention policies "+tferrors.Message(err, apiScopes...),
 		)
 		return diags
 	}
@@ -668,7 +887,7 @@ func (r *hostGroupResource) purgeResponsePolicies(
 	if err != nil {
 		diags.AddError(
 			"Error deleting CrowdStrike host group",
-			"Unable to read assigned response policies "+err.Error(),
+			"Unable to read assigned response policies "+tferrors.Message(err, apiScopes...),
 		)
 		return diags
 	}
@@ -699,7 +918,7 @@ func (r *hostGroupResource) purgeResponsePolicies(
 	if err != nil {
 		diags.AddError(
 			"Error deleting CrowdStrike host group",
-			"Unable to remove assigned response policies "+err.Error(),
+			"Unable to remove assigned response policies "+tferrors.Message(err, apiScopes...),
 		)
 		return diags
 	}
@@ -1,11 +1,22 @@
 package provider
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
 
 func TestAccHostGroupResource(t *testing.T) {
@@ -62,6 +73,14 @@ resource "crowdstrike_host_group" "test" {
 				ImportStateVerify:       true,
 				ImportStateVerifyIgnore: []string{"last_updated"},
 			},
+			// ImportState by name testing
+			{
+				ResourceName:            "crowdstrike_host_group.test",
+				ImportState:             true,
+				ImportStateId:           "name:" + rName,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
 			// Update and Read testing
 			{
 				Config: providerConfig + fmt.Sprintf(`
@@ -190,3 +209,349 @@ resource "crowdstrike_host_group" "test" {
 		},
 	})
 }
+
+func TestAccHostGroupResource_identity(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acceptance-test")
+	resource.ParallelTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "crowdstrike_host_group" "test" {
+  name        = "%s"
+  description = "made with terraform"
+  type        = "dynamic"
+}
+`, rName),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentityValueMatchesState(
+						"crowdstrike_host_group.test",
+						tfjsonpath.New("id"),
+					),
+				},
+			},
+			{
+				ResourceName:    "crowdstrike_host_group.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+		},
+	})
+}
+
+// mockHostGroups registers in-memory host group endpoints on the mock API.
+func mockHostGroups(m *mockFalcon) {
+	var mu sync.Mutex
+	groups := map[string]map[string]any{}
+
+	m.handle("POST /devices/entities/host-groups/v1", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Resources []map[string]any `json:"resources"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeAPIError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		group := body.Resources[0]
+		group["id"] = fmt.Sprintf("mock-host-group-%d", len(groups)+1)
+		groups[group["id"].(string)] = group
+
+		writeJSON(w, http.StatusCreated, map[string]any{"resources": []any{group}})
+	})
+
+	m.handle("GET /devices/entities/host-groups/v1", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		resources := []any{}
+		for _, id := range r.URL.Query()["ids"] {
+			if group, ok := groups[id]; ok {
+				resources = append(resources, group)
+			}
+		}
+
+		if len(resources) == 0 {
+			writeAPIError(w, http.StatusNotFound, "host group not found")
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]any{"resources": resources})
+	})
+
+	m.handle("PATCH /devices/entities/host-groups/v1", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Resources []map[string]any `json:"resources"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeAPIError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		update := body.Resources[0]
+		group, ok := groups[update["id"].(string)]
+		if !ok {
+			writeAPIError(w, http.StatusNotFound, "host group not found")
+			return
+		}
+
+		for k, v := range update {
+			group[k] = v
+		}
+
+		writeJSON(w, http.StatusOK, map[string]any{"resources": []any{group}})
+	})
+
+	m.handle("DELETE /devices/entities/host-groups/v1", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		for _, id := range r.URL.Query()["ids"] {
+			delete(groups, id)
+		}
+
+		writeJSON(w, http.StatusOK, map[string]any{"resources": []any{}})
+	})
+
+	for _, policy := range []string{"sensor-update", "device-control", "prevention", "firewall", "response"} {
+		m.handle("GET /policy/queries/"+policy+"/v1", func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, http.StatusOK, map[string]any{"resources": []any{}})
+		})
+	}
+}
+
+func TestHostGroupResource_mock(t *testing.T) {
+	m := newMockFalcon(t)
+	mockHostGroups(m)
+
+	mockUnitTest(t, m, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: m.providerConfig() + `
+resource "crowdstrike_host_group" "test" {
+  name            = "mock"
+  description     = "made with terraform"
+  type            = "dynamic"
+  assignment_rule = "platform_name:'Linux'"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("crowdstrike_host_group.test", "id", "mock-host-group-1"),
+					resource.TestCheckResourceAttr("crowdstrike_host_group.test", "assignment_rule", "platform_name:'Linux'"),
+				),
+			},
+			{
+				Config: m.providerConfig() + `
+resource "crowdstrike_host_group" "test" {
+  name            = "mock-updated"
+  description     = "updated with terraform"
+  type            = "dynamic"
+  assignment_rule = "platform_name:'Windows'"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("crowdstrike_host_group.test", "name", "mock-updated"),
+					resource.TestCheckResourceAttr("crowdstrike_host_group.test", "description", "updated with terraform"),
+					resource.TestCheckResourceAttr("crowdstrike_host_group.test", "assignment_rule", "platform_name:'Windows'"),
+				),
+			},
+		},
+		CheckDestroy: func(_ *terraform.State) error {
+			if m.calls("DELETE /devices/entities/host-groups/v1") != 1 {
+				return fmt.Errorf("expected the host group to be deleted once")
+			}
+			return nil
+		},
+	})
+}
+
+func TestHostGroupResource_memberCID_mock(t *testing.T) {
+	m := newMockFalcon(t)
+	mockHostGroups(m)
+
+	mockUnitTest(t, m, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: m.providerConfig() + `
+resource "crowdstrike_host_group" "test" {
+  name                  = "mock"
+  type                  = "static"
+  member_cid            = "child-cid"
+  force_detach_policies = false
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("crowdstrike_host_group.test", "member_cid", "child-cid"),
+					func(_ *terraform.State) error {
+						if !slices.Contains(m.tokenMemberCIDs(), "child-cid") {
+							return fmt.Errorf("expected a token for member CID child-cid, got %v", m.tokenMemberCIDs())
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:            "crowdstrike_host_group.test",
+				ImportState:             true,
+				ImportStateId:           "child-cid/mock-host-group-1",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated", "force_detach_policies"},
+			},
+		},
+	})
+}
+
+func TestHostGroupResource_deletionProtection(t *testing.T) {
+	m := newMockFalcon(t)
+	mockHostGroups(m)
+
+	config := func(deletionProtection bool) string {
+		return m.providerConfig() + fmt.Sprintf(`
+resource "crowdstrike_host_group" "test" {
+  name                = "mock"
+  description         = "made with terraform"
+  type                = "dynamic"
+  assignment_rule     = "platform_name:'Linux'"
+  deletion_protection = %t
+}
+`, deletionProtection)
+	}
+
+	mockUnitTest(t, m, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: config(true),
+				Check:  resource.TestCheckResourceAttr("crowdstrike_host_group.test", "deletion_protection", "true"),
+			},
+			{
+				Config:      config(true),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile("deletion_protection enabled"),
+			},
+			{
+				Config: config(false),
+				Check:  resource.TestCheckResourceAttr("crowdstrike_host_group.test", "deletion_protection", "false"),
+			},
+		},
+		CheckDestroy: func(_ *terraform.State) error {
+			if m.calls("DELETE /devices/entities/host-groups/v1") != 1 {
+				return fmt.Errorf("expected the host group to be deleted once")
+			}
+			return nil
+		},
+	})
+}
+
+func TestHostGroupResource_forceDetachPoliciesDisabled(t *testing.T) {
+	m := newMockFalcon(t)
+	mockHostGroups(m)
+
+	mockUnitTest(t, m, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: m.providerConfig() + `
+resource "crowdstrike_host_group" "test" {
+  name                  = "mock"
+  description           = "made with terraform"
+  type                  = "dynamic"
+  assignment_rule       = "platform_name:'Linux'"
+  force_detach_policies = false
+}
+`,
+				Check: resource.TestCheckResourceAttr("crowdstrike_host_group.test", "force_detach_policies", "false"),
+			},
+		},
+		CheckDestroy: func(_ *terraform.State) error {
+			if n := m.calls("GET /policy/queries/sensor-update/v1"); n != 0 {
+				return fmt.Errorf("expected assigned policies not to be looked up, got %d queries", n)
+			}
+			if m.calls("DELETE /devices/entities/host-groups/v1") != 1 {
+				return fmt.Errorf("expected the host group to be deleted once")
+			}
+			return nil
+		},
+	})
+}
+
+func TestHostGroupIDByName(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		ids       []string
+		wantID    string
+		wantError string
+	}{
+		{
+			name:   "found",
+			status: http.StatusOK,
+			ids:    []string{"abc123"},
+			wantID: "abc123",
+		},
+		{
+			name:      "not found",
+			status:    http.StatusOK,
+			ids:       []string{},
+			wantError: `No host group named "mock" was found.`,
+		},
+		{
+			name:      "ambiguous",
+			status:    http.StatusOK,
+			ids:       []string{"abc123", "def456"},
+			wantError: "More than one host group is named",
+		},
+		{
+			name:      "forbidden",
+			status:    http.StatusForbidden,
+			wantError: "Host groups",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newMockFalcon(t)
+			m.handle("GET /devices/queries/host-groups/v1", func(w http.ResponseWriter, r *http.Request) {
+				if got := r.URL.Query().Get("filter"); got != "name:'mock'" {
+					writeAPIError(w, http.StatusBadRequest, "unexpected filter "+got)
+					return
+				}
+
+				if tt.status != http.StatusOK {
+					writeAPIError(w, tt.status, "access denied, authorization failed")
+					return
+				}
+
+				writeJSON(w, http.StatusOK, map[string]any{"resources": tt.ids})
+			})
+
+			r := &hostGroupResource{client: m.client(t)}
+			id, diags := r.hostGroupIDByName(context.Background(), "mock")
+
+			if tt.wantError == "" {
+				if diags.HasError() {
+					t.Fatalf("unexpected error: %v", diags)
+				}
+				if id != tt.wantID {
+					t.Fatalf("got id %q, want %q", id, tt.wantID)
+				}
+				return
+			}
+
+			if !diags.HasError() {
+				t.Fatalf("expected an error, got id %q", id)
+			}
+			if detail := diags.Errors()[0].Detail(); !strings.Contains(detail, tt.wantError) {
+				t.Fatalf("expected error to contain %q, got: %s", tt.wantError, detail)
+			}
+		})
+	}
+}
@@ -0,0 +1,516 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/client/hosts"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/scopes"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/tferrors"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &hostNetworkContainmentResource{}
+	_ resource.ResourceWithConfigure = &hostNetworkContainmentResource{}
+)
+
+var hostNetworkContainmentScopes = []scopes.Scope{
+	{
+		Name:  "Hosts",
+		Read:  true,
+		Write: true,
+	},
+}
+
+const (
+	containmentStateContained = "contained"
+	containmentStateNormal    = "normal"
+
+	// containmentBatchSize is the maximum number of hosts sent in a single api call.
+	containmentBatchSize = 100
+)
+
+// containmentStatuses maps a desired state to the host statuses that satisfy it.
+var containmentStatuses = map[string][]string{
+	containmentStateContained: {"contained", "containment_pending"},
+	containmentStateNormal:    {"normal", "lift_containment_pending"},
+}
+
+// containmentActions maps a desired state to the host action that reaches it.
+var containmentActions = map[string]string{
+	containmentStateContained: "contain",
+	containmentStateNormal:    "lift_containment",
+}
+
+// NewHostNetworkContainmentResource is a helper function to simplify the provider implementation.
+func NewHostNetworkContainmentResource() resource.Resource {
+	return &hostNetworkContainmentResource{}
+}
+
+// hostNetworkContainmentResource is the resource implementation.
+type hostNetworkContainmentResource struct {
+	client *client.CrowdStrikeAPISpecification
+}
+
+// hostNetworkContainmentResourceModel is the resource model.
+type hostNetworkContainmentResourceModel struct {
+	ID           types.String   `tfsdk:"id"`
+	DeviceIDs    types.Set      `tfsdk:"device_ids"`
+	DesiredState types.String   `tfsdk:"desired_state"`
+	Statuses     types.Map      `tfsdk:"statuses"`
+	LastUpdated  types.String   `tfsdk:"last_updated"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *hostNetworkContainmentResource) Configure(
+	ctx context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.CrowdStrikeAPISpecification)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf(
+				"Expected *client.CrowdStrikeAPISpecification, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Metadata returns the resource type name.
+func (r *hostNetworkContainmentResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_host_network_containment"
+}
+
+// Schema defines the schema for the resource.
+func (r *hostNetworkContainmentResource) Schema(
+	ctx context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: fmt.Sprintf(
+			"Host --- This resource contains (network isolates) hosts or lifts containment for hosts. Hosts that drift from desired_state are reconciled on the next apply. Hosts removed from device_ids, and all hosts when the resource is destroyed, have containment lifted if desired_state is contained.\n\n%s",
+			scopes.GenerateScopeDescription(hostNetworkContainmentScopes),
+		),
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Identifier for the containment.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"device_ids": schema.SetAttribute{
+				Required:    true,
+				ElementType: types.StringType,
+				Description: "Host agent ids (AID) to manage containment for.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"desired_state": schema.StringAttribute{
+				Required:    true,
+				Description: "The desired network containment state of the hosts. (contained, normal)",
+				Validators: []validator.String{
+					stringvalidator.OneOf(containmentStateContained, containmentStateNormal),
+				},
+			},
+			"statuses": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "Map of device id to the containment status reported by the api.",
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:    true,
+				Description: "Timestamp of the last Terraform update of the resource.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *hostNetworkContainmentResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan hostNetworkContainmentResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	id, err := utils.NewRandomID()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating host network containment",
+			"Could not generate resource id: "+err.Error(),
+		)
+		return
+	}
+	plan.ID = types.StringValue(id)
+
+	resp.Diagnostics.Append(r.reconcile(ctx, &plan, nil)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *hostNetworkContainmentResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state hostNetworkContainmentResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	var deviceIDs []string
+	resp.Diagnostics.Append(state.DeviceIDs.ElementsAs(ctx, &deviceIDs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	statuses, diags := r.getStatuses(ctx, deviceIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(statuses) == 0 {
+		tflog.Warn(
+			ctx,
+			"hosts not found, removing host network containment from state",
+			map[string]interface{}{"id": state.ID.ValueString()},
+		)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// hosts that drifted from the desired state are dropped so the next plan reconciles them.
+	inDesiredState := []string{}
+	for _, id := range deviceIDs {
+		if status, ok := statuses[id]; ok && hasContainmentStatus(state.DesiredState.ValueString(), status) {
+			inDesiredState = append(inDesiredState, id)
+		}
+	}
+
+	state.DeviceIDs, diags = types.SetValueFrom(ctx, types.StringType, inDesiredState)
+	resp.Diagnostics.Append(diags...)
+
+	state.Statuses, diags = types.MapValueFrom(ctx, types.StringType, statuses)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *hostNetworkContainmentResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var plan hostNetworkContainmentResourceModel
+	var state hostNetworkContainmentResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(r.reconcile(ctx, &plan, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *hostNetworkContainmentResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var state hostNetworkContainmentResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	if state.DesiredState.ValueString() != containmentStateContained {
+		return
+	}
+
+	var deviceIDs []string
+	resp.Diagnostics.Append(state.DeviceIDs.ElementsAs(ctx, &deviceIDs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.setState(ctx, containmentStateNormal, deviceIDs)...)
+}
+
+// reconcile moves the planned hosts to the desired state and lifts containment for hosts
+// removed from a contained resource. The planned statuses and last updated are set on plan.
+func (r *hostNetworkContainmentResource) reconcile(
+	ctx context.Context,
+	plan *hostNetworkContainmentResourceModel,
+	state *hostNetworkContainmentResourceModel,
+) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var planIDs []string
+	diags.Append(plan.DeviceIDs.ElementsAs(ctx, &planIDs, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	if state != nil && state.DesiredState.ValueString() == containmentStateContained {
+		_, removed, setDiags := utils.SetIDsToModify(ctx, plan.DeviceIDs, state.DeviceIDs)
+		diags.Append(setDiags...)
+		if diags.HasError() {
+			return diags
+		}
+
+		diags.Append(r.setState(ctx, containmentStateNormal, removed)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	diags.Append(r.setState(ctx, plan.DesiredState.ValueString(), planIDs)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	statuses, getDiags := r.getStatuses(ctx, planIDs)
+	diags.Append(getDiags...)
+	if diags.HasError() {
+		return diags
+	}
+
+	var mapDiags diag.Diagnostics
+	plan.Statuses, mapDiags = types.MapValueFrom(ctx, types.StringType, statuses)
+	diags.Append(mapDiags...)
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	return diags
+}
+
+// setState performs the containment action for the hosts not already in the desired state.
+func (r *hostNetworkContainmentResource) setState(
+	ctx context.Context,
+	desiredState string,
+	deviceIDs []string,
+) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if len(deviceIDs) == 0 {
+		return diags
+	}
+
+	statuses, diags := r.getStatuses(ctx, deviceIDs)
+	if diags.HasError() {
+		return diags
+	}
+
+	var toChange []string
+	for _, id := range deviceIDs {
+		status, ok := statuses[id]
+		if !ok {
+			diags.AddError(
+				"Error updating host network containment",
+				fmt.Sprintf("Host %s was not found.", id),
+			)
+			continue
+		}
+
+		if !hasContainmentStatus(desiredState, status) {
+			toChange = append(toChange, id)
+		}
+	}
+
+	if diags.HasError() {
+		return diags
+	}
+
+	action := containmentActions[desiredState]
+	for i := 0; i < len(toChange); i += containmentBatchSize {
+		batch := toChange[i:min(i+containmentBatchSize, len(toChange))]
+
+		res, err := r.client.Hosts.PerformActionV2(&hosts.PerformActionV2Params{
+			Context:    ctx,
+			ActionName: action,
+			Body: &models.MsaEntityActionRequestV2{
+				Ids: batch,
+			},
+		})
+
+		if err != nil {
+			diags.AddError(
+				"Error updating host network containment",
+				fmt.Sprintf(
+					"Could not %s hosts (%s): %s",
+					action,
+					strings.Join(batch, ", "),
+					tferrors.Message(err, hostNetworkContainmentScopes...),
+				),
+			)
+			return diags
+		}
+
+		if res != nil && res.Payload != nil {
+			for _, apiErr := range res.Payload.Errors {
+				diags.AddError(
+					"Error updating host network containment",
+					fmt.Sprintf("Could not %s host %s: %s", action, apiErr.ID, apiErr.String()),
+				)
+			}
+		}
+	}
+
+	return diags
+}
+
+// getStatuses returns the containment status of each host, hosts that do not exist are left out.
+func (r *hostNetworkContainmentResource) getStatuses(
+	ctx context.Context,
+	deviceIDs []string,
+) (map[string]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	statuses := make(map[string]string, len(deviceIDs))
+
+	ids := append([]string(nil), deviceIDs...)
+	sort.Strings(ids)
+
+	for i := 0; i < len(ids); i += containmentBatchSize {
+		batch := ids[i:min(i+containmentBatchSize, len(ids))]
+
+		res, err := r.client.Hosts.GetDeviceDetailsV2(&hosts.GetDeviceDetailsV2Params{
+			Context: ctx,
+			Ids:     batch,
+		})
+
+		if tferrors.IsNotFound(err) {
+			continue
+		}
+
+		if err != nil {
+			diags.AddError(
+				"Error reading host network containment",
+				fmt.Sprintf(
+					"Could not read hosts (%s): %s",
+					strings.Join(batch, ", "),
+					tferrors.Message(err, hostNetworkContainmentScopes...),
+				),
+			)
+			return statuses, diags
+		}
+
+		for _, device := range res.Payload.Resources {
+			if device != nil && device.DeviceID != nil {
+				statuses[*device.DeviceID] = device.Status
+			}
+		}
+	}
+
+	return statuses, diags
+}
+
+// hasContainmentStatus returns true when status satisfies the desired containment state.
+func hasContainmentStatus(desiredState, status string) bool {
+	for _, s := range containmentStatuses[desiredState] {
+		if strings.EqualFold(s, status) {
+			return true
+		}
+	}
+
+	return false
+}
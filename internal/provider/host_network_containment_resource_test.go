@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccHostNetworkContainmentResource(t *testing.T) {
+	// containing a host cuts it off from the network, so only the normal state is exercised.
+	deviceID := os.Getenv("DEVICE_ID")
+	if deviceID == "" {
+		t.Skip("DEVICE_ID must be set to run host network containment acceptance tests")
+	}
+
+	resourceName := "crowdstrike_host_network_containment.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "crowdstrike_host_network_containment" "test" {
+  device_ids    = ["%[1]s"]
+  desired_state = "normal"
+}
+`, deviceID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "desired_state", "normal"),
+					resource.TestCheckResourceAttr(resourceName, "device_ids.#", "1"),
+					resource.TestCheckResourceAttr(
+						resourceName,
+						fmt.Sprintf("statuses.%s", deviceID),
+						"normal",
+					),
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttrSet(resourceName, "last_updated"),
+				),
+			},
+		},
+	})
+}
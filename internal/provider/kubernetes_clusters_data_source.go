@@ -0,0 +1,230 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/client/kubernetes_protection"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/scopes"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/tferrors"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &kubernetesClustersDataSource{}
+	_ datasource.DataSourceWithConfigure = &kubernetesClustersDataSource{}
+)
+
+var kubernetesClustersScopes = []scopes.Scope{
+	{
+		Name:  "Kubernetes Protection",
+		Read:  true,
+		Write: false,
+	},
+}
+
+// kubernetesClustersQueryLimit is the page size used when querying clusters.
+const kubernetesClustersQueryLimit int64 = 200
+
+// NewKubernetesClustersDataSource is a helper function to simplify the provider implementation.
+func NewKubernetesClustersDataSource() datasource.DataSource {
+	return &kubernetesClustersDataSource{}
+}
+
+// kubernetesClustersDataSource is the data source implementation.
+type kubernetesClustersDataSource struct {
+	client *client.CrowdStrikeAPISpecification
+}
+
+// kubernetesClustersDataSourceModel maps the data source schema data.
+type kubernetesClustersDataSourceModel struct {
+	Filter   types.String        `tfsdk:"filter"`
+	Clusters []kubernetesCluster `tfsdk:"clusters"`
+}
+
+// kubernetesCluster maps a single registered kubernetes cluster.
+type kubernetesCluster struct {
+	ClusterID         types.String `tfsdk:"cluster_id"`
+	ClusterName       types.String `tfsdk:"cluster_name"`
+	CloudName         types.String `tfsdk:"cloud_name"`
+	CloudRegion       types.String `tfsdk:"cloud_region"`
+	CloudAccountID    types.String `tfsdk:"cloud_account_id"`
+	AgentStatus       types.String `tfsdk:"agent_status"`
+	ClusterStatus     types.String `tfsdk:"cluster_status"`
+	KubernetesVersion types.String `tfsdk:"kubernetes_version"`
+	NodeCount         types.Int64  `tfsdk:"node_count"`
+	LastSeen          types.String `tfsdk:"last_seen"`
+}
+
+// Metadata returns the data source type name.
+func (d *kubernetesClustersDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_kubernetes_clusters"
+}
+
+// Schema defines the schema for the data source.
+func (d *kubernetesClustersDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: fmt.Sprintf(
+			"Kubernetes Protection --- This data source returns the registered Kubernetes clusters matching an FQL filter.\n\n%s",
+			scopes.GenerateScopeDescription(kubernetesClustersScopes),
+		),
+		Attributes: map[string]schema.Attribute{
+			"filter": schema.StringAttribute{
+				Optional:    true,
+				Description: "FQL filter selecting the clusters to return, e.g. cloud_name:'aws'+agent_status:'Online'. All clusters are returned when left blank.",
+			},
+			"clusters": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The clusters matching the filter.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"cluster_id": schema.StringAttribute{
+							Computed:    true,
+							Description: "Identifier for the cluster.",
+						},
+						"cluster_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "Name of the cluster.",
+						},
+						"cloud_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "Cloud provider the cluster runs in.",
+						},
+						"cloud_region": schema.StringAttribute{
+							Computed:    true,
+							Description: "Cloud region the cluster runs in.",
+						},
+						"cloud_account_id": schema.StringAttribute{
+							Computed:    true,
+							Description: "Cloud account the cluster belongs to.",
+						},
+						"agent_status": schema.StringAttribute{
+							Computed:    true,
+							Description: "Status of the Falcon agent in the cluster.",
+						},
+						"cluster_status": schema.StringAttribute{
+							Computed:    true,
+							Description: "Status of the cluster.",
+						},
+						"kubernetes_version": schema.StringAttribute{
+							Computed:    true,
+							Description: "Kubernetes version of the cluster.",
+						},
+						"node_count": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Number of nodes in the cluster.",
+						},
+						"last_seen": schema.StringAttribute{
+							Computed:    true,
+							Description: "Timestamp the cluster was last seen.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *kubernetesClustersDataSource) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var state kubernetesClustersDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Clusters = []kubernetesCluster{}
+	limit := kubernetesClustersQueryLimit
+	var offset int64
+
+	for {
+		params := &kubernetes_protection.ClusterCombinedParams{
+			Context: ctx,
+			Limit:   &limit,
+			Offset:  &offset,
+		}
+
+		if state.Filter.ValueString() != "" {
+			params.Filter = state.Filter.ValueStringPointer()
+		}
+
+		res, err := d.client.KubernetesProtection.ClusterCombined(params)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to read kubernetes clusters",
+				tferrors.Message(err, kubernetesClustersScopes...),
+			)
+			return
+		}
+
+		for _, c := range res.Payload.Resources {
+			state.Clusters = append(state.Clusters, newKubernetesCluster(c))
+		}
+
+		var more bool
+		offset, more = nextPageOffset(offset, len(res.Payload.Resources), res.Payload.Meta)
+		if !more {
+			break
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// newKubernetesCluster maps a cluster returned by the API.
+func newKubernetesCluster(c *models.ModelsCluster) kubernetesCluster {
+	return kubernetesCluster{
+		ClusterID:         types.StringPointerValue(c.ClusterID),
+		ClusterName:       types.StringPointerValue(c.ClusterName),
+		CloudName:         types.StringPointerValue(c.CloudName),
+		CloudRegion:       types.StringPointerValue(c.CloudRegion),
+		CloudAccountID:    types.StringPointerValue(c.CloudAccountID),
+		AgentStatus:       types.StringPointerValue(c.AgentStatus),
+		ClusterStatus:     types.StringPointerValue(c.ClusterStatus),
+		KubernetesVersion: types.StringPointerValue(c.KubernetesVersion),
+		NodeCount:         int32Value(c.NodeCount),
+		LastSeen:          types.StringPointerValue(c.LastSeen),
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *kubernetesClustersDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.CrowdStrikeAPISpecification)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf(
+				"Expected *client.CrowdStrikeAPISpecification, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+		return
+	}
+
+	d.client = client
+}
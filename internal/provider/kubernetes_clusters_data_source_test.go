@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccKubernetesClustersDataSource(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+data "crowdstrike_kubernetes_clusters" "all" {}
+
+data "crowdstrike_kubernetes_clusters" "none" {
+  filter = "cluster_name:'tf-acceptance-test-does-not-exist'"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(
+						"data.crowdstrike_kubernetes_clusters.all",
+						"clusters.#",
+					),
+					resource.TestCheckResourceAttr(
+						"data.crowdstrike_kubernetes_clusters.none",
+						"clusters.#",
+						"0",
+					),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	redacted = "[REDACTED]"
+
+	// maxLoggedBodySize is the largest request or response body included in trace logs.
+	maxLoggedBodySize = 64 * 1024
+)
+
+// redactedHeaders are never written to the logs.
+var redactedHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+// secretBodyPaths are the path prefixes of endpoints whose request or response bodies
+// contain credentials or tokens, their bodies are never written to the logs.
+var secretBodyPaths = []string{
+	"/oauth2/",
+	"/policy/combined/reveal-uninstall-token/",
+}
+
+// loggingTransport is a http.RoundTripper that logs each request to the CrowdStrike APIs.
+// A summary is logged at debug level and headers and json bodies at trace level.
+// Credentials and tokens are redacted.
+type loggingTransport struct {
+	next http.RoundTripper
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	start := time.Now()
+
+	fields := map[string]any{
+		"http_method": req.Method,
+		"http_path":   req.URL.Path,
+	}
+
+	if req.URL.RawQuery != "" {
+		fields["http_query"] = req.URL.RawQuery
+	}
+
+	redactBody := hasSecretBody(req.URL.Path)
+
+	if req.Body != nil && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err == nil {
+			tflog.Trace(ctx, "CrowdStrike API request", map[string]any{
+				"http_method":  req.Method,
+				"http_path":    req.URL.Path,
+				"http_headers": redactHeaders(req.Header),
+				"http_body":    loggedBody(body, redactBody),
+			})
+		}
+	}
+
+	res, err := t.next.RoundTrip(req)
+	fields["http_duration_ms"] = time.Since(start).Milliseconds()
+
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.Debug(ctx, "CrowdStrike API request failed", fields)
+		return res, err
+	}
+
+	fields["http_status"] = res.StatusCode
+	fields["trace_id"] = res.Header.Get("X-Cs-Traceid")
+
+	tflog.Debug(ctx, "CrowdStrike API request", fields)
+
+	if res.Body != nil && strings.Contains(res.Header.Get("Content-Type"), "json") {
+		body, readErr := io.ReadAll(res.Body)
+		res.Body.Close()
+		res.Body = io.NopCloser(bytes.NewReader(body))
+
+		if readErr != nil {
+			return nil, readErr
+		}
+
+		tflog.Trace(ctx, "CrowdStrike API response", map[string]any{
+			"http_status":  res.StatusCode,
+			"http_path":    req.URL.Path,
+			"http_headers": redactHeaders(res.Header),
+			"http_body":    loggedBody(io.NopCloser(bytes.NewReader(body)), redactBody),
+			"trace_id":     res.Header.Get("X-Cs-Traceid"),
+		})
+	}
+
+	return res, nil
+}
+
+// hasSecretBody reports whether the path is an endpoint whose bodies contain credentials or tokens.
+func hasSecretBody(path string) bool {
+	for _, prefix := range secretBodyPaths {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func redactHeaders(header http.Header) map[string]string {
+	headers := make(map[string]string, len(header))
+
+	for k, v := range header {
+		headers[k] = strings.Join(v, ", ")
+	}
+
+	for _, k := range redactedHeaders {
+		if _, ok := headers[http.CanonicalHeaderKey(k)]; ok {
+			headers[http.CanonicalHeaderKey(k)] = redacted
+		}
+	}
+
+	return headers
+}
+
+func loggedBody(body io.ReadCloser, redact bool) string {
+	defer body.Close()
+
+	if redact {
+		return redacted
+	}
+
+	b, err := io.ReadAll(io.LimitReader(body, maxLoggedBodySize+1))
+	if err != nil {
+		return ""
+	}
+
+	if len(b) > maxLoggedBodySize {
+		return string(b[:maxLoggedBodySize]) + "...(truncated)"
+	}
+
+	return string(b)
+}
@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestLoggingTransport_redactsSecretBodies(t *testing.T) {
+	tests := []struct {
+		name   string
+		path   string
+		body   string
+		secret string
+		redact bool
+	}{
+		{
+			name:   "oauth2 token",
+			path:   "/oauth2/token",
+			body:   `{"access_token":"secret-access-token"}`,
+			secret: "secret-access-token",
+			redact: true,
+		},
+		{
+			name:   "reveal uninstall token",
+			path:   "/policy/combined/reveal-uninstall-token/v1",
+			body:   `{"resources":[{"uninstall_token":"secret-uninstall-token"}]}`,
+			secret: "secret-uninstall-token",
+			redact: true,
+		},
+		{
+			name:   "other endpoints",
+			path:   "/devices/entities/host-groups/v1",
+			body:   `{"resources":[{"name":"visible-host-group"}]}`,
+			secret: "visible-host-group",
+			redact: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logs bytes.Buffer
+			ctx := tflogtest.RootLogger(context.Background(), &logs)
+
+			transport := &loggingTransport{
+				next: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     http.Header{"Content-Type": []string{"application/json"}},
+						Body:       io.NopCloser(strings.NewReader(tt.body)),
+					}, nil
+				}),
+			}
+
+			req, err := http.NewRequestWithContext(
+				ctx,
+				http.MethodPost,
+				"https://api.crowdstrike.com"+tt.path,
+				strings.NewReader(tt.body),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			res, err := transport.RoundTrip(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			// the caller still receives the unredacted body.
+			body, _ := io.ReadAll(res.Body)
+			if string(body) != tt.body {
+				t.Errorf("expected response body %s, got %s", tt.body, body)
+			}
+
+			logged := strings.Contains(logs.String(), tt.secret)
+			if tt.redact && logged {
+				t.Errorf("expected the body of %s to be redacted, got logs: %s", tt.path, logs.String())
+			}
+			if !tt.redact && !logged {
+				t.Errorf("expected the body of %s to be logged, got logs: %s", tt.path, logs.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/crowdstrike/gofalcon/falcon"
+	"github.com/crowdstrike/gofalcon/falcon/client/host_group"
+	membercid "github.com/crowdstrike/terraform-provider-crowdstrike/internal/member_cid"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestMemberClients(t *testing.T) {
+	m := newMockFalcon(t)
+	m.handle("GET /devices/queries/host-groups/v1", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]any{"meta": map[string]any{}, "resources": []string{}})
+	})
+
+	members := membercid.NewClients(m.apiConfig())
+
+	childA, diags := members.Client("child-a")
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if _, err := childA.HostGroup.QueryHostGroups(&host_group.QueryHostGroupsParams{Context: context.Background()}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got := m.tokenMemberCIDs(); !slices.Equal(got, []string{"child-a"}) {
+		t.Errorf("expected a token for member CID child-a, got %v", got)
+	}
+
+	cached, _ := members.Client("CHILD-A")
+	if cached != childA {
+		t.Error("expected the client of a member CID to be reused")
+	}
+
+	childB, _ := members.Client("child-b")
+	if childB == childA {
+		t.Error("expected a separate client for each member CID")
+	}
+
+	provider := m.client(t)
+
+	c, diags := membercid.ClientFor(provider, members, types.StringNull())
+	if diags.HasError() || c != provider {
+		t.Error("expected the provider client when member_cid is not set")
+	}
+
+	c, diags = membercid.ClientFor(provider, members, types.StringValue("child-b"))
+	if diags.HasError() || c != childB {
+		t.Error("expected the member CID client when member_cid is set")
+	}
+}
+
+func TestMemberClients_accessToken(t *testing.T) {
+	members := membercid.NewClients(falcon.ApiConfig{
+		AccessToken: "token",
+		Cloud:       falcon.CloudUs1,
+	})
+
+	_, diags := members.Client("child-a")
+	if !diags.HasError() {
+		t.Fatal("expected an error when the provider uses an access token")
+	}
+}
@@ -0,0 +1,198 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/crowdstrike/gofalcon/falcon"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"golang.org/x/oauth2"
+)
+
+// mockFalcon is an in-process CrowdStrike API used to test resource logic and error
+// handling without live credentials. The oauth2 endpoints are always served, tests
+// register handlers for the API endpoints the resource under test calls.
+type mockFalcon struct {
+	server       *httptest.Server
+	mux          *http.ServeMux
+	caBundlePath string
+
+	mu       sync.Mutex
+	requests map[string]int
+	// memberCIDs are the member_cid values of the token requests.
+	memberCIDs []string
+}
+
+// newMockFalcon starts a mock CrowdStrike API that is stopped when the test ends.
+func newMockFalcon(t *testing.T) *mockFalcon {
+	t.Helper()
+
+	m := &mockFalcon{
+		mux:      http.NewServeMux(),
+		requests: map[string]int{},
+	}
+
+	m.mux.HandleFunc("POST /oauth2/token", func(w http.ResponseWriter, r *http.Request) {
+		token := "mock-token"
+		if memberCID := r.PostFormValue("member_cid"); memberCID != "" {
+			m.mu.Lock()
+			m.memberCIDs = append(m.memberCIDs, memberCID)
+			m.mu.Unlock()
+			token += "-" + memberCID
+		}
+
+		w.Header().Set("X-Cs-Region", "us-1")
+		writeJSON(w, http.StatusCreated, map[string]any{
+			"access_token": token,
+			"token_type":   "bearer",
+			"expires_in":   1799,
+		})
+	})
+	m.mux.HandleFunc("POST /oauth2/revoke", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]any{"resources": []any{}})
+	})
+
+	m.server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		m.mu.Lock()
+		m.requests[r.Method+" "+r.URL.Path]++
+		m.mu.Unlock()
+
+		m.mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(m.server.Close)
+
+	// The provider only trusts the mock certificate through ca_bundle_path,
+	// the same way it would trust a TLS inspecting proxy.
+	m.caBundlePath = filepath.Join(t.TempDir(), "mock-ca.pem")
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: m.server.Certificate().Raw})
+	if err := os.WriteFile(m.caBundlePath, cert, 0o600); err != nil {
+		t.Fatalf("unable to write mock ca bundle: %s", err)
+	}
+
+	return m
+}
+
+// handle registers a handler for a method and path pattern, such as
+// "GET /devices/entities/host-groups/v1".
+func (m *mockFalcon) handle(pattern string, handler http.HandlerFunc) {
+	m.mux.HandleFunc(pattern, handler)
+}
+
+// calls returns the number of requests received for a method and path, such as
+// "DELETE /devices/entities/host-groups/v1".
+func (m *mockFalcon) calls(request string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.requests[request]
+}
+
+// tokenMemberCIDs returns the member_cid of each token request that set one.
+func (m *mockFalcon) tokenMemberCIDs() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return slices.Clone(m.memberCIDs)
+}
+
+// requestMemberCID returns the member CID of the token a request was sent with, empty
+// for a token of the parent CID.
+func requestMemberCID(r *http.Request) string {
+	return strings.TrimPrefix(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer mock-token"), "-")
+}
+
+// host returns the host and port of the mock API.
+func (m *mockFalcon) host() string {
+	return strings.TrimPrefix(m.server.URL, "https://")
+}
+
+// providerConfig returns a provider block that authenticates to the mock API.
+func (m *mockFalcon) providerConfig() string {
+	return fmt.Sprintf(`
+provider "crowdstrike" {
+  cloud          = "us-1"
+  client_id      = "mock-client-id"
+  client_secret  = "mock-client-secret"
+  ca_bundle_path = %q
+}
+`, m.caBundlePath)
+}
+
+// providerFactories returns provider factories that send every request to the mock API.
+func (m *mockFalcon) providerFactories() map[string]func() (tfprotov6.ProviderServer, error) {
+	return map[string]func() (tfprotov6.ProviderServer, error){
+		"crowdstrike": providerserver.NewProtocol6WithError(&CrowdStrikeProvider{
+			version: "test",
+			apiHost: m.host(),
+		}),
+	}
+}
+
+// apiConfig returns the gofalcon configuration of a client for the mock API.
+func (m *mockFalcon) apiConfig() falcon.ApiConfig {
+	return falcon.ApiConfig{
+		ClientId:     "mock-client-id",
+		ClientSecret: "mock-client-secret",
+		HostOverride: m.host(),
+		Context:      context.WithValue(context.Background(), oauth2.HTTPClient, m.server.Client()),
+	}
+}
+
+// client returns a gofalcon client for the mock API, for testing resource helpers
+// directly instead of through Terraform.
+func (m *mockFalcon) client(t *testing.T) *client.CrowdStrikeAPISpecification {
+	t.Helper()
+
+	apiConfig := m.apiConfig()
+	c, err := falcon.NewClient(&apiConfig)
+	if err != nil {
+		t.Fatalf("unable to create mock client: %s", err)
+	}
+
+	return c
+}
+
+// mockUnitTest runs a test case against the mock API. Unlike acceptance tests it does
+// not require TF_ACC or credentials, only a Terraform binary.
+func mockUnitTest(t *testing.T, m *mockFalcon, testCase resource.TestCase) {
+	t.Helper()
+
+	if os.Getenv("TF_ACC_TERRAFORM_PATH") == "" {
+		if _, err := exec.LookPath("terraform"); err != nil {
+			t.Skip("terraform binary not found, set TF_ACC_TERRAFORM_PATH to run mock tests")
+		}
+	}
+
+	testCase.ProtoV6ProviderFactories = m.providerFactories()
+	resource.UnitTest(t, testCase)
+}
+
+// writeJSON writes a json response with the given status code.
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// writeAPIError writes a CrowdStrike API error response.
+func writeAPIError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]any{
+		"meta":      map[string]any{},
+		"resources": []any{},
+		"errors":    []map[string]any{{"code": status, "message": message}},
+	})
+}
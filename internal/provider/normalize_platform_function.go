@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &normalizePlatformFunction{}
+
+// platformAliases maps the lowercase spellings of a platform to the name the API uses.
+var platformAliases = map[string]string{
+	"windows": "Windows",
+	"win":     "Windows",
+	"linux":   "Linux",
+	"lin":     "Linux",
+	"mac":     "Mac",
+	"macos":   "Mac",
+	"osx":     "Mac",
+	"darwin":  "Mac",
+}
+
+// NewNormalizePlatformFunction is a helper function to simplify the provider implementation.
+func NewNormalizePlatformFunction() function.Function {
+	return &normalizePlatformFunction{}
+}
+
+// normalizePlatformFunction is the normalize_platform function implementation.
+type normalizePlatformFunction struct{}
+
+// Metadata returns the function name.
+func (f *normalizePlatformFunction) Metadata(
+	_ context.Context,
+	_ function.MetadataRequest,
+	resp *function.MetadataResponse,
+) {
+	resp.Name = "normalize_platform"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *normalizePlatformFunction) Definition(
+	_ context.Context,
+	_ function.DefinitionRequest,
+	resp *function.DefinitionResponse,
+) {
+	resp.Definition = function.Definition{
+		Summary: "Normalize a platform name",
+		MarkdownDescription: "Returns the platform name the CrowdStrike API and the policy resources use " +
+			"(`Windows`, `Linux` or `Mac`) for a case insensitive platform name or common alias " +
+			"such as `win`, `macos` or `darwin`. Unknown platforms are an error.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "platform",
+				MarkdownDescription: "Platform name to normalize.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run normalizes the platform name.
+func (f *normalizePlatformFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var platform string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &platform))
+	if resp.Error != nil {
+		return
+	}
+
+	normalized, err := normalizePlatform(platform)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, normalized))
+}
+
+// normalizePlatform returns the API platform name for platform.
+func normalizePlatform(platform string) (string, error) {
+	normalized, ok := platformAliases[strings.ToLower(strings.TrimSpace(platform))]
+	if !ok {
+		return "", fmt.Errorf("unknown platform %q, expected one of Windows, Linux or Mac", platform)
+	}
+
+	return normalized, nil
+}
@@ -0,0 +1,34 @@
+package provider
+
+import "testing"
+
+func TestNormalizePlatform(t *testing.T) {
+	tests := map[string]string{
+		"windows": "Windows",
+		"WIN":     "Windows",
+		"Windows": "Windows",
+		" linux ": "Linux",
+		"LINUX":   "Linux",
+		"mac":     "Mac",
+		"macOS":   "Mac",
+		"Darwin":  "Mac",
+	}
+
+	for platform, expected := range tests {
+		normalized, err := normalizePlatform(platform)
+		if err != nil {
+			t.Errorf("normalizePlatform(%q) returned an error: %s", platform, err)
+			continue
+		}
+
+		if normalized != expected {
+			t.Errorf("normalizePlatform(%q) = %q; want %q", platform, normalized, expected)
+		}
+	}
+
+	for _, platform := range []string{"", "android", "windows10"} {
+		if _, err := normalizePlatform(platform); err == nil {
+			t.Errorf("normalizePlatform(%q) expected an error", platform)
+		}
+	}
+}
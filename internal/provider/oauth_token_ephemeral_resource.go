@@ -0,0 +1,276 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ ephemeral.EphemeralResource              = &oauthTokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &oauthTokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithClose     = &oauthTokenEphemeralResource{}
+)
+
+// oauthTokenPrivateKey is the private data key holding the token to revoke on close.
+const oauthTokenPrivateKey = "oauth_token"
+
+// NewOAuthTokenEphemeralResource is a helper function to simplify the provider implementation.
+func NewOAuthTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &oauthTokenEphemeralResource{}
+}
+
+// ephemeralResourceData is passed to ephemeral resources by the provider. Ephemeral resources
+// that talk to the oauth2 endpoints directly need the credentials, the others use the API client.
+type ephemeralResourceData struct {
+	client       *client.CrowdStrikeAPISpecification
+	httpClient   *http.Client
+	cloud        string
+	baseURL      string
+	clientId     string
+	clientSecret string
+	memberCID    string
+}
+
+// oauthTokenEphemeralResource is the ephemeral resource implementation.
+type oauthTokenEphemeralResource struct {
+	data *ephemeralResourceData
+}
+
+// oauthTokenEphemeralResourceModel maps the ephemeral resource schema data.
+type oauthTokenEphemeralResourceModel struct {
+	ClientId     types.String `tfsdk:"client_id"`
+	ClientSecret types.String `tfsdk:"client_secret"`
+	MemberCID    types.String `tfsdk:"member_cid"`
+	AccessToken  types.String `tfsdk:"access_token"`
+	TokenType    types.String `tfsdk:"token_type"`
+	ExpiresIn    types.Int64  `tfsdk:"expires_in"`
+	ExpiresAt    types.String `tfsdk:"expires_at"`
+	BaseURL      types.String `tfsdk:"base_url"`
+}
+
+// oauthTokenPrivateData is stored in the private data so the token can be revoked on close.
+type oauthTokenPrivateData struct {
+	ClientId    string `json:"client_id"`
+	AccessToken string `json:"access_token"`
+}
+
+// Configure adds the provider configured data to the ephemeral resource.
+func (r *oauthTokenEphemeralResource) Configure(
+	ctx context.Context,
+	req ephemeral.ConfigureRequest,
+	resp *ephemeral.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ephemeralResourceData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf(
+				"Expected *provider.ephemeralResourceData, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+
+		return
+	}
+
+	r.data = data
+}
+
+// Metadata returns the ephemeral resource type name.
+func (r *oauthTokenEphemeralResource) Metadata(
+	_ context.Context,
+	req ephemeral.MetadataRequest,
+	resp *ephemeral.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_oauth_token"
+}
+
+// Schema defines the schema for the ephemeral resource.
+func (r *oauthTokenEphemeralResource) Schema(
+	_ context.Context,
+	_ ephemeral.SchemaRequest,
+	resp *ephemeral.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "OAuth2 --- This ephemeral resource creates a short-lived CrowdStrike Falcon API bearer token that other providers and provisioners can use to call the Falcon APIs without the client secret or token being written to state or plan. The token has the API scopes assigned to the API client and is revoked when Terraform closes the ephemeral resource. Requires Terraform 1.10 or later.",
+		Attributes: map[string]schema.Attribute{
+			"client_id": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Falcon Client Id used to create the token. The client id configured for the provider is used when left blank.",
+			},
+			"client_secret": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Falcon Client Secret used to create the token. Required when client_id is set.",
+			},
+			"member_cid": schema.StringAttribute{
+				Optional:    true,
+				Description: "CID of a child tenant to create the token for when using Falcon Flight Control. The member_cid configured for the provider is used when left blank and client_id is not set.",
+			},
+			"access_token": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The OAuth2 bearer token.",
+			},
+			"token_type": schema.StringAttribute{
+				Computed:    true,
+				Description: "The OAuth2 token type.",
+			},
+			"expires_in": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Number of seconds until the token expires.",
+			},
+			"expires_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "RFC3339 timestamp of when the token expires.",
+			},
+			"base_url": schema.StringAttribute{
+				Computed:    true,
+				Description: "Base url of the CrowdStrike API the token is valid for.",
+			},
+		},
+	}
+}
+
+// Open creates the token.
+func (r *oauthTokenEphemeralResource) Open(
+	ctx context.Context,
+	req ephemeral.OpenRequest,
+	resp *ephemeral.OpenResponse,
+) {
+	var config oauthTokenEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.data == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured CrowdStrike Provider",
+			"The provider must be configured before the crowdstrike_oauth_token ephemeral resource can be opened.",
+		)
+		return
+	}
+
+	clientId := r.data.clientId
+	clientSecret := r.data.clientSecret
+	memberCID := r.data.memberCID
+
+	if !config.ClientId.IsNull() || !config.ClientSecret.IsNull() {
+		clientId = config.ClientId.ValueString()
+		clientSecret = config.ClientSecret.ValueString()
+		memberCID = ""
+	}
+
+	if !config.MemberCID.IsNull() {
+		memberCID = config.MemberCID.ValueString()
+	}
+
+	if clientId == "" || clientSecret == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("client_id"),
+			"Missing CrowdStrike API Credentials",
+			"Both client_id and client_secret are required to create a token. "+
+				"Set them on the ephemeral resource, or configure the provider with client_id and client_secret instead of an access_token.",
+		)
+		return
+	}
+
+	token, _, err := requestToken(
+		ctx,
+		r.data.httpClient,
+		r.data.baseURL,
+		r.data.cloud,
+		clientId,
+		clientSecret,
+		memberCID,
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating CrowdStrike OAuth2 token",
+			"Could not create OAuth2 token: "+err.Error(),
+		)
+		return
+	}
+
+	config.AccessToken = types.StringValue(token.AccessToken)
+	config.TokenType = types.StringValue(token.TokenType)
+	config.ExpiresIn = types.Int64Value(token.ExpiresIn)
+	config.ExpiresAt = types.StringValue(
+		time.Now().Add(time.Duration(token.ExpiresIn) * time.Second).UTC().Format(time.RFC3339),
+	)
+	config.BaseURL = types.StringValue(r.data.baseURL)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	private, err := json.Marshal(oauthTokenPrivateData{
+		ClientId:    clientId,
+		AccessToken: token.AccessToken,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating CrowdStrike OAuth2 token",
+			"Could not store the token for revocation: "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, oauthTokenPrivateKey, private)...)
+}
+
+// Close revokes the token. Tokens created with credentials from the ephemeral resource
+// configuration can not be revoked as the client secret is not kept, they expire on their own.
+func (r *oauthTokenEphemeralResource) Close(
+	ctx context.Context,
+	req ephemeral.CloseRequest,
+	resp *ephemeral.CloseResponse,
+) {
+	b, diags := req.Private.GetKey(ctx, oauthTokenPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || len(b) == 0 || r.data == nil {
+		return
+	}
+
+	var private oauthTokenPrivateData
+	if err := json.Unmarshal(b, &private); err != nil {
+		resp.Diagnostics.AddError(
+			"Error closing CrowdStrike OAuth2 token",
+			"Could not read the token to revoke: "+err.Error(),
+		)
+		return
+	}
+
+	if private.ClientId != r.data.clientId || r.data.clientSecret == "" {
+		tflog.Debug(ctx, "Skipping revocation of CrowdStrike OAuth2 token created with ephemeral resource credentials")
+		return
+	}
+
+	revokeToken(
+		ctx,
+		r.data.httpClient,
+		r.data.baseURL,
+		r.data.clientId,
+		r.data.clientSecret,
+		private.AccessToken,
+	)
+}
@@ -0,0 +1,206 @@
+package provider
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &parseExclusionCSVFunction{}
+
+// exclusionCSVColumns are the columns of an exclusion csv, in the order used when the csv
+// has no header row.
+var exclusionCSVColumns = []string{"value", "comment", "host_groups", "apply_to_descendant_processes"}
+
+// exclusionCSVRow is an exclusion in the shape of the exclusions attribute of
+// crowdstrike_sensor_visibility_exclusions.
+type exclusionCSVRow struct {
+	Value                      string   `tfsdk:"value"`
+	Comment                    *string  `tfsdk:"comment"`
+	HostGroups                 []string `tfsdk:"host_groups"`
+	ApplyToDescendantProcesses bool     `tfsdk:"apply_to_descendant_processes"`
+}
+
+// NewParseExclusionCSVFunction is a helper function to simplify the provider implementation.
+func NewParseExclusionCSVFunction() function.Function {
+	return &parseExclusionCSVFunction{}
+}
+
+// parseExclusionCSVFunction is the parse_exclusion_csv function implementation.
+type parseExclusionCSVFunction struct{}
+
+// Metadata returns the function name.
+func (f *parseExclusionCSVFunction) Metadata(
+	_ context.Context,
+	_ function.MetadataRequest,
+	resp *function.MetadataResponse,
+) {
+	resp.Name = "parse_exclusion_csv"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *parseExclusionCSVFunction) Definition(
+	_ context.Context,
+	_ function.DefinitionRequest,
+	resp *function.DefinitionResponse,
+) {
+	resp.Definition = function.Definition{
+		Summary: "Parse a CSV of sensor visibility exclusions",
+		MarkdownDescription: "Parses a CSV of exclusions into the list the `exclusions` attribute of " +
+			"`crowdstrike_sensor_visibility_exclusions` accepts, for example the contents of an exclusion export read with `file()`. " +
+			"The columns are `value`, `comment`, `host_groups` and `apply_to_descendant_processes`, only `value` is required. " +
+			"Host group ids are separated by `;`, an empty `host_groups` applies the exclusion to all hosts. " +
+			"The first row is used as a header when every cell is one of the column names, so the columns can be in any order. " +
+			"Empty lines and lines starting with `#` are skipped.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "csv",
+				MarkdownDescription: "CSV content to parse.",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.ObjectType{
+				AttrTypes: map[string]attr.Type{
+					"value":                         types.StringType,
+					"comment":                       types.StringType,
+					"host_groups":                   types.ListType{ElemType: types.StringType},
+					"apply_to_descendant_processes": types.BoolType,
+				},
+			},
+		},
+	}
+}
+
+// Run parses the csv.
+func (f *parseExclusionCSVFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var content string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &content))
+	if resp.Error != nil {
+		return
+	}
+
+	rows, err := parseExclusionCSV(content)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, rows))
+}
+
+// parseExclusionCSV returns an exclusion for each record of content.
+func parseExclusionCSV(content string) ([]exclusionCSVRow, error) {
+	reader := csv.NewReader(strings.NewReader(content))
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	columns := exclusionCSVColumns
+	rows := []exclusionCSVRow{}
+	seen := map[string]int{}
+
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+
+		if first && isExclusionCSVHeader(record) {
+			header, err := exclusionCSVHeader(record)
+			if err != nil {
+				return nil, err
+			}
+			columns = header
+			continue
+		}
+
+		if len(record) > len(columns) {
+			return nil, fmt.Errorf("line %d has %d columns, expected at most %d", line, len(record), len(columns))
+		}
+
+		var row exclusionCSVRow
+		for i, field := range record {
+			field = strings.TrimSpace(field)
+			if field == "" {
+				continue
+			}
+
+			switch columns[i] {
+			case "value":
+				row.Value = field
+			case "comment":
+				row.Comment = &field
+			case "host_groups":
+				for _, group := range strings.Split(field, ";") {
+					if group = strings.TrimSpace(group); group != "" {
+						row.HostGroups = append(row.HostGroups, group)
+					}
+				}
+			case "apply_to_descendant_processes":
+				row.ApplyToDescendantProcesses, err = strconv.ParseBool(field)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: apply_to_descendant_processes must be true or false, got %q", line, field)
+				}
+			}
+		}
+
+		if row.Value == "" {
+			return nil, fmt.Errorf("line %d: value is required", line)
+		}
+
+		if previous, ok := seen[row.Value]; ok {
+			return nil, fmt.Errorf("line %d: duplicate value %q, first seen on line %d", line, row.Value, previous)
+		}
+		seen[row.Value] = line
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// isExclusionCSVHeader reports whether every cell of record is a column name.
+func isExclusionCSVHeader(record []string) bool {
+	for _, name := range record {
+		if !slices.Contains(exclusionCSVColumns, strings.ToLower(strings.TrimSpace(name))) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// exclusionCSVHeader returns the columns named by a header record.
+func exclusionCSVHeader(record []string) ([]string, error) {
+	columns := make([]string, 0, len(record))
+	seen := map[string]bool{}
+
+	for _, name := range record {
+		name = strings.ToLower(strings.TrimSpace(name))
+
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate column %q in the header", name)
+		}
+		seen[name] = true
+
+		columns = append(columns, name)
+	}
+
+	return columns, nil
+}
@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestParseExclusionCSV(t *testing.T) {
+	comment := "legacy av"
+
+	tests := []struct {
+		name     string
+		csv      string
+		expected []exclusionCSVRow
+		wantErr  bool
+	}{
+		{
+			name: "columns in default order",
+			csv:  "/opt/app/**,legacy av,hg-1;hg-2\n\n# skipped\n/tmp/build/*\n",
+			expected: []exclusionCSVRow{
+				{Value: "/opt/app/**", Comment: &comment, HostGroups: []string{"hg-1", "hg-2"}},
+				{Value: "/tmp/build/*"},
+			},
+		},
+		{
+			name: "header with columns in any order",
+			csv:  "value,apply_to_descendant_processes,comment\n\"/opt/a,b/**\",true, legacy av \n",
+			expected: []exclusionCSVRow{
+				{Value: "/opt/a,b/**", Comment: &comment, ApplyToDescendantProcesses: true},
+			},
+		},
+		{
+			name:     "empty",
+			csv:      "",
+			expected: []exclusionCSVRow{},
+		},
+		{
+			name:    "missing value",
+			csv:     ",comment\n",
+			wantErr: true,
+		},
+		{
+			name:    "duplicate value",
+			csv:     "/opt/app/**\n/opt/app/**\n",
+			wantErr: true,
+		},
+		{
+			name: "header that does not start with value",
+			csv:  "comment,value,host_groups\nlegacy av,/opt/app/**,hg-1\n",
+			expected: []exclusionCSVRow{
+				{Value: "/opt/app/**", Comment: &comment, HostGroups: []string{"hg-1"}},
+			},
+		},
+		{
+			name: "first row with an unknown column is data",
+			csv:  "value,legacy av\n",
+			expected: []exclusionCSVRow{
+				{Value: "value", Comment: &comment},
+			},
+		},
+		{
+			name:    "duplicate header column",
+			csv:     "value,comment,value\n/opt/app/**,legacy av,/opt\n",
+			wantErr: true,
+		},
+		{
+			name:    "too many columns",
+			csv:     "/opt/app/**,comment,hg-1,false,extra\n",
+			wantErr: true,
+		},
+		{
+			name:    "invalid bool",
+			csv:     "/opt/app/**,,,maybe\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := parseExclusionCSV(tt.csv)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %+v", rows)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !reflect.DeepEqual(rows, tt.expected) {
+				t.Errorf("parseExclusionCSV() = %+v, want %+v", rows, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseExclusionCSVFunctionRun(t *testing.T) {
+	f := NewParseExclusionCSVFunction()
+
+	var definition function.DefinitionResponse
+	f.Definition(context.Background(), function.DefinitionRequest{}, &definition)
+
+	req := function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{types.StringValue("/opt/app/**,,hg-1\n/tmp/*\n")}),
+	}
+	result, funcErr := definition.Definition.Return.NewResultData(context.Background())
+	if funcErr != nil {
+		t.Fatalf("unexpected error: %s", funcErr)
+	}
+	resp := function.RunResponse{Result: result}
+
+	f.Run(context.Background(), req, &resp)
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %s", resp.Error)
+	}
+
+	list, ok := resp.Result.Value().(types.List)
+	if !ok || len(list.Elements()) != 2 {
+		t.Fatalf("expected a list of 2 exclusions, got %s", resp.Result.Value())
+	}
+
+	second := list.Elements()[1].(types.Object).Attributes()
+	if !second["comment"].IsNull() || !second["host_groups"].IsNull() {
+		t.Errorf("expected unset columns to be null, got %s", list.Elements()[1])
+	}
+}
@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/crowdstrike/gofalcon/falcon/models"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// policiesQueryLimit is the page size used when querying policies.
+const policiesQueryLimit int64 = 500
+
+// policiesDataSourceModel maps the schema data shared by the policy data sources.
+type policiesDataSourceModel struct {
+	Filter   types.String      `tfsdk:"filter"`
+	Policies []policyDataModel `tfsdk:"policies"`
+}
+
+// policyDataModel maps a single policy returned by the policy data sources.
+type policyDataModel struct {
+	ID           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	Description  types.String `tfsdk:"description"`
+	PlatformName types.String `tfsdk:"platform_name"`
+	Enabled      types.Bool   `tfsdk:"enabled"`
+	HostGroups   types.Set    `tfsdk:"host_groups"`
+}
+
+// policiesDataSourceAttributes returns the schema attributes shared by the policy data sources.
+func policiesDataSourceAttributes(policyType string) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"filter": schema.StringAttribute{
+			Optional:    true,
+			Description: "FQL filter used to select the " + policyType + " policies, e.g. platform_name:'Windows'. All policies are returned when left blank.",
+		},
+		"policies": schema.ListNestedAttribute{
+			Computed:    true,
+			Description: "The " + policyType + " policies matching the filter.",
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"id": schema.StringAttribute{
+						Computed:    true,
+						Description: "Identifier for the policy.",
+					},
+					"name": schema.StringAttribute{
+						Computed:    true,
+						Description: "Name of the policy.",
+					},
+					"description": schema.StringAttribute{
+						Computed:    true,
+						Description: "Description of the policy.",
+					},
+					"platform_name": schema.StringAttribute{
+						Computed:    true,
+						Description: "Platform of the policy.",
+					},
+					"enabled": schema.BoolAttribute{
+						Computed:    true,
+						Description: "Whether the policy is enabled.",
+					},
+					"host_groups": schema.SetAttribute{
+						Computed:    true,
+						ElementType: types.StringType,
+						Description: "Host Group ids attached to the policy.",
+					},
+				},
+			},
+		},
+	}
+}
+
+// newPolicyDataModel maps the fields shared by every policy type into a policyDataModel.
+func newPolicyDataModel(
+	ctx context.Context,
+	id, name, description, platformName *string,
+	enabled *bool,
+	groups []*models.HostGroupsHostGroupV1,
+) (policyDataModel, diag.Diagnostics) {
+	hostGroups := []string{}
+	for _, group := range groups {
+		if group != nil && group.ID != nil {
+			hostGroups = append(hostGroups, *group.ID)
+		}
+	}
+
+	hostGroupIDs, diags := types.SetValueFrom(ctx, types.StringType, hostGroups)
+
+	return policyDataModel{
+		ID:           types.StringPointerValue(id),
+		Name:         types.StringPointerValue(name),
+		Description:  types.StringPointerValue(description),
+		PlatformName: types.StringPointerValue(platformName),
+		Enabled:      types.BoolPointerValue(enabled),
+		HostGroups:   hostGroupIDs,
+	}, diags
+}
+
+// nextPageOffset returns the offset of the next page of a query, or false when all pages were read.
+func nextPageOffset(offset int64, pageSize int, meta *models.MsaMetaInfo) (int64, bool) {
+	offset += int64(pageSize)
+
+	if pageSize == 0 || meta == nil || meta.Pagination == nil || meta.Pagination.Total == nil {
+		return offset, false
+	}
+
+	return offset, offset < *meta.Pagination.Total
+}
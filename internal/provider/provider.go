@@ -5,14 +5,29 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/crowdstrike/gofalcon/falcon"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/credentials"
+	detectionstatusautomation "github.com/crowdstrike/terraform-provider-crowdstrike/internal/detection_status_automation"
 	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/fim"
+	membercid "github.com/crowdstrike/terraform-provider-crowdstrike/internal/member_cid"
+	policyattachment "github.com/crowdstrike/terraform-provider-crowdstrike/internal/policy_attachment"
 	preventionpolicy "github.com/crowdstrike/terraform-provider-crowdstrike/internal/prevention_policy"
+	sensorvisibilityexclusion "github.com/crowdstrike/terraform-provider-crowdstrike/internal/sensor_visibility_exclusion"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/workflow"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
@@ -21,11 +36,19 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/oauth2"
 )
 
 // Ensure ScaffoldingProvider satisfies various provider interfaces.
 var _ provider.Provider = &CrowdStrikeProvider{}
 var _ provider.ProviderWithFunctions = &CrowdStrikeProvider{}
+var _ provider.ProviderWithEphemeralResources = &CrowdStrikeProvider{}
+
+// defaultTimeout is used for a resource operation when the timeouts block does not configure one.
+const defaultTimeout = 20 * time.Minute
+
+// userAgentSuffixPattern allows printable ascii characters so the suffix cannot break the header.
+var userAgentSuffixPattern = regexp.MustCompile(`^[\x20-\x7E]+$`)
 
 // CrowdStrikeProvider defines the provider implementation.
 type CrowdStrikeProvider struct {
@@ -33,13 +56,49 @@ type CrowdStrikeProvider struct {
 	// provider is built and ran locally, and "test" when running acceptance
 	// testing.
 	version string
+	// apiHost replaces the cloud api host when set, it is used by tests to
+	// point the provider at a mock CrowdStrike API.
+	apiHost string
+}
+
+// host returns the api host the provider sends requests to for the given cloud.
+func (p *CrowdStrikeProvider) host(cloud string) string {
+	if p.apiHost != "" {
+		return p.apiHost
+	}
+
+	return cloudHosts[cloud]
+}
+
+// baseURL returns the base url of the API for the given cloud.
+func (p *CrowdStrikeProvider) baseURL(cloud string) string {
+	if p.apiHost != "" {
+		return "https://" + p.apiHost
+	}
+
+	return cloudBaseURL(cloud)
 }
 
 // CrowdStrikeProviderModel describes the provider data model.
 type CrowdStrikeProviderModel struct {
-	Cloud        types.String `tfsdk:"cloud"`
-	ClientSecret types.String `tfsdk:"client_id"`
-	ClientId     types.String `tfsdk:"client_secret"`
+	Cloud                 types.String `tfsdk:"cloud"`
+	ClientId              types.String `tfsdk:"client_id"`
+	ClientSecret          types.String `tfsdk:"client_secret"`
+	AccessToken           types.String `tfsdk:"access_token"`
+	MemberCID             types.String `tfsdk:"member_cid"`
+	Profile               types.String `tfsdk:"profile"`
+	CredentialsFile       types.String `tfsdk:"credentials_file"`
+	ProxyURL              types.String `tfsdk:"proxy_url"`
+	CABundlePath          types.String `tfsdk:"ca_bundle_path"`
+	TLSInsecureSkipVerify types.Bool   `tfsdk:"tls_insecure_skip_verify"`
+	MaxConcurrentRequests types.Int64  `tfsdk:"max_concurrent_requests"`
+	DebugHTTP             types.Bool   `tfsdk:"debug_http"`
+	UserAgentSuffix       types.String `tfsdk:"user_agent_suffix"`
+	MaxIdleConns          types.Int64  `tfsdk:"max_idle_conns"`
+	IdleConnTimeout       types.String `tfsdk:"idle_conn_timeout"`
+	TLSHandshakeTimeout   types.String `tfsdk:"tls_handshake_timeout"`
+	EnforcementMode       types.String `tfsdk:"enforcement_mode"`
+	ValidateReferences    types.Bool   `tfsdk:"validate_references"`
 }
 
 func (p *CrowdStrikeProvider) Metadata(
@@ -69,8 +128,84 @@ func (p *CrowdStrikeProvider) Schema(
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"access_token": schema.StringAttribute{
+				MarkdownDescription: "Pre-issued OAuth2 bearer token used for authenticating to the CrowdStrike APIs instead of client_id and client_secret. Will use FALCON_ACCESS_TOKEN environment variable when left blank. Requires cloud to be set to a value other than autodiscover.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"member_cid": schema.StringAttribute{
+				MarkdownDescription: "CID of a child tenant to manage when using Falcon Flight Control. The provider requests its tokens for the child CID, so every resource and data source is read and written in the child. Resources that support `member_cid` can override it. Requires client_id and client_secret. Will use FALCON_MEMBER_CID environment variable when left blank.",
+				Optional:            true,
+			},
+			"profile": schema.StringAttribute{
+				MarkdownDescription: "Named profile in the shared credentials file to read credentials from. Will use FALCON_PROFILE environment variable when left blank. Credentials from the profile are only used when client_id, client_secret, and access_token are not set in the configuration or environment. Defaults to `default`.",
+				Optional:            true,
+			},
+			"credentials_file": schema.StringAttribute{
+				MarkdownDescription: "Path to the shared credentials file. Will use FALCON_CREDENTIALS_FILE environment variable when left blank. Defaults to `~/.crowdstrike/credentials`.",
+				Optional:            true,
+			},
+			"proxy_url": schema.StringAttribute{
+				MarkdownDescription: "URL of the HTTP or HTTPS proxy used for requests to the CrowdStrike APIs, for example `http://proxy.example.com:8080`. Will use the standard HTTPS_PROXY, HTTP_PROXY, and NO_PROXY environment variables when left blank.",
+				Optional:            true,
+			},
+			"ca_bundle_path": schema.StringAttribute{
+				MarkdownDescription: "Path to a PEM encoded CA bundle trusted in addition to the system certificate pool, for networks that use TLS interception. Will use FALCON_CA_BUNDLE_PATH environment variable when left blank.",
+				Optional:            true,
+			},
+			"tls_insecure_skip_verify": schema.BoolAttribute{
+				MarkdownDescription: "Skip verification of the TLS certificate presented by the CrowdStrike APIs or proxy. This is insecure and should only be used for troubleshooting. Defaults to `false`.",
+				Optional:            true,
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of concurrent requests to the CrowdStrike APIs across all resources and data sources. Lower this value if large applies are hitting API rate limits. Will use FALCON_MAX_CONCURRENT_REQUESTS environment variable when left blank. Defaults to unlimited.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"debug_http": schema.BoolAttribute{
+				MarkdownDescription: "Log the method, path, status, latency, and trace id of each request to the CrowdStrike APIs at debug level, and headers and bodies at trace level. Authorization headers and credentials are redacted. Logs are written when TF_LOG is set to DEBUG or TRACE. Will use FALCON_DEBUG_HTTP environment variable when left blank. Defaults to `false`.",
+				Optional:            true,
+			},
+			"user_agent_suffix": schema.StringAttribute{
+				MarkdownDescription: "Text appended to the User-Agent sent to the CrowdStrike APIs, such as a team name or pipeline id, to identify API usage. Will use FALCON_USER_AGENT_SUFFIX environment variable when left blank.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						userAgentSuffixPattern,
+						"must only contain printable ASCII characters",
+					),
+				},
+			},
+			"max_idle_conns": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of idle keep-alive connections to the CrowdStrike APIs kept open for reuse. Defaults to `100`.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"idle_conn_timeout": schema.StringAttribute{
+				MarkdownDescription: "How long an idle keep-alive connection to the CrowdStrike APIs is kept open, as a duration such as `90s` or `2m`. Defaults to `90s`.",
+				Optional:            true,
+			},
+			"tls_handshake_timeout": schema.StringAttribute{
+				MarkdownDescription: "Maximum time to wait for a TLS handshake with the CrowdStrike APIs or proxy, as a duration such as `10s`. Defaults to `10s`.",
+				Optional:            true,
+			},
+			"enforcement_mode": schema.StringAttribute{
+				MarkdownDescription: "Whether changes are applied to the CrowdStrike APIs. Valid values are enforce and report. In report mode resources are refreshed and plans show drift from the configuration, but updates and deletes are skipped with a warning and deleted resources are only removed from the Terraform state. New resources are still created. Use report mode to adopt Terraform gradually against settings managed in the Falcon console. Will use FALCON_ENFORCEMENT_MODE environment variable when left blank. Defaults to `enforce`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(enforcementModeEnforce, enforcementModeReport),
+				},
+			},
+			"validate_references": schema.BoolAttribute{
+				MarkdownDescription: "Check at plan time that the host groups, custom IOA rule groups, and FileVantage rule groups referenced by each resource exist. Every missing id of a resource is reported at once instead of failing mid-apply. Ids that are unknown until apply, such as those of groups created in the same apply, are not checked. Will use FALCON_VALIDATE_REFERENCES environment variable when left blank. Defaults to `false`.",
+				Optional:            true,
+			},
 			"cloud": schema.StringAttribute{
-				MarkdownDescription: "Falcon Cloud to authenticate to. Valid values are autodiscover, us-1, us-2, eu-1, us-gov-1",
+				MarkdownDescription: "Falcon Cloud to authenticate to. Valid values are autodiscover, us-1, us-2, eu-1, us-gov-1, us-gov-2. When using client credentials the provider verifies the cloud against the home cloud of the API client and falls back to the home cloud with a warning if they do not match. autodiscover also detects GovCloud API clients. Defaults to autodiscover.",
 				Optional:            true,
 				Validators: []validator.String{
 					stringvalidator.OneOfCaseInsensitive(
@@ -79,6 +214,7 @@ func (p *CrowdStrikeProvider) Schema(
 						"us-2",
 						"eu-1",
 						"us-gov-1",
+						"us-gov-2",
 					),
 				},
 			},
@@ -126,6 +262,141 @@ func (p *CrowdStrikeProvider) Configure(
 		)
 	}
 
+	if config.AccessToken.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("access_token"),
+			"Unknown CrowdStrike API Access Token",
+			"The provider cannot create the CrowdStrike API client as there is an unknown configuration value for the CrowdStrike API Access Token. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the FALCON_ACCESS_TOKEN environment variable.",
+		)
+	}
+
+	if config.MemberCID.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("member_cid"),
+			"Unknown CrowdStrike Member CID",
+			"The provider cannot create the CrowdStrike API client as there is an unknown configuration value for member_cid. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the FALCON_MEMBER_CID environment variable.",
+		)
+	}
+
+	if config.Profile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("profile"),
+			"Unknown CrowdStrike Credentials Profile",
+			"The provider cannot create the CrowdStrike API client as there is an unknown configuration value for the credentials profile. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the FALCON_PROFILE environment variable.",
+		)
+	}
+
+	if config.CredentialsFile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("credentials_file"),
+			"Unknown CrowdStrike Credentials File",
+			"The provider cannot create the CrowdStrike API client as there is an unknown configuration value for the credentials file. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the FALCON_CREDENTIALS_FILE environment variable.",
+		)
+	}
+
+	if config.ProxyURL.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("proxy_url"),
+			"Unknown CrowdStrike API Proxy URL",
+			"The provider cannot create the CrowdStrike API client as there is an unknown configuration value for the proxy url. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the HTTPS_PROXY environment variable.",
+		)
+	}
+
+	if config.CABundlePath.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ca_bundle_path"),
+			"Unknown CrowdStrike API CA Bundle Path",
+			"The provider cannot create the CrowdStrike API client as there is an unknown configuration value for the ca bundle path. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the FALCON_CA_BUNDLE_PATH environment variable.",
+		)
+	}
+
+	if config.TLSInsecureSkipVerify.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("tls_insecure_skip_verify"),
+			"Unknown CrowdStrike API TLS Insecure Skip Verify",
+			"The provider cannot create the CrowdStrike API client as there is an unknown configuration value for tls_insecure_skip_verify. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.MaxConcurrentRequests.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_concurrent_requests"),
+			"Unknown CrowdStrike API Max Concurrent Requests",
+			"The provider cannot create the CrowdStrike API client as there is an unknown configuration value for max_concurrent_requests. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the FALCON_MAX_CONCURRENT_REQUESTS environment variable.",
+		)
+	}
+
+	if config.DebugHTTP.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("debug_http"),
+			"Unknown CrowdStrike API Debug HTTP",
+			"The provider cannot create the CrowdStrike API client as there is an unknown configuration value for debug_http. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the FALCON_DEBUG_HTTP environment variable.",
+		)
+	}
+
+	if config.UserAgentSuffix.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("user_agent_suffix"),
+			"Unknown CrowdStrike API User Agent Suffix",
+			"The provider cannot create the CrowdStrike API client as there is an unknown configuration value for user_agent_suffix. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the FALCON_USER_AGENT_SUFFIX environment variable.",
+		)
+	}
+
+	if config.EnforcementMode.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("enforcement_mode"),
+			"Unknown CrowdStrike Enforcement Mode",
+			"The provider cannot be configured as there is an unknown configuration value for enforcement_mode. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the FALCON_ENFORCEMENT_MODE environment variable.",
+		)
+	}
+
+	if config.ValidateReferences.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("validate_references"),
+			"Unknown CrowdStrike Validate References",
+			"The provider cannot be configured as there is an unknown configuration value for validate_references. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the FALCON_VALIDATE_REFERENCES environment variable.",
+		)
+	}
+
+	if config.MaxIdleConns.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_idle_conns"),
+			"Unknown CrowdStrike API Max Idle Connections",
+			"The provider cannot create the CrowdStrike API client as there is an unknown configuration value for max_idle_conns. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.IdleConnTimeout.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("idle_conn_timeout"),
+			"Unknown CrowdStrike API Idle Connection Timeout",
+			"The provider cannot create the CrowdStrike API client as there is an unknown configuration value for idle_conn_timeout. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.TLSHandshakeTimeout.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("tls_handshake_timeout"),
+			"Unknown CrowdStrike API TLS Handshake Timeout",
+			"The provider cannot create the CrowdStrike API client as there is an unknown configuration value for tls_handshake_timeout. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -135,62 +406,263 @@ func (p *CrowdStrikeProvider) Configure(
 	cloud := os.Getenv("FALCON_CLOUD")
 	clientId := os.Getenv("FALCON_CLIENT_ID")
 	clientSecret := os.Getenv("FALCON_CLIENT_SECRET")
+	accessToken := os.Getenv("FALCON_ACCESS_TOKEN")
+	memberCID := os.Getenv("FALCON_MEMBER_CID")
+	profile := os.Getenv("FALCON_PROFILE")
+	credentialsFile := os.Getenv("FALCON_CREDENTIALS_FILE")
+	caBundlePath := os.Getenv("FALCON_CA_BUNDLE_PATH")
+	userAgentSuffix := os.Getenv("FALCON_USER_AGENT_SUFFIX")
 
 	if !config.Cloud.IsNull() {
 		cloud = config.Cloud.ValueString()
 	}
 
-	if cloud == "" {
-		cloud = "autodiscover"
-	}
-
 	if !config.ClientId.IsNull() {
-		clientId = config.ClientSecret.ValueString()
+		clientId = config.ClientId.ValueString()
 	}
 
 	if !config.ClientSecret.IsNull() {
 		clientSecret = config.ClientSecret.ValueString()
 	}
 
-	if clientId == "" {
+	if !config.AccessToken.IsNull() {
+		accessToken = config.AccessToken.ValueString()
+	}
+
+	if !config.MemberCID.IsNull() {
+		memberCID = config.MemberCID.ValueString()
+	}
+
+	if !config.Profile.IsNull() {
+		profile = config.Profile.ValueString()
+	}
+
+	if !config.CredentialsFile.IsNull() {
+		credentialsFile = config.CredentialsFile.ValueString()
+	}
+
+	if !config.CABundlePath.IsNull() {
+		caBundlePath = config.CABundlePath.ValueString()
+	}
+
+	if !config.UserAgentSuffix.IsNull() {
+		userAgentSuffix = config.UserAgentSuffix.ValueString()
+	}
+
+	if userAgentSuffix != "" && !userAgentSuffixPattern.MatchString(userAgentSuffix) {
 		resp.Diagnostics.AddAttributeError(
-			path.Root("client_id"),
-			"Missing CrowdStrike API Client ID",
-			"The provider cannot create the CrowdStrike API client as there is a missing or empty value for the CrowdStrike API Client ID. "+
-				"Set the client_id value in the configuration or use the FALCON_CLIENT_ID environment variable. "+
-				"If either is already set, ensure the value is not empty.",
+			path.Root("user_agent_suffix"),
+			"Invalid CrowdStrike API User Agent Suffix",
+			"The user agent suffix must only contain printable ASCII characters.",
 		)
+		return
 	}
 
-	if clientSecret == "" {
+	userAgent := fmt.Sprintf("terraform-provider-crowdstrike/%s", p.version)
+	if userAgentSuffix != "" {
+		userAgent = fmt.Sprintf("%s %s", userAgent, userAgentSuffix)
+	}
+
+	enforcementMode := os.Getenv("FALCON_ENFORCEMENT_MODE")
+
+	if !config.EnforcementMode.IsNull() {
+		enforcementMode = config.EnforcementMode.ValueString()
+	}
+
+	switch enforcementMode {
+	case "":
+		enforcementMode = enforcementModeEnforce
+	case enforcementModeEnforce, enforcementModeReport:
+	default:
 		resp.Diagnostics.AddAttributeError(
-			path.Root("client_secret"),
-			"Missing CrowdStrike API Client Secret",
-			"The provider cannot create the CrowdStrike API client as there is a missing or empty value for the CrowdStrike API Client Secret. "+
-				"Set the client_secret value in the configuration or use the FALCON_CLIENT_SECRET environment variable. "+
-				"If either is already set, ensure the value is not empty.",
+			path.Root("enforcement_mode"),
+			"Invalid CrowdStrike Enforcement Mode",
+			fmt.Sprintf("The FALCON_ENFORCEMENT_MODE environment variable must be %s or %s, got %q.", enforcementModeEnforce, enforcementModeReport, enforcementMode),
 		)
+		return
+	}
+
+	validateReferences, _ := strconv.ParseBool(os.Getenv("FALCON_VALIDATE_REFERENCES"))
+
+	if !config.ValidateReferences.IsNull() {
+		validateReferences = config.ValidateReferences.ValueBool()
 	}
 
+	debugHTTP, _ := strconv.ParseBool(os.Getenv("FALCON_DEBUG_HTTP"))
+
+	if !config.DebugHTTP.IsNull() {
+		debugHTTP = config.DebugHTTP.ValueBool()
+	}
+
+	var maxConcurrentRequests int64
+
+	if v := os.Getenv("FALCON_MAX_CONCURRENT_REQUESTS"); v != "" {
+		parsed, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || parsed < 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_concurrent_requests"),
+				"Invalid CrowdStrike API Max Concurrent Requests",
+				fmt.Sprintf("The FALCON_MAX_CONCURRENT_REQUESTS environment variable must be a positive integer, got %q.", v),
+			)
+			return
+		}
+		maxConcurrentRequests = parsed
+	}
+
+	if !config.MaxConcurrentRequests.IsNull() {
+		maxConcurrentRequests = config.MaxConcurrentRequests.ValueInt64()
+	}
+
+	idleConnTimeout, diags := parseDuration(path.Root("idle_conn_timeout"), config.IdleConnTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	tlsHandshakeTimeout, diags := parseDuration(path.Root("tls_handshake_timeout"), config.TLSHandshakeTimeout)
+	resp.Diagnostics.Append(diags...)
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// Fall back to the shared credentials file when no credentials
+	// were provided by the configuration or environment.
+	if clientId == "" && clientSecret == "" && accessToken == "" {
+		creds, diags := loadCredentialsProfile(credentialsFile, profile)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		clientId = creds.ClientId
+		clientSecret = creds.ClientSecret
+		accessToken = creds.AccessToken
+
+		if cloud == "" {
+			cloud = creds.Cloud
+		}
+	}
+
+	if cloud == "" {
+		cloud = "autodiscover"
+	}
+
+	if accessToken != "" {
+		resp.Diagnostics.Append(validateAccessTokenConfig(cloud, clientId, clientSecret)...)
+		if memberCID != "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("member_cid"),
+				"Invalid CrowdStrike Member CID",
+				membercid.AccessTokenDetail,
+			)
+		}
+	} else {
+		resp.Diagnostics.Append(validateClientCredentialsConfig(clientId, clientSecret)...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cloud = strings.ToLower(cloud)
+
+	transport := transportConfig{
+		ProxyURL:              config.ProxyURL.ValueString(),
+		CABundlePath:          caBundlePath,
+		TLSInsecureSkipVerify: config.TLSInsecureSkipVerify.ValueBool(),
+		MaxConcurrentRequests: int(maxConcurrentRequests),
+		Debug:                 debugHTTP,
+		MaxIdleConns:          int(config.MaxIdleConns.ValueInt64()),
+		IdleConnTimeout:       idleConnTimeout,
+		TLSHandshakeTimeout:   tlsHandshakeTimeout,
+	}
+
+	if transport.TLSInsecureSkipVerify {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("tls_insecure_skip_verify"),
+			"TLS Certificate Verification Disabled",
+			"The provider will not verify the TLS certificate presented by the CrowdStrike APIs or proxy. "+
+				"This allows credentials to be intercepted and should only be used for troubleshooting.",
+		)
+	}
+
+	cacheKey := clientCacheKey(
+		cloud,
+		clientId,
+		clientSecret,
+		accessToken,
+		memberCID,
+		userAgent,
+		fmt.Sprintf("%+v", transport),
+		p.apiHost,
+	)
+
+	clientCache.Lock()
+	defer clientCache.Unlock()
+
+	if cached, ok := clientCache.entries[cacheKey]; ok {
+		resp.Diagnostics.Append(cached.diags...)
+		resp.DataSourceData = cached.client
+		resp.ResourceData = &resourceData{
+			client:             cached.client,
+			memberClients:      cached.memberClients,
+			enforcementMode:    enforcementMode,
+			validateReferences: validateReferences,
+		}
+		resp.EphemeralResourceData = cached.ephemeralData
+
+		tflog.Debug(ctx, "Reusing CrowdStrike client", map[string]any{
+			"token_requests": tokenRequests.Load(),
+		})
+		return
+	}
+
+	httpClient, err := newHTTPClient(transport)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid CrowdStrike API Transport Configuration",
+			"The provider cannot create the CrowdStrike API client as the proxy or tls configuration is invalid: "+err.Error(),
+		)
+		return
+	}
+
+	// Verify the credentials belong to the configured cloud so a mismatched
+	// region surfaces here instead of as a 403 in a resource call.
+	var cloudDiags diag.Diagnostics
+	if accessToken == "" {
+		cloud, cloudDiags = resolveCloud(ctx, httpClient, p.baseURL, cloud, clientId, clientSecret)
+		resp.Diagnostics.Append(cloudDiags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	ctx = tflog.SetField(ctx, "crowdstrike_cloud", cloud)
 	ctx = tflog.SetField(ctx, "crowdstrike_client_id", clientId)
 	ctx = tflog.SetField(ctx, "crowdstrike_client_secret", clientSecret)
+	ctx = tflog.SetField(ctx, "crowdstrike_access_token", accessToken)
 	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "crowdstrike_client_id")
 	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "crowdstrike_client_secret")
+	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "crowdstrike_access_token")
 
 	tflog.Debug(ctx, "Creating CrowdStrike client")
 
-	client, err := falcon.NewClient(&falcon.ApiConfig{
+	apiConfig := falcon.ApiConfig{
 		Cloud:             falcon.Cloud(cloud),
-		ClientId:          clientId,
-		ClientSecret:      clientSecret,
-		UserAgentOverride: fmt.Sprintf("terraform-provider-crowdstrike/%s", p.version),
-		Context:           context.Background(),
-	})
+		HostOverride:      p.host(cloud),
+		UserAgentOverride: userAgent,
+		// The context outlives this request as it is used to refresh tokens,
+		// so only its values (such as the logger) are kept.
+		Context: context.WithValue(context.WithoutCancel(ctx), oauth2.HTTPClient, httpClient),
+	}
+
+	if accessToken != "" {
+		apiConfig.AccessToken = accessToken
+	} else {
+		apiConfig.ClientId = clientId
+		apiConfig.ClientSecret = clientSecret
+		apiConfig.MemberCID = memberCID
+	}
+
+	client, err := falcon.NewClient(&apiConfig)
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -199,34 +671,215 @@ func (p *CrowdStrikeProvider) Configure(
 				"If the error is not clear, please open a issue here: https://github.com/CrowdStrike/terraform-provider-crowdstrike\n\n"+
 				"CrowdStrike Client Error: "+err.Error(),
 		)
+		return
+	}
+
+	ephemeralData := &ephemeralResourceData{
+		client:       client,
+		httpClient:   httpClient,
+		cloud:        cloud,
+		baseURL:      p.baseURL(cloud),
+		clientId:     clientId,
+		clientSecret: clientSecret,
+		memberCID:    memberCID,
+	}
+
+	memberClients := membercid.NewClients(apiConfig)
+
+	clientCache.entries[cacheKey] = clientCacheEntry{
+		client:        client,
+		ephemeralData: ephemeralData,
+		memberClients: memberClients,
+		diags:         cloudDiags,
 	}
 
 	resp.DataSourceData = client
-	resp.ResourceData = client
+	resp.ResourceData = &resourceData{
+		client:             client,
+		memberClients:      memberClients,
+		enforcementMode:    enforcementMode,
+		validateReferences: validateReferences,
+	}
+	resp.EphemeralResourceData = ephemeralData
 
 	tflog.Info(ctx, "Configured CrowdStrike client", map[string]any{"success": true})
 }
 
+// parseDuration parses an optional duration attribute, returning 0 when it is not set.
+func parseDuration(p path.Path, value types.String) (time.Duration, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if value.IsNull() || value.ValueString() == "" {
+		return 0, diags
+	}
+
+	d, err := time.ParseDuration(value.ValueString())
+	if err != nil || d <= 0 {
+		diags.AddAttributeError(
+			p,
+			"Invalid Duration",
+			fmt.Sprintf("%q is not a valid duration, use a positive value such as \"30s\" or \"2m\".", value.ValueString()),
+		)
+	}
+
+	return d, diags
+}
+
+// loadCredentialsProfile reads a profile from the shared credentials file.
+// A missing file is only an error when a profile or file was explicitly requested.
+func loadCredentialsProfile(credentialsFile, profile string) (credentials.Profile, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	explicit := credentialsFile != "" || profile != ""
+
+	if profile == "" {
+		profile = credentials.DefaultProfile
+	}
+
+	if credentialsFile == "" {
+		defaultPath, err := credentials.DefaultFilePath()
+		if err != nil {
+			if explicit {
+				diags.AddAttributeError(
+					path.Root("credentials_file"),
+					"Unable to Locate CrowdStrike Credentials File",
+					"The provider could not determine the default credentials file location: "+err.Error(),
+				)
+			}
+			return credentials.Profile{}, diags
+		}
+		credentialsFile = defaultPath
+	}
+
+	creds, err := credentials.LoadProfile(credentialsFile, profile)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) && !explicit {
+			return credentials.Profile{}, diags
+		}
+
+		diags.AddAttributeError(
+			path.Root("profile"),
+			"Unable to Read CrowdStrike Credentials Profile",
+			fmt.Sprintf(
+				"The provider could not read profile %q from credentials file %s: %s",
+				profile,
+				credentialsFile,
+				err.Error(),
+			),
+		)
+	}
+
+	return creds, diags
+}
+
+// validateClientCredentialsConfig validates the configuration when authenticating
+// with a client id and client secret.
+func validateClientCredentialsConfig(clientId, clientSecret string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if clientId == "" {
+		diags.AddAttributeError(
+			path.Root("client_id"),
+			"Missing CrowdStrike API Client ID",
+			"The provider cannot create the CrowdStrike API client as there is a missing or empty value for the CrowdStrike API Client ID. "+
+				"Set the client_id value in the configuration, use the FALCON_CLIENT_ID environment variable, or add it to a credentials file profile. "+
+				"If either is already set, ensure the value is not empty.",
+		)
+	}
+
+	if clientSecret == "" {
+		diags.AddAttributeError(
+			path.Root("client_secret"),
+			"Missing CrowdStrike API Client Secret",
+			"The provider cannot create the CrowdStrike API client as there is a missing or empty value for the CrowdStrike API Client Secret. "+
+				"Set the client_secret value in the configuration, use the FALCON_CLIENT_SECRET environment variable, or add it to a credentials file profile. "+
+				"If either is already set, ensure the value is not empty.",
+		)
+	}
+
+	return diags
+}
+
+// validateAccessTokenConfig validates the configuration when authenticating
+// with a pre-issued access token.
+func validateAccessTokenConfig(cloud, clientId, clientSecret string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if clientId != "" || clientSecret != "" {
+		diags.AddAttributeError(
+			path.Root("access_token"),
+			"Conflicting CrowdStrike API Credentials",
+			"The provider cannot create the CrowdStrike API client as both an access token and a client id or client secret were provided. "+
+				"Set either access_token (FALCON_ACCESS_TOKEN) or client_id and client_secret (FALCON_CLIENT_ID and FALCON_CLIENT_SECRET), but not both.",
+		)
+	}
+
+	if strings.EqualFold(cloud, "autodiscover") {
+		diags.AddAttributeError(
+			path.Root("cloud"),
+			"Invalid CrowdStrike API Cloud",
+			"The provider cannot autodiscover the CrowdStrike API cloud when authenticating with an access token. "+
+				"Set the cloud value in the configuration or use the FALCON_CLOUD environment variable.",
+		)
+	}
+
+	return diags
+}
+
 func (p *CrowdStrikeProvider) Resources(ctx context.Context) []func() resource.Resource {
-	return []func() resource.Resource{
+	resources := []func() resource.Resource{
 		NewSensorUpdatePolicyResource,
 		NewHostGroupResource,
+		NewHostNetworkContainmentResource,
+		NewSnapshotScanJobResource,
 		preventionpolicy.NewPreventionPolicyWindowsResource,
 		preventionpolicy.NewPreventionPolicyLinuxResource,
 		preventionpolicy.NewPreventionPolicyMacResource,
 		fim.NewFIMPolicyResource,
 		fim.NewFilevantageRuleGroupResource,
+		sensorvisibilityexclusion.NewSensorVisibilityExclusionsResource,
+		policyattachment.NewPreventionPolicyAttachmentResource,
+		policyattachment.NewSensorUpdatePolicyAttachmentResource,
+		policyattachment.NewResponsePolicyAttachmentResource,
+		policyattachment.NewFirewallPolicyAttachmentResource,
+		policyattachment.NewDeviceControlPolicyAttachmentResource,
+		detectionstatusautomation.NewDetectionStatusAutomationResource,
+		workflow.NewWorkflowScheduleResource,
+		NewPolicyRolloutResource,
+	}
+
+	for i, newResource := range resources {
+		resources[i] = withEnforcementMode(newResource)
 	}
+
+	return resources
 }
 
 func (p *CrowdStrikeProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewSensorUpdateBuildsDataSource,
+		NewResponsePoliciesDataSource,
+		NewFirewallPoliciesDataSource,
+		NewCustomIOARuleGroupsDataSource,
+		NewKubernetesClustersDataSource,
+		NewContainerImagesDataSource,
+		fim.NewFilevantagePoliciesDataSource,
+		fim.NewFilevantageRuleGroupsDataSource,
+	}
+}
+
+func (p *CrowdStrikeProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewOAuthTokenEphemeralResource,
+		NewUninstallTokenEphemeralResource,
 	}
 }
 
 func (p *CrowdStrikeProvider) Functions(ctx context.Context) []func() function.Function {
-	return []func() function.Function{}
+	return []func() function.Function{
+		NewFQLFunction,
+		NewNormalizePlatformFunction,
+		NewParseExclusionCSVFunction,
+	}
 }
 
 func New(version string) func() provider.Provider {
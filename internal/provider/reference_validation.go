@@ -0,0 +1,204 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/client/custom_ioa"
+	"github.com/crowdstrike/gofalcon/falcon/client/filevantage"
+	hostgroups "github.com/crowdstrike/terraform-provider-crowdstrike/internal/host_groups"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/scopes"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/tferrors"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// referenceBatchSize is the maximum number of ids looked up in a single api call.
+const referenceBatchSize = 100
+
+// referenceKind is an object type that resources reference by id in a top level attribute.
+type referenceKind struct {
+	attribute string
+	name      string
+	scopes    []scopes.Scope
+	// find returns the ids of the objects in ids that exist.
+	find func(ctx context.Context, c *client.CrowdStrikeAPISpecification, ids []string) (map[string]bool, error)
+}
+
+var referenceKinds = []referenceKind{
+	{
+		attribute: "host_groups",
+		name:      "host groups",
+		scopes:    apiScopes,
+		find:      hostgroups.ExistingIDs,
+	},
+	{
+		attribute: "ioa_rule_groups",
+		name:      "custom IOA rule groups",
+		scopes:    customIOARuleGroupsScopes,
+		find: func(ctx context.Context, c *client.CrowdStrikeAPISpecification, ids []string) (map[string]bool, error) {
+			return existingIDs(ids, func(batch []string) ([]string, error) {
+				res, err := c.CustomIoa.GetRuleGroupsMixin0(&custom_ioa.GetRuleGroupsMixin0Params{
+					Context: ctx,
+					Ids:     batch,
+				})
+				if err != nil {
+					return nil, err
+				}
+
+				var found []string
+				for _, group := range res.Payload.Resources {
+					if group != nil && group.ID != nil {
+						found = append(found, *group.ID)
+					}
+				}
+				return found, nil
+			})
+		},
+	},
+	{
+		attribute: "rule_groups",
+		name:      "FileVantage rule groups",
+		scopes: []scopes.Scope{
+			{
+				Name:  "Falcon FileVantage",
+				Read:  true,
+				Write: false,
+			},
+		},
+		find: func(ctx context.Context, c *client.CrowdStrikeAPISpecification, ids []string) (map[string]bool, error) {
+			return existingIDs(ids, func(batch []string) ([]string, error) {
+				res, err := c.Filevantage.GetRuleGroups(&filevantage.GetRuleGroupsParams{
+					Context: ctx,
+					Ids:     batch,
+				})
+				if err != nil {
+					return nil, err
+				}
+
+				var found []string
+				for _, group := range res.Payload.Resources {
+					if group != nil && group.ID != nil {
+						found = append(found, *group.ID)
+					}
+				}
+				return found, nil
+			})
+		},
+	},
+}
+
+// validatePlanReferences returns an error for each reference attribute of the plan that
+// contains ids of objects that do not exist. Unknown ids are skipped.
+func validatePlanReferences(
+	ctx context.Context,
+	c *client.CrowdStrikeAPISpecification,
+	plan tftypes.Value,
+) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for _, kind := range referenceKinds {
+		ids := knownStringElements(plan, kind.attribute)
+		if len(ids) == 0 {
+			continue
+		}
+
+		found, err := kind.find(ctx, c, ids)
+		if tferrors.IsForbidden(err) {
+			// the lookup only guards against mistakes, so missing scopes do not block the plan.
+			diags.AddWarning(
+				fmt.Sprintf("Unable to verify %s", kind.name),
+				fmt.Sprintf(
+					"The %s referenced by %s could not be verified: %s",
+					kind.name,
+					kind.attribute,
+					tferrors.Message(err, kind.scopes...),
+				),
+			)
+			continue
+		}
+
+		if err != nil {
+			diags.AddError(
+				fmt.Sprintf("Error verifying %s", kind.name),
+				fmt.Sprintf(
+					"Could not verify the %s referenced by %s: %s",
+					kind.name,
+					kind.attribute,
+					tferrors.Message(err, kind.scopes...),
+				),
+			)
+			continue
+		}
+
+		var missing []string
+		for _, id := range ids {
+			if !found[id] {
+				missing = append(missing, id)
+			}
+		}
+
+		if len(missing) > 0 {
+			diags.AddAttributeError(
+				path.Root(kind.attribute),
+				fmt.Sprintf("Missing %s", kind.name),
+				fmt.Sprintf(
+					"The following %s do not exist: %s. Remove them from %s or create them before applying.",
+					kind.name,
+					strings.Join(missing, ", "),
+					kind.attribute,
+				),
+			)
+		}
+	}
+
+	return diags
+}
+
+// existingIDs returns the ids that exist, looked up in batches with get.
+func existingIDs(ids []string, get func(batch []string) ([]string, error)) (map[string]bool, error) {
+	existing, err := utils.GetInBatches(ids, referenceBatchSize, get)
+
+	found := make(map[string]bool, len(existing))
+	for _, id := range existing {
+		found[id] = true
+	}
+
+	return found, err
+}
+
+// knownStringElements returns the sorted, known string elements of a top level set or list
+// attribute. Nothing is returned when the attribute does not exist or is null or unknown.
+func knownStringElements(value tftypes.Value, attribute string) []string {
+	v, _, err := tftypes.WalkAttributePath(value, tftypes.NewAttributePath().WithAttributeName(attribute))
+	if err != nil {
+		return nil
+	}
+
+	attr, ok := v.(tftypes.Value)
+	if !ok || attr.IsNull() || !attr.IsKnown() {
+		return nil
+	}
+
+	var elements []tftypes.Value
+	if err := attr.As(&elements); err != nil {
+		return nil
+	}
+
+	var ids []string
+	for _, element := range elements {
+		var id string
+		if !element.IsKnown() || element.IsNull() || element.As(&id) != nil {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return ids
+}
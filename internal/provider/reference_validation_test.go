@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestValidatePlanReferences_mock(t *testing.T) {
+	m := newMockFalcon(t)
+
+	// like the real api, the request fails when any of the ids does not exist.
+	m.handle("GET /devices/entities/host-groups/v1", func(w http.ResponseWriter, r *http.Request) {
+		resources := []any{}
+		for _, id := range r.URL.Query()["ids"] {
+			if id != "hg-1" {
+				writeAPIError(w, http.StatusNotFound, "host group not found")
+				return
+			}
+			resources = append(resources, map[string]any{"id": id})
+		}
+
+		writeJSON(w, http.StatusOK, map[string]any{"resources": resources})
+	})
+
+	setType := tftypes.Set{ElementType: tftypes.String}
+	objectType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"name":        tftypes.String,
+			"host_groups": setType,
+		},
+	}
+
+	plan := tftypes.NewValue(objectType, map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, "test"),
+		"host_groups": tftypes.NewValue(setType, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, "hg-1"),
+			tftypes.NewValue(tftypes.String, "hg-2"),
+			tftypes.NewValue(tftypes.String, "hg-3"),
+			tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		}),
+	})
+
+	diags := validatePlanReferences(context.Background(), m.client(t), plan)
+
+	if diags.ErrorsCount() != 1 {
+		t.Fatalf("expected 1 error, got %d: %v", diags.ErrorsCount(), diags)
+	}
+
+	detail := diags.Errors()[0].Detail()
+	if !strings.Contains(detail, "hg-2, hg-3") || strings.Contains(detail, "hg-1") {
+		t.Errorf("expected hg-2 and hg-3 to be reported as missing, got: %s", detail)
+	}
+}
@@ -0,0 +1,266 @@
+package provider
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// plannedReplacements returns the paths of the attributes whose plan modifiers require the
+// resource to be replaced. The framework only merges these into the plan sent to Terraform
+// after the resource ModifyPlan has run, so a wrapper has to run the modifiers itself to
+// know about them. Attributes nested in sets are not checked.
+func plannedReplacements(
+	ctx context.Context,
+	s schema.Schema,
+	config tfsdk.Config,
+	plan tfsdk.Plan,
+	state tfsdk.State,
+) path.Paths {
+	w := &replacementWalker{ctx: ctx, config: config, plan: plan, state: state}
+	w.walk(path.Empty(), s.Attributes, s.Blocks)
+
+	return w.paths
+}
+
+// replacementWalker runs the plan modifiers of every attribute and block of a schema.
+type replacementWalker struct {
+	ctx    context.Context
+	config tfsdk.Config
+	plan   tfsdk.Plan
+	state  tfsdk.State
+	paths  path.Paths
+}
+
+func (w *replacementWalker) walk(parent path.Path, attributes map[string]schema.Attribute, blocks map[string]schema.Block) {
+	for _, name := range slices.Sorted(maps.Keys(attributes)) {
+		p := parent.AtName(name)
+		w.check(p, attributes[name])
+
+		switch a := attributes[name].(type) {
+		case schema.SingleNestedAttribute:
+			w.walk(p, a.Attributes, nil)
+		case schema.ListNestedAttribute:
+			for i := range w.listLength(p) {
+				w.walk(p.AtListIndex(i), a.NestedObject.Attributes, nil)
+			}
+		case schema.MapNestedAttribute:
+			for _, key := range w.mapKeys(p) {
+				w.walk(p.AtMapKey(key), a.NestedObject.Attributes, nil)
+			}
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(blocks)) {
+		p := parent.AtName(name)
+		w.check(p, blocks[name])
+
+		switch b := blocks[name].(type) {
+		case schema.SingleNestedBlock:
+			w.walk(p, b.Attributes, b.Blocks)
+		case schema.ListNestedBlock:
+			for i := range w.listLength(p) {
+				w.walk(p.AtListIndex(i), b.NestedObject.Attributes, b.NestedObject.Blocks)
+			}
+		}
+	}
+}
+
+// check runs the plan modifiers of the attribute or block at p and records p when one of
+// them requires replacement.
+func (w *replacementWalker) check(p path.Path, element any) {
+	config, plan, state, ok := w.values(p)
+	if !ok {
+		return
+	}
+
+	var replace bool
+
+	switch e := element.(type) {
+	case interface{ StringPlanModifiers() []planmodifier.String }:
+		for _, m := range e.StringPlanModifiers() {
+			req := planmodifier.StringRequest{
+				Path: p, PathExpression: p.Expression(), Config: w.config, Plan: w.plan, State: w.state,
+				ConfigValue: asString(w.ctx, config), PlanValue: asString(w.ctx, plan), StateValue: asString(w.ctx, state),
+			}
+			resp := &planmodifier.StringResponse{PlanValue: req.PlanValue}
+			m.PlanModifyString(w.ctx, req, resp)
+			replace = replace || resp.RequiresReplace
+		}
+	case interface{ BoolPlanModifiers() []planmodifier.Bool }:
+		for _, m := range e.BoolPlanModifiers() {
+			req := planmodifier.BoolRequest{
+				Path: p, PathExpression: p.Expression(), Config: w.config, Plan: w.plan, State: w.state,
+				ConfigValue: asBool(w.ctx, config), PlanValue: asBool(w.ctx, plan), StateValue: asBool(w.ctx, state),
+			}
+			resp := &planmodifier.BoolResponse{PlanValue: req.PlanValue}
+			m.PlanModifyBool(w.ctx, req, resp)
+			replace = replace || resp.RequiresReplace
+		}
+	case interface{ Int64PlanModifiers() []planmodifier.Int64 }:
+		for _, m := range e.Int64PlanModifiers() {
+			req := planmodifier.Int64Request{
+				Path: p, PathExpression: p.Expression(), Config: w.config, Plan: w.plan, State: w.state,
+				ConfigValue: asInt64(w.ctx, config), PlanValue: asInt64(w.ctx, plan), StateValue: asInt64(w.ctx, state),
+			}
+			resp := &planmodifier.Int64Response{PlanValue: req.PlanValue}
+			m.PlanModifyInt64(w.ctx, req, resp)
+			replace = replace || resp.RequiresReplace
+		}
+	case interface{ ListPlanModifiers() []planmodifier.List }:
+		for _, m := range e.ListPlanModifiers() {
+			req := planmodifier.ListRequest{
+				Path: p, PathExpression: p.Expression(), Config: w.config, Plan: w.plan, State: w.state,
+				ConfigValue: asList(w.ctx, config), PlanValue: asList(w.ctx, plan), StateValue: asList(w.ctx, state),
+			}
+			resp := &planmodifier.ListResponse{PlanValue: req.PlanValue}
+			m.PlanModifyList(w.ctx, req, resp)
+			replace = replace || resp.RequiresReplace
+		}
+	case interface{ SetPlanModifiers() []planmodifier.Set }:
+		for _, m := range e.SetPlanModifiers() {
+			req := planmodifier.SetRequest{
+				Path: p, PathExpression: p.Expression(), Config: w.config, Plan: w.plan, State: w.state,
+				ConfigValue: asSet(w.ctx, config), PlanValue: asSet(w.ctx, plan), StateValue: asSet(w.ctx, state),
+			}
+			resp := &planmodifier.SetResponse{PlanValue: req.PlanValue}
+			m.PlanModifySet(w.ctx, req, resp)
+			replace = replace || resp.RequiresReplace
+		}
+	case interface{ MapPlanModifiers() []planmodifier.Map }:
+		for _, m := range e.MapPlanModifiers() {
+			req := planmodifier.MapRequest{
+				Path: p, PathExpression: p.Expression(), Config: w.config, Plan: w.plan, State: w.state,
+				ConfigValue: asMap(w.ctx, config), PlanValue: asMap(w.ctx, plan), StateValue: asMap(w.ctx, state),
+			}
+			resp := &planmodifier.MapResponse{PlanValue: req.PlanValue}
+			m.PlanModifyMap(w.ctx, req, resp)
+			replace = replace || resp.RequiresReplace
+		}
+	case interface{ ObjectPlanModifiers() []planmodifier.Object }:
+		for _, m := range e.ObjectPlanModifiers() {
+			req := planmodifier.ObjectRequest{
+				Path: p, PathExpression: p.Expression(), Config: w.config, Plan: w.plan, State: w.state,
+				ConfigValue: asObject(w.ctx, config), PlanValue: asObject(w.ctx, plan), StateValue: asObject(w.ctx, state),
+			}
+			resp := &planmodifier.ObjectResponse{PlanValue: req.PlanValue}
+			m.PlanModifyObject(w.ctx, req, resp)
+			replace = replace || resp.RequiresReplace
+		}
+	}
+
+	if replace {
+		w.paths = append(w.paths, p)
+	}
+}
+
+// values returns the config, plan and state values at p. A value missing from the config
+// or state, such as an element added to a list, is null.
+func (w *replacementWalker) values(p path.Path) (config, plan, state attr.Value, ok bool) {
+	if diags := w.plan.GetAttribute(w.ctx, p, &plan); diags.HasError() || plan == nil {
+		return nil, nil, nil, false
+	}
+
+	null := func() attr.Value {
+		t := plan.Type(w.ctx)
+		v, _ := t.ValueFromTerraform(w.ctx, tftypes.NewValue(t.TerraformType(w.ctx), nil))
+		return v
+	}
+
+	if diags := w.config.GetAttribute(w.ctx, p, &config); diags.HasError() || config == nil {
+		config = null()
+	}
+
+	if w.state.Raw.IsNull() {
+		state = null()
+	} else if diags := w.state.GetAttribute(w.ctx, p, &state); diags.HasError() || state == nil {
+		state = null()
+	}
+
+	return config, plan, state, true
+}
+
+// listLength returns the number of elements of the planned list at p.
+func (w *replacementWalker) listLength(p path.Path) int {
+	var v attr.Value
+	if diags := w.plan.GetAttribute(w.ctx, p, &v); diags.HasError() {
+		return 0
+	}
+
+	return len(asList(w.ctx, v).Elements())
+}
+
+// mapKeys returns the keys of the planned map at p.
+func (w *replacementWalker) mapKeys(p path.Path) []string {
+	var v attr.Value
+	if diags := w.plan.GetAttribute(w.ctx, p, &v); diags.HasError() {
+		return nil
+	}
+
+	return slices.Sorted(maps.Keys(asMap(w.ctx, v).Elements()))
+}
+
+func asString(ctx context.Context, v attr.Value) types.String {
+	if s, ok := v.(basetypes.StringValuable); ok {
+		value, _ := s.ToStringValue(ctx)
+		return value
+	}
+	return types.StringNull()
+}
+
+func asBool(ctx context.Context, v attr.Value) types.Bool {
+	if b, ok := v.(basetypes.BoolValuable); ok {
+		value, _ := b.ToBoolValue(ctx)
+		return value
+	}
+	return types.BoolNull()
+}
+
+func asInt64(ctx context.Context, v attr.Value) types.Int64 {
+	if i, ok := v.(basetypes.Int64Valuable); ok {
+		value, _ := i.ToInt64Value(ctx)
+		return value
+	}
+	return types.Int64Null()
+}
+
+func asList(ctx context.Context, v attr.Value) types.List {
+	if l, ok := v.(basetypes.ListValuable); ok {
+		value, _ := l.ToListValue(ctx)
+		return value
+	}
+	return types.ListNull(types.StringType)
+}
+
+func asSet(ctx context.Context, v attr.Value) types.Set {
+	if s, ok := v.(basetypes.SetValuable); ok {
+		value, _ := s.ToSetValue(ctx)
+		return value
+	}
+	return types.SetNull(types.StringType)
+}
+
+func asMap(ctx context.Context, v attr.Value) types.Map {
+	if m, ok := v.(basetypes.MapValuable); ok {
+		value, _ := m.ToMapValue(ctx)
+		return value
+	}
+	return types.MapNull(types.StringType)
+}
+
+func asObject(ctx context.Context, v attr.Value) types.Object {
+	if o, ok := v.(basetypes.ObjectValuable); ok {
+		value, _ := o.ToObjectValue(ctx)
+		return value
+	}
+	return types.ObjectNull(map[string]attr.Type{})
+}
@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/client/response_policies"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/scopes"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/tferrors"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &responsePoliciesDataSource{}
+	_ datasource.DataSourceWithConfigure = &responsePoliciesDataSource{}
+)
+
+var responsePoliciesScopes = []scopes.Scope{
+	{
+		Name:  "Response policies",
+		Read:  true,
+		Write: false,
+	},
+}
+
+// NewResponsePoliciesDataSource is a helper function to simplify the provider implementation.
+func NewResponsePoliciesDataSource() datasource.DataSource {
+	return &responsePoliciesDataSource{}
+}
+
+// responsePoliciesDataSource is the data source implementation.
+type responsePoliciesDataSource struct {
+	client *client.CrowdStrikeAPISpecification
+}
+
+// Metadata returns the data source type name.
+func (d *responsePoliciesDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_response_policies"
+}
+
+// Schema defines the schema for the data source.
+func (d *responsePoliciesDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: fmt.Sprintf(
+			"Response Policy --- This data source returns the real time response policies matching an FQL filter.\n\n%s",
+			scopes.GenerateScopeDescription(responsePoliciesScopes),
+		),
+		Attributes: policiesDataSourceAttributes("real time response"),
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *responsePoliciesDataSource) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var state policiesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Policies = []policyDataModel{}
+	limit := policiesQueryLimit
+	var offset int64
+
+	for {
+		params := &response_policies.QueryCombinedRTResponsePoliciesParams{
+			Context: ctx,
+			Limit:   &limit,
+			Offset:  &offset,
+		}
+
+		if state.Filter.ValueString() != "" {
+			params.Filter = state.Filter.ValueStringPointer()
+		}
+
+		res, err := d.client.ResponsePolicies.QueryCombinedRTResponsePolicies(params)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to read real time response policies",
+				tferrors.Message(err, responsePoliciesScopes...),
+			)
+			return
+		}
+
+		for _, p := range res.Payload.Resources {
+			policy, diags := newPolicyDataModel(
+				ctx,
+				p.ID,
+				p.Name,
+				p.Description,
+				p.PlatformName,
+				p.Enabled,
+				p.Groups,
+			)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			state.Policies = append(state.Policies, policy)
+		}
+
+		var more bool
+		offset, more = nextPageOffset(offset, len(res.Payload.Resources), res.Payload.Meta)
+		if !more {
+			break
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *responsePoliciesDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.CrowdStrikeAPISpecification)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf(
+				"Expected *client.CrowdStrikeAPISpecification, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+		return
+	}
+
+	d.client = client
+}
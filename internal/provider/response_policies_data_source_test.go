@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccResponsePoliciesDataSource(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+data "crowdstrike_response_policies" "all" {}
+
+data "crowdstrike_response_policies" "windows" {
+  filter = "platform_name:'Windows'"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(
+						"data.crowdstrike_response_policies.all",
+						"policies.0.id",
+					),
+					resource.TestCheckResourceAttr(
+						"data.crowdstrike_response_policies.windows",
+						"policies.0.platform_name",
+						"Windows",
+					),
+				),
+			},
+		},
+	})
+}
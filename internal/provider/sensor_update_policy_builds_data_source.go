@@ -8,6 +8,7 @@ import (
 	"github.com/crowdstrike/gofalcon/falcon/client"
 	"github.com/crowdstrike/gofalcon/falcon/client/sensor_update_policies"
 	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/scopes"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/tferrors"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -74,6 +75,7 @@ type sensorUpdatePolicyBuildsDataSourceModel struct {
 	Windows    platformBuilds `tfsdk:"windows"`
 	Linux      platformBuilds `tfsdk:"linux"`
 	LinuxArm64 platformBuilds `tfsdk:"linux_arm64"`
+	ZLinux     platformBuilds `tfsdk:"zlinux"`
 	Mac        platformBuilds `tfsdk:"mac"`
 }
 
@@ -107,6 +109,14 @@ func (d *sensorUpdatePolicyBuildsDataSource) Metadata(
 	resp.TypeName = req.ProviderTypeName + "_sensor_update_policy_builds"
 }
 
+var sensorUpdateBuildsScopes = []scopes.Scope{
+	{
+		Name:  "Sensor update policies",
+		Read:  true,
+		Write: false,
+	},
+}
+
 // Schema defines the schema for the data source.
 func (d *sensorUpdatePolicyBuildsDataSource) Schema(
 	_ context.Context,
@@ -116,15 +126,7 @@ func (d *sensorUpdatePolicyBuildsDataSource) Schema(
 	resp.Schema = schema.Schema{
 		MarkdownDescription: fmt.Sprintf(
 			"Sensor Update Policy --- This data source provides information about the latest sensor builds for each platform.\n\n%s",
-			scopes.GenerateScopeDescription(
-				[]scopes.Scope{
-					{
-						Name:  "Sensor update policies",
-						Read:  true,
-						Write: false,
-					},
-				},
-			),
+			scopes.GenerateScopeDescription(sensorUpdateBuildsScopes),
 		),
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -146,6 +148,11 @@ func (d *sensorUpdatePolicyBuildsDataSource) Schema(
 				Description: "Builds for the Linux platform (arm64).",
 				Attributes:  platformSchema,
 			},
+			"zlinux": schema.SingleNestedAttribute{
+				Computed:    true,
+				Description: "Builds for the Linux platform (zLinux).",
+				Attributes:  platformSchema,
+			},
 			"mac": schema.SingleNestedAttribute{
 				Computed:    true,
 				Description: "Builds for the Mac platform.",
@@ -170,17 +177,19 @@ func (d *sensorUpdatePolicyBuildsDataSource) Read(
 	)
 
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to read sensor update policy builds", err.Error())
+		resp.Diagnostics.AddError("Unable to read sensor update policy builds", tferrors.Message(err, sensorUpdateBuildsScopes...))
 		return
 	}
 
 	var windowsPlatformBuilds platformBuilds
 	var linuxPlatformBuilds platformBuilds
 	var linuxArm64PlatformBuilds platformBuilds
+	var zLinuxPlatformBuilds platformBuilds
 	var macPlatformBuilds platformBuilds
 	var windowsBuilds []sensorBuild
 	var linuxBuilds []sensorBuild
 	var linuxArm64Builds []sensorBuild
+	var zLinuxBuilds []sensorBuild
 	var macBuilds []sensorBuild
 
 	for _, b := range builds.Payload.Resources {
@@ -203,6 +212,9 @@ func (d *sensorUpdatePolicyBuildsDataSource) Read(
 		case "linux":
 			mapBuild(&linuxPlatformBuilds, build)
 			linuxBuilds = append(linuxBuilds, build)
+		case "zlinux":
+			mapBuild(&zLinuxPlatformBuilds, build)
+			zLinuxBuilds = append(zLinuxBuilds, build)
 		default:
 			mapBuild(&linuxArm64PlatformBuilds, build)
 			linuxArm64Builds = append(linuxArm64Builds, build)
@@ -212,12 +224,14 @@ func (d *sensorUpdatePolicyBuildsDataSource) Read(
 	windowsPlatformBuilds.All = windowsBuilds
 	linuxPlatformBuilds.All = linuxBuilds
 	linuxArm64PlatformBuilds.All = linuxArm64Builds
+	zLinuxPlatformBuilds.All = zLinuxBuilds
 	macPlatformBuilds.All = macBuilds
 
 	state.ID = types.StringValue("all")
 	state.Windows = windowsPlatformBuilds
 	state.Linux = linuxPlatformBuilds
 	state.LinuxArm64 = linuxArm64PlatformBuilds
+	state.ZLinux = zLinuxPlatformBuilds
 	state.Mac = macPlatformBuilds
 
 	diags := resp.State.Set(ctx, &state)
@@ -10,10 +10,15 @@ import (
 	"github.com/crowdstrike/gofalcon/falcon/client/sensor_update_policies"
 	"github.com/crowdstrike/gofalcon/falcon/models"
 	hostgroups "github.com/crowdstrike/terraform-provider-crowdstrike/internal/host_groups"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/identity"
+	membercid "github.com/crowdstrike/terraform-provider-crowdstrike/internal/member_cid"
 	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/scopes"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/tferrors"
 	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -24,7 +29,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"golang.org/x/exp/maps"
+	"golang.org/x/sync/errgroup"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -32,7 +39,9 @@ var (
 	_ resource.Resource                   = &sensorUpdatePolicyResource{}
 	_ resource.ResourceWithConfigure      = &sensorUpdatePolicyResource{}
 	_ resource.ResourceWithImportState    = &sensorUpdatePolicyResource{}
+	_ resource.ResourceWithIdentity       = &sensorUpdatePolicyResource{}
 	_ resource.ResourceWithValidateConfig = &sensorUpdatePolicyResource{}
+	_ membercid.Resource                  = &sensorUpdatePolicyResource{}
 )
 
 // int64ToDay maps numbers used by api to a string representation of the day.
@@ -131,11 +140,12 @@ var timezones = []string{
 	"Europe/Paris",
 	"Europe/London",
 	"Europe/Lisbon",
-	"Antartica/Troll",
+	"Antarctica/Troll",
 	"MET",
 }
 
 var linuxArm64Varient = "LinuxArm64"
+var zLinuxVarient = "zLinux"
 
 // NewSensorUpdatePolicyResource is a helper function to simplify the provider implementation.
 func NewSensorUpdatePolicyResource() resource.Resource {
@@ -144,22 +154,29 @@ func NewSensorUpdatePolicyResource() resource.Resource {
 
 // sensorUpdatePolicyResource is the resource implementation.
 type sensorUpdatePolicyResource struct {
-	client *client.CrowdStrikeAPISpecification
+	client        *client.CrowdStrikeAPISpecification
+	memberClients *membercid.Clients
 }
 
 // sensorUpdatePolicyResourceModel is the resource model.
 type sensorUpdatePolicyResourceModel struct {
-	ID                  types.String   `tfsdk:"id"`
-	Enabled             types.Bool     `tfsdk:"enabled"`
-	Name                types.String   `tfsdk:"name"`
-	Build               types.String   `tfsdk:"build"`
-	BuildArm64          types.String   `tfsdk:"build_arm64"`
-	Description         types.String   `tfsdk:"description"`
-	PlatformName        types.String   `tfsdk:"platform_name"`
-	UninstallProtection types.Bool     `tfsdk:"uninstall_protection"`
-	LastUpdated         types.String   `tfsdk:"last_updated"`
-	HostGroups          types.Set      `tfsdk:"host_groups"`
-	Schedule            policySchedule `tfsdk:"schedule"`
+	ID                       types.String   `tfsdk:"id"`
+	MemberCID                types.String   `tfsdk:"member_cid"`
+	Enabled                  types.Bool     `tfsdk:"enabled"`
+	Name                     types.String   `tfsdk:"name"`
+	Build                    types.String   `tfsdk:"build"`
+	BuildArm64               types.String   `tfsdk:"build_arm64"`
+	BuildZLinux              types.String   `tfsdk:"build_zlinux"`
+	Description              types.String   `tfsdk:"description"`
+	PlatformName             types.String   `tfsdk:"platform_name"`
+	UninstallProtection      types.Bool     `tfsdk:"uninstall_protection"`
+	LastUpdated              types.String   `tfsdk:"last_updated"`
+	DeletionProtection       types.Bool     `tfsdk:"deletion_protection"`
+	AssignedHostCount        types.Int64    `tfsdk:"assigned_host_count"`
+	AssignedHostCountByGroup types.Map      `tfsdk:"assigned_host_count_by_group"`
+	HostGroups               types.Set      `tfsdk:"host_groups"`
+	Schedule                 policySchedule `tfsdk:"schedule"`
+	Timeouts                 timeouts.Value `tfsdk:"timeouts"`
 }
 
 // policySchedule the schedule for a sensor update policy.
@@ -203,6 +220,24 @@ func (r *sensorUpdatePolicyResource) Configure(
 	r.client = client
 }
 
+// SetMemberClients adds the clients used when member_cid is set.
+func (r *sensorUpdatePolicyResource) SetMemberClients(m *membercid.Clients) {
+	r.memberClients = m
+}
+
+// withMemberCID returns a copy of the resource that uses the client of memberCID.
+func (r *sensorUpdatePolicyResource) withMemberCID(memberCID types.String) (*sensorUpdatePolicyResource, diag.Diagnostics) {
+	c, diags := membercid.ClientFor(r.client, r.memberClients, memberCID)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	scoped := *r
+	scoped.client = c
+
+	return &scoped, diags
+}
+
 // Metadata returns the resource type name.
 func (r *sensorUpdatePolicyResource) Metadata(
 	_ context.Context,
@@ -212,24 +247,24 @@ func (r *sensorUpdatePolicyResource) Metadata(
 	resp.TypeName = req.ProviderTypeName + "_sensor_update_policy"
 }
 
+var sensorUpdatePolicyScopes = []scopes.Scope{
+	{
+		Name:  "Sensor update policies",
+		Read:  true,
+		Write: true,
+	},
+}
+
 // Schema defines the schema for the resource.
 func (r *sensorUpdatePolicyResource) Schema(
-	_ context.Context,
+	ctx context.Context,
 	_ resource.SchemaRequest,
 	resp *resource.SchemaResponse,
 ) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: fmt.Sprintf(
 			"Sensor Update Policy --- This resource allows management of sensor update policies in the CrowdStrike Falcon platform. Sensor update policies allow you to control the update process across a set of hosts.\n\n%s",
-			scopes.GenerateScopeDescription(
-				[]scopes.Scope{
-					{
-						Name:  "Sensor update policies",
-						Read:  true,
-						Write: true,
-					},
-				},
-			),
+			scopes.GenerateScopeDescription(sensorUpdatePolicyScopes),
 		),
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -239,10 +274,21 @@ func (r *sensorUpdatePolicyResource) Schema(
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"member_cid": membercid.Attribute("sensor update policy"),
 			"last_updated": schema.StringAttribute{
 				Computed:    true,
 				Description: "Timestamp of the last Terraform update of the resource.",
 			},
+			"deletion_protection": utils.DeletionProtectionAttribute("sensor update policy"),
+			"assigned_host_count": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The number of hosts assigned to the sensor update policy.",
+			},
+			"assigned_host_count_by_group": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.Int64Type,
+				Description: "The number of hosts assigned to the sensor update policy through each of its host groups, keyed by host group id.",
+			},
 			"name": schema.StringAttribute{
 				Required:    true,
 				Description: "Name of the sensor update policy.",
@@ -255,6 +301,10 @@ func (r *sensorUpdatePolicyResource) Schema(
 				Optional:    true,
 				Description: "Sensor arm64 build to use for the sensor update policy (Linux only). Required if platform_name is Linux.",
 			},
+			"build_zlinux": schema.StringAttribute{
+				Optional:    true,
+				Description: "Sensor zLinux (s390x) build to use for the sensor update policy (Linux only).",
+			},
 			// todo: make this case insensitive
 			"platform_name": schema.StringAttribute{
 				Required:    true,
@@ -276,7 +326,7 @@ func (r *sensorUpdatePolicyResource) Schema(
 			"uninstall_protection": schema.BoolAttribute{
 				Optional:    true,
 				Computed:    true,
-				Description: "Enable uninstall protection. Windows and Mac only.",
+				Description: "Enable uninstall protection. Windows and Mac only. Use the crowdstrike_sensor_update_policy_uninstall_token ephemeral resource to retrieve uninstall tokens.",
 				Default:     booldefault.StaticBool(false),
 			},
 			"host_groups": schema.SetAttribute{
@@ -295,7 +345,7 @@ func (r *sensorUpdatePolicyResource) Schema(
 					"timezone": schema.StringAttribute{
 						Optional:    true,
 						Computed:    true,
-						Description: "The time zones that will be used for the time blocks. Only set when enabled is true.",
+						Description: "The IANA time zone that will be used for the time blocks. Only set when enabled is true.",
 						Default:     stringdefault.StaticString("Etc/UTC"),
 						Validators: []validator.String{
 							stringvalidator.OneOf(timezones...),
@@ -303,7 +353,7 @@ func (r *sensorUpdatePolicyResource) Schema(
 					},
 					"time_blocks": schema.SetNestedAttribute{
 						Optional:    true,
-						Description: "The time block to prevent sensor updates. Only set when enabled is true.",
+						Description: "The time block to prevent sensor updates. Only set when enabled is true. Time blocks on the same day can't overlap.",
 						NestedObject: schema.NestedAttributeObject{
 							Attributes: map[string]schema.Attribute{
 								"days": schema.SetAttribute{
@@ -332,6 +382,14 @@ func (r *sensorUpdatePolicyResource) Schema(
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -349,6 +407,21 @@ func (r *sensorUpdatePolicyResource) Create(
 		return
 	}
 
+	r, diags = r.withMemberCID(plan.MemberCID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	policyParams := sensor_update_policies.CreateSensorUpdatePoliciesV2Params{
 		Context: ctx,
 		Body: &models.SensorUpdateCreatePoliciesReqV2{
@@ -366,13 +439,7 @@ func (r *sensorUpdatePolicyResource) Create(
 	}
 
 	if strings.ToLower(plan.PlatformName.ValueString()) == "linux" {
-		variants := []*models.SensorUpdateBuildReqV1{
-			{
-				Build:    plan.BuildArm64.ValueStringPointer(),
-				Platform: &linuxArm64Varient,
-			},
-		}
-		policyParams.Body.Resources[0].Settings.Variants = variants
+		policyParams.Body.Resources[0].Settings.Variants = linuxVariants(plan)
 	}
 
 	var uninstallProtection string
@@ -398,13 +465,18 @@ func (r *sensorUpdatePolicyResource) Create(
 	}
 	policyParams.Body.Resources[0].Settings.Scheduler = &updateSchedular
 
-	policy, err := r.client.SensorUpdatePolicies.CreateSensorUpdatePoliciesV2(&policyParams)
+	var policy *sensor_update_policies.CreateSensorUpdatePoliciesV2Created
+	err := utils.RetryOnConflict(ctx, func() error {
+		var err error
+		policy, err = r.client.SensorUpdatePolicies.CreateSensorUpdatePoliciesV2(&policyParams)
+		return err
+	})
 
 	// todo: if we should handle scope and timeout errors instead of giving a vague error
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating sensor update policy",
-			"Could not create sensor update policy, unexpected error: "+err.Error(),
+			"Could not create sensor update policy, unexpected error: "+tferrors.Message(err, sensorUpdatePolicyScopes...),
 		)
 		return
 	}
@@ -427,7 +499,7 @@ func (r *sensorUpdatePolicyResource) Create(
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error enabling sensor update policy",
-				"Could not enable sensor update policy, unexpected error: "+err.Error(),
+				"Could not enable sensor update policy, unexpected error: "+tferrors.Message(err, sensorUpdatePolicyScopes...),
 			)
 			return
 		}
@@ -447,17 +519,29 @@ func (r *sensorUpdatePolicyResource) Create(
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error assinging host group to policy",
-				"Could not assign host group to policy, unexpected error: "+err.Error(),
+				"Could not assign host group to policy, unexpected error: "+tferrors.Message(err, sensorUpdatePolicyScopes...),
 			)
 			return
 		}
 	}
 
+	plan.AssignedHostCount, diags = r.getAssignedHostCount(ctx, plan.ID.ValueString())
+	resp.Diagnostics.Append(diags...)
+
+	plan.AssignedHostCountByGroup, diags = r.getAssignedHostCountByGroup(
+		ctx,
+		plan.ID.ValueString(),
+		plan.HostGroups,
+	)
+	resp.Diagnostics.Append(diags...)
+
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, plan.ID)...)
 }
 
 // Read refreshes the Terraform state with the latest data.
@@ -473,17 +557,56 @@ func (r *sensorUpdatePolicyResource) Read(
 		return
 	}
 
-	policy, err := r.client.SensorUpdatePolicies.GetSensorUpdatePoliciesV2(
-		&sensor_update_policies.GetSensorUpdatePoliciesV2Params{
-			Context: ctx,
-			Ids:     []string{state.ID.ValueString()},
-		},
-	)
+	r, diags = r.withMemberCID(state.MemberCID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	var policy *sensor_update_policies.GetSensorUpdatePoliciesV2OK
+	var assignedHostCount types.Int64
+	var countDiags diag.Diagnostics
+
+	// The policy and its host count are independent so are fetched concurrently.
+	var g errgroup.Group
+	g.Go(func() (err error) {
+		policy, err = r.client.SensorUpdatePolicies.GetSensorUpdatePoliciesV2(
+			&sensor_update_policies.GetSensorUpdatePoliciesV2Params{
+				Context: ctx,
+				Ids:     []string{state.ID.ValueString()},
+			},
+		)
+		return err
+	})
+	g.Go(func() error {
+		assignedHostCount, countDiags = r.getAssignedHostCount(ctx, state.ID.ValueString())
+		return nil
+	})
+	err := g.Wait()
+
+	if tferrors.IsNotFound(err) || (err == nil && len(policy.Payload.Resources) == 0) {
+		tflog.Warn(
+			ctx,
+			"Sensor update policy not found, removing from state",
+			map[string]interface{}{"id": state.ID.ValueString()},
+		)
+		resp.State.RemoveResource(ctx)
+		return
+	}
 
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading CrowdStrike sensor update policy",
-			"Could not read CrowdStrike sensor update policy: "+state.ID.ValueString()+": "+err.Error(),
+			"Could not read CrowdStrike sensor update policy: "+state.ID.ValueString()+": "+tferrors.Message(err, sensorUpdatePolicyScopes...),
 		)
 		return
 	}
@@ -497,6 +620,7 @@ func (r *sensorUpdatePolicyResource) Read(
 	state.PlatformName = types.StringValue(*policyResource.PlatformName)
 	state.Enabled = types.BoolValue(*policyResource.Enabled)
 
+	state.BuildZLinux = types.StringNull()
 	if strings.ToLower(state.PlatformName.ValueString()) == "linux" &&
 		policyResource.Settings.Variants != nil {
 		for _, v := range policyResource.Settings.Variants {
@@ -505,9 +629,14 @@ func (r *sensorUpdatePolicyResource) Read(
 				continue
 			}
 
-			if strings.EqualFold(*vCopy.Platform, linuxArm64Varient) {
+			if strings.EqualFold(*vCopy.Platform, linuxArm64Varient) && vCopy.Build != nil {
 				state.BuildArm64 = types.StringValue(*vCopy.Build)
 			}
+
+			// a policy without a zLinux build returns the variant with an empty build.
+			if strings.EqualFold(*vCopy.Platform, zLinuxVarient) && vCopy.Build != nil && *vCopy.Build != "" {
+				state.BuildZLinux = types.StringValue(*vCopy.Build)
+			}
 		}
 
 	}
@@ -556,12 +685,29 @@ func (r *sensorUpdatePolicyResource) Read(
 		}
 	}
 
+	state.AssignedHostCount = assignedHostCount
+	resp.Diagnostics.Append(countDiags...)
+
+	state.AssignedHostCountByGroup, diags = r.getAssignedHostCountByGroup(
+		ctx,
+		state.ID.ValueString(),
+		state.HostGroups,
+	)
+	resp.Diagnostics.Append(diags...)
+
+	// deletion_protection is not stored in Falcon, imported resources use the default.
+	if state.DeletionProtection.IsNull() {
+		state.DeletionProtection = types.BoolValue(false)
+	}
+
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, state.ID)...)
 }
 
 // Update updates the resource and sets the updated Terraform state on success.
@@ -577,6 +723,21 @@ func (r *sensorUpdatePolicyResource) Update(
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	r, diags = r.withMemberCID(plan.MemberCID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 	// Retrieve values from state
 	var state sensorUpdatePolicyResourceModel
 	diags = req.State.Get(ctx, &state)
@@ -609,7 +770,7 @@ func (r *sensorUpdatePolicyResource) Update(
 					"Could not add host groups: (%s) to policy with id: %s \n\n %s",
 					strings.Join(hostGroupsToAdd, ", "),
 					plan.ID.ValueString(),
-					err.Error(),
+					tferrors.Message(err, sensorUpdatePolicyScopes...),
 				),
 			)
 			return
@@ -630,7 +791,7 @@ func (r *sensorUpdatePolicyResource) Update(
 					"Could not remove host groups: (%s) from policy with id: %s \n\n %s",
 					strings.Join(hostGroupsToAdd, ", "),
 					plan.ID.ValueString(),
-					err.Error(),
+					tferrors.Message(err, sensorUpdatePolicyScopes...),
 				),
 			)
 			return
@@ -654,13 +815,7 @@ func (r *sensorUpdatePolicyResource) Update(
 	}
 
 	if strings.ToLower(plan.PlatformName.ValueString()) == "linux" {
-		variants := []*models.SensorUpdateBuildReqV1{
-			{
-				Build:    plan.BuildArm64.ValueStringPointer(),
-				Platform: &linuxArm64Varient,
-			},
-		}
-		policyParams.Body.Resources[0].Settings.Variants = variants
+		policyParams.Body.Resources[0].Settings.Variants = linuxVariants(plan)
 	}
 
 	if plan.UninstallProtection.ValueBool() {
@@ -690,7 +845,7 @@ func (r *sensorUpdatePolicyResource) Update(
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating CrowdStrike sensor update policy",
-			"Could not update sensor update policy with ID: "+plan.ID.ValueString()+": "+err.Error(),
+			"Could not update sensor update policy with ID: "+plan.ID.ValueString()+": "+tferrors.Message(err, sensorUpdatePolicyScopes...),
 		)
 		return
 	}
@@ -720,7 +875,7 @@ func (r *sensorUpdatePolicyResource) Update(
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error changing sensor update policy enabled state",
-				"Could not change sensor update policy enabled state, unexpected error: "+err.Error(),
+				"Could not change sensor update policy enabled state, unexpected error: "+tferrors.Message(err, sensorUpdatePolicyScopes...),
 			)
 			return
 		}
@@ -735,11 +890,23 @@ func (r *sensorUpdatePolicyResource) Update(
 		return
 	}
 
+	plan.AssignedHostCount, diags = r.getAssignedHostCount(ctx, plan.ID.ValueString())
+	resp.Diagnostics.Append(diags...)
+
+	plan.AssignedHostCountByGroup, diags = r.getAssignedHostCountByGroup(
+		ctx,
+		plan.ID.ValueString(),
+		plan.HostGroups,
+	)
+	resp.Diagnostics.Append(diags...)
+
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	resp.Diagnostics.Append(identity.Set(ctx, resp.Identity, plan.ID)...)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -755,6 +922,26 @@ func (r *sensorUpdatePolicyResource) Delete(
 		return
 	}
 
+	r, diags = r.withMemberCID(state.MemberCID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.DeletionProtection.ValueBool() {
+		resp.Diagnostics.Append(utils.DeletionProtectionError("sensor update policy", state.ID.ValueString()))
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	// need to make sure the policy is disabled before delete
 	_, err := r.updatePolicyEnabledState(
 		ctx,
@@ -766,7 +953,7 @@ func (r *sensorUpdatePolicyResource) Delete(
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error disabling sensor update policy for delete",
-			"Could not disable sensor update policy, unexpected error: "+err.Error(),
+			"Could not disable sensor update policy, unexpected error: "+tferrors.Message(err, sensorUpdatePolicyScopes...),
 		)
 		return
 	}
@@ -781,24 +968,87 @@ func (r *sensorUpdatePolicyResource) Delete(
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error deleting CrowdStrike sensor update policy",
-			"Could not delete sensor update policy, unexpected error: "+err.Error(),
+			"Could not delete sensor update policy, unexpected error: "+tferrors.Message(err, sensorUpdatePolicyScopes...),
 		)
 		return
 	}
 }
 
+// IdentitySchema defines the identity schema for the resource.
+func (r *sensorUpdatePolicyResource) IdentitySchema(
+	_ context.Context,
+	_ resource.IdentitySchemaRequest,
+	resp *resource.IdentitySchemaResponse,
+) {
+	resp.IdentitySchema = identity.Schema()
+}
+
 // ImportState implements the logic to support resource imports.
 func (r *sensorUpdatePolicyResource) ImportState(
 	ctx context.Context,
 	req resource.ImportStateRequest,
 	resp *resource.ImportStateResponse,
 ) {
-	// Retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	// sensor update policies of a child CID are imported as <member_cid>/<id> or <member_cid>/name:<name>.
+	if memberCID, id, ok := membercid.ImportID(req.ID); ok {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("member_cid"), memberCID)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		scoped, diags := r.withMemberCID(types.StringValue(memberCID))
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		r = scoped
+		req.ID = id
+	}
+
+	if name, ok := utils.NameFromImportID(req.ID); ok {
+		id, diags := r.policyIDByName(ctx, name)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	} else {
+		// Retrieve import ID or identity and save to id attribute
+		resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
+	}
+
 	resp.Diagnostics.Append(
 		resp.State.SetAttribute(ctx, path.Root("schedule").AtName("enabled"), false)...)
 }
 
+// policyIDByName looks up the id of the sensor update policy with the given name.
+func (r *sensorUpdatePolicyResource) policyIDByName(
+	ctx context.Context,
+	name string,
+) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	filter := "name:" + utils.QuoteFQL(name)
+	res, err := r.client.SensorUpdatePolicies.QuerySensorUpdatePolicies(
+		&sensor_update_policies.QuerySensorUpdatePoliciesParams{
+			Context: ctx,
+			Filter:  &filter,
+		},
+	)
+
+	if err != nil {
+		diags.AddError(
+			"Error importing CrowdStrike sensor update policy",
+			"Could not look up sensor update policy by name: "+tferrors.Message(err, sensorUpdatePolicyScopes...),
+		)
+		return "", diags
+	}
+
+	return utils.IDFromNameLookup("sensor update policy", name, res.Payload.Resources)
+}
+
 // ValidateConfig runs during validate, plan, and apply
 // validate resource is configured as expected.
 func (r *sensorUpdatePolicyResource) ValidateConfig(
@@ -825,6 +1075,16 @@ func (r *sensorUpdatePolicyResource) ValidateConfig(
 		return
 	}
 
+	if platform != "linux" && !config.BuildZLinux.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("build_zlinux"),
+			"Invalid attribute build_zlinux",
+			"Attribute build_zlinux is only supported when platform_name is linux. Remove the attribute.",
+		)
+
+		return
+	}
+
 	if config.UninstallProtection.ValueBool() && platform == "linux" {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("uninstall_protection"),
@@ -875,9 +1135,13 @@ func (r *sensorUpdatePolicyResource) ValidateConfig(
 			)
 			return
 		}
-		usedDays := make(map[string]interface{})
+		usedWindows := make(map[string][]timeBlock)
 
 		for _, b := range config.Schedule.TimeBlocks {
+			if b.StartTime.IsUnknown() || b.EndTime.IsUnknown() || b.Days.IsUnknown() {
+				continue
+			}
+
 			ok, err := validTime(b.StartTime.ValueString(), b.EndTime.ValueString())
 
 			if err != nil {
@@ -901,19 +1165,26 @@ func (r *sensorUpdatePolicyResource) ValidateConfig(
 			resp.Diagnostics.Append(b.Days.ElementsAs(ctx, &days, false)...)
 
 			for _, day := range days {
-				_, ok := usedDays[day]
-				if ok {
-					resp.Diagnostics.AddAttributeError(
-						path.Root("schedule"),
-						"Duplicate days in schedule",
-						fmt.Sprintf(
-							"Day %s declared in multiple time_blocks. Multiple time_blocks can't reference the same day.",
-							day,
-						),
-					)
+				day = strings.ToLower(day)
+
+				for _, used := range usedWindows[day] {
+					if timeBlocksOverlap(used, b) {
+						resp.Diagnostics.AddAttributeError(
+							path.Root("schedule"),
+							"Overlapping time_blocks in schedule",
+							fmt.Sprintf(
+								"The time_blocks %s-%s and %s-%s overlap on %s. Time blocks on the same day can't overlap.",
+								used.StartTime.ValueString(),
+								used.EndTime.ValueString(),
+								b.StartTime.ValueString(),
+								b.EndTime.ValueString(),
+								day,
+							),
+						)
+					}
 				}
 
-				usedDays[day] = nil
+				usedWindows[day] = append(usedWindows[day], b)
 			}
 		}
 	}
@@ -935,6 +1206,34 @@ func validTime(startTimeStr string, endTimeStr string) (bool, error) {
 	return duration >= time.Hour, nil
 }
 
+// timeBlocksOverlap returns true if the start and end times of a and b overlap.
+// Both time blocks must already be validated by validTime.
+func timeBlocksOverlap(a, b timeBlock) bool {
+	aStart, _ := time.Parse("15:04", a.StartTime.ValueString())
+	aEnd, _ := time.Parse("15:04", a.EndTime.ValueString())
+	bStart, _ := time.Parse("15:04", b.StartTime.ValueString())
+	bEnd, _ := time.Parse("15:04", b.EndTime.ValueString())
+
+	return aStart.Before(bEnd) && bStart.Before(aEnd)
+}
+
+// linuxVariants returns the per architecture builds of a linux sensor update policy.
+func linuxVariants(plan sensorUpdatePolicyResourceModel) []*models.SensorUpdateBuildReqV1 {
+	// the zLinux variant is always sent, an empty build clears a build removed from the config.
+	zLinuxBuild := plan.BuildZLinux.ValueString()
+
+	return []*models.SensorUpdateBuildReqV1{
+		{
+			Build:    plan.BuildArm64.ValueStringPointer(),
+			Platform: &linuxArm64Varient,
+		},
+		{
+			Build:    &zLinuxBuild,
+			Platform: &zLinuxVarient,
+		},
+	}
+}
+
 // updatePolicyEnabledState enables or disables a sensor update policy.
 func (r *sensorUpdatePolicyResource) updatePolicyEnabledState(
 	ctx context.Context,
@@ -1045,3 +1344,108 @@ func (r *sensorUpdatePolicyResource) assignHostGroups(
 
 	return diags
 }
+
+// getAssignedHostCount returns the number of hosts assigned to the sensor update policy.
+// The count is informational, so a failure to read it is a warning and the count is null
+// rather than an error that would leave an applied change out of the state.
+func (r *sensorUpdatePolicyResource) getAssignedHostCount(
+	ctx context.Context,
+	id string,
+) (types.Int64, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	total, err := r.queryPolicyMemberTotal(ctx, id, nil)
+	if err != nil {
+		diags.AddWarning(
+			"Unable to read CrowdStrike sensor update policy members",
+			fmt.Sprintf(
+				"Could not read members of sensor update policy (%s), assigned_host_count will be empty: %s",
+				id,
+				tferrors.Message(err, sensorUpdatePolicyScopes...),
+			),
+		)
+		return types.Int64Null(), diags
+	}
+
+	return types.Int64Value(total), diags
+}
+
+// getAssignedHostCountByGroup returns the number of hosts assigned to the sensor update
+// policy through each of the host groups, keyed by host group id. Like
+// getAssignedHostCount, a failure to read the counts is a warning and the counts are null.
+func (r *sensorUpdatePolicyResource) getAssignedHostCountByGroup(
+	ctx context.Context,
+	id string,
+	hostGroups types.Set,
+) (types.Map, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	counts := map[string]attr.Value{}
+
+	if hostGroups.IsNull() || hostGroups.IsUnknown() {
+		return types.MapValueMust(types.Int64Type, counts), diags
+	}
+
+	var groupIDs []string
+	diags.Append(hostGroups.ElementsAs(ctx, &groupIDs, false)...)
+	if diags.HasError() {
+		return types.MapNull(types.Int64Type), diags
+	}
+
+	totals := make([]int64, len(groupIDs))
+
+	var g errgroup.Group
+	for i, groupID := range groupIDs {
+		g.Go(func() (err error) {
+			filter := "groups:" + utils.QuoteFQL(groupID)
+			totals[i], err = r.queryPolicyMemberTotal(ctx, id, &filter)
+			return err
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		diags.AddWarning(
+			"Unable to read CrowdStrike sensor update policy members",
+			fmt.Sprintf(
+				"Could not read members of sensor update policy (%s) by host group, assigned_host_count_by_group will be empty: %s",
+				id,
+				tferrors.Message(err, sensorUpdatePolicyScopes...),
+			),
+		)
+		return types.MapNull(types.Int64Type), diags
+	}
+
+	for i, groupID := range groupIDs {
+		counts[groupID] = types.Int64Value(totals[i])
+	}
+
+	return types.MapValueMust(types.Int64Type, counts), diags
+}
+
+// queryPolicyMemberTotal returns the number of hosts assigned to the sensor update policy
+// that match filter.
+func (r *sensorUpdatePolicyResource) queryPolicyMemberTotal(
+	ctx context.Context,
+	id string,
+	filter *string,
+) (int64, error) {
+	limit := int64(1)
+
+	res, err := r.client.SensorUpdatePolicies.QuerySensorUpdatePolicyMembers(
+		&sensor_update_policies.QuerySensorUpdatePolicyMembersParams{
+			Context: ctx,
+			ID:      &id,
+			Filter:  filter,
+			Limit:   &limit,
+		},
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	if res.Payload.Meta == nil || res.Payload.Meta.Pagination == nil ||
+		res.Payload.Meta.Pagination.Total == nil {
+		return 0, nil
+	}
+
+	return *res.Payload.Meta.Pagination.Total, nil
+}
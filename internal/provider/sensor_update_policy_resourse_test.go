@@ -1,10 +1,15 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"os"
+	"regexp"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
@@ -75,6 +80,15 @@ resource "crowdstrike_sensor_update_policy" "test" {
 						"crowdstrike_sensor_update_policy.test",
 						"last_updated",
 					),
+					resource.TestCheckResourceAttrSet(
+						"crowdstrike_sensor_update_policy.test",
+						"assigned_host_count",
+					),
+					resource.TestCheckResourceAttr(
+						"crowdstrike_sensor_update_policy.test",
+						"assigned_host_count_by_group.%",
+						"0",
+					),
 				),
 			},
 			// ImportState testing
@@ -490,3 +504,115 @@ resource "crowdstrike_sensor_update_policy" "test" {
 		},
 	})
 }
+
+func TestAccSensorUpdatePolicyResourceOverlappingSchedule(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-acceptance-test")
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "crowdstrike_sensor_update_policy" "test" {
+  name                 = "%s"
+  enabled              = true
+  description          = "made with terraform"
+  platform_name        = "Windows"
+  build                = "18110"
+  uninstall_protection = false
+  schedule = {
+    enabled  = true
+    timezone = "Etc/UTC"
+    time_blocks = [
+      {
+        days       = ["sunday", "wednesday"]
+        start_time = "12:00"
+        end_time   = "16:00"
+      },
+      {
+        days       = ["wednesday"]
+        start_time = "15:00"
+        end_time   = "18:00"
+      }
+    ]
+  }
+}
+`, rName),
+				ExpectError: regexp.MustCompile("Overlapping time_blocks in schedule"),
+			},
+		},
+	})
+}
+
+func TestLinuxVariants(t *testing.T) {
+	plan := sensorUpdatePolicyResourceModel{
+		BuildArm64:  types.StringValue("18601"),
+		BuildZLinux: types.StringNull(),
+	}
+
+	variants := linuxVariants(plan)
+	if len(variants) != 2 {
+		t.Fatalf("expected arm64 and zLinux variants, got %d", len(variants))
+	}
+
+	zLinux := variants[1]
+	if *zLinux.Platform != zLinuxVarient || zLinux.Build == nil || *zLinux.Build != "" {
+		t.Errorf("expected an empty zLinux build to clear the variant, got %+v", zLinux)
+	}
+
+	plan.BuildZLinux = types.StringValue("18602")
+	if build := *linuxVariants(plan)[1].Build; build != "18602" {
+		t.Errorf("expected zLinux build 18602, got %q", build)
+	}
+}
+
+func TestSensorUpdatePolicyAssignedHostCount(t *testing.T) {
+	m := newMockFalcon(t)
+	fail := false
+	m.handle("GET /policy/queries/sensor-update-members/v1", func(w http.ResponseWriter, r *http.Request) {
+		if fail {
+			writeAPIError(w, http.StatusForbidden, "access denied")
+			return
+		}
+
+		total := map[string]int{"": 5, "groups:'group-a'": 3, "groups:'group-b'": 2}[r.URL.Query().Get("filter")]
+		writeJSON(w, http.StatusOK, map[string]any{
+			"meta":      map[string]any{"pagination": map[string]any{"total": total}},
+			"resources": []string{},
+		})
+	})
+
+	ctx := context.Background()
+	r := &sensorUpdatePolicyResource{client: m.client(t)}
+	hostGroups := types.SetValueMust(types.StringType, []attr.Value{
+		types.StringValue("group-a"),
+		types.StringValue("group-b"),
+	})
+
+	count, diags := r.getAssignedHostCount(ctx, "policy-id")
+	if diags.HasError() || count.ValueInt64() != 5 {
+		t.Fatalf("expected 5 assigned hosts, got %s %v", count, diags)
+	}
+
+	byGroup, diags := r.getAssignedHostCountByGroup(ctx, "policy-id", hostGroups)
+	want := types.MapValueMust(types.Int64Type, map[string]attr.Value{
+		"group-a": types.Int64Value(3),
+		"group-b": types.Int64Value(2),
+	})
+	if diags.HasError() || !byGroup.Equal(want) {
+		t.Fatalf("expected %s, got %s %v", want, byGroup, diags)
+	}
+
+	// a failure to read the counts must not fail the apply.
+	fail = true
+
+	count, diags = r.getAssignedHostCount(ctx, "policy-id")
+	if diags.HasError() || diags.WarningsCount() != 1 || !count.IsNull() {
+		t.Errorf("expected a warning and a null count, got %s %v", count, diags)
+	}
+
+	byGroup, diags = r.getAssignedHostCountByGroup(ctx, "policy-id", hostGroups)
+	if diags.HasError() || diags.WarningsCount() != 1 || !byGroup.IsNull() {
+		t.Errorf("expected a warning and null counts, got %s %v", byGroup, diags)
+	}
+}
@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestTimezonesAreIANA(t *testing.T) {
+	for _, tz := range timezones {
+		if _, err := time.LoadLocation(tz); err != nil {
+			t.Errorf("timezone %q is not a valid IANA time zone: %s", tz, err)
+		}
+	}
+}
+
+func TestTimeBlocksOverlap(t *testing.T) {
+	block := func(start, end string) timeBlock {
+		return timeBlock{
+			StartTime: types.StringValue(start),
+			EndTime:   types.StringValue(end),
+		}
+	}
+
+	tests := []struct {
+		name     string
+		a, b     timeBlock
+		expected bool
+	}{
+		{name: "separate", a: block("01:00", "03:00"), b: block("12:00", "14:00"), expected: false},
+		{name: "adjacent", a: block("01:00", "03:00"), b: block("03:00", "05:00"), expected: false},
+		{name: "partial", a: block("01:00", "03:00"), b: block("02:00", "05:00"), expected: true},
+		{name: "contained", a: block("01:00", "08:00"), b: block("02:00", "05:00"), expected: true},
+		{name: "single digit hour", a: block("9:00", "11:00"), b: block("10:00", "12:00"), expected: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := timeBlocksOverlap(tc.a, tc.b); got != tc.expected {
+				t.Errorf("timeBlocksOverlap() = %t; want %t", got, tc.expected)
+			}
+			if got := timeBlocksOverlap(tc.b, tc.a); got != tc.expected {
+				t.Errorf("timeBlocksOverlap() reversed = %t; want %t", got, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,564 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/client/cloud_snapshots"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/scopes"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/tferrors"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &snapshotScanJobResource{}
+	_ resource.ResourceWithConfigure = &snapshotScanJobResource{}
+)
+
+var snapshotScanJobScopes = []scopes.Scope{
+	{
+		Name:  "Snapshot",
+		Read:  true,
+		Write: true,
+	},
+}
+
+// snapshotScanPendingStatuses are the scan statuses that are not yet final.
+var snapshotScanPendingStatuses = []string{
+	"pending",
+	"queued",
+	"created",
+	"deploying",
+	"in_progress",
+	"running",
+	"scanning",
+}
+
+// snapshotScanPollInterval is how often scan statuses are read while waiting for completion.
+var snapshotScanPollInterval = 30 * time.Second
+
+// NewSnapshotScanJobResource is a helper function to simplify the provider implementation.
+func NewSnapshotScanJobResource() resource.Resource {
+	return &snapshotScanJobResource{}
+}
+
+// snapshotScanJobResource is the resource implementation.
+type snapshotScanJobResource struct {
+	client *client.CrowdStrikeAPISpecification
+}
+
+// snapshotScanJobResourceModel is the resource model.
+type snapshotScanJobResourceModel struct {
+	ID                types.String   `tfsdk:"id"`
+	CloudProvider     types.String   `tfsdk:"cloud_provider"`
+	AccountID         types.String   `tfsdk:"account_id"`
+	Region            types.String   `tfsdk:"region"`
+	InstanceIDs       types.Set      `tfsdk:"instance_ids"`
+	WaitForCompletion types.Bool     `tfsdk:"wait_for_completion"`
+	JobIDs            types.Map      `tfsdk:"job_ids"`
+	Statuses          types.Map      `tfsdk:"statuses"`
+	LastUpdated       types.String   `tfsdk:"last_updated"`
+	Timeouts          timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *snapshotScanJobResource) Configure(
+	ctx context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.CrowdStrikeAPISpecification)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf(
+				"Expected *client.CrowdStrikeAPISpecification, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Metadata returns the resource type name.
+func (r *snapshotScanJobResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_snapshot_scan_job"
+}
+
+// Schema defines the schema for the resource.
+func (r *snapshotScanJobResource) Schema(
+	ctx context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: fmt.Sprintf(
+			"Cloud Security --- This resource starts a one-off agentless snapshot scan of cloud instances and tracks the status of each scan. Changing the instances starts a new scan. Destroying the resource only removes it from state, scans that are running are not stopped.\n\n%s",
+			scopes.GenerateScopeDescription(snapshotScanJobScopes),
+		),
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Identifier for the scan job.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cloud_provider": schema.StringAttribute{
+				Required:    true,
+				Description: "Cloud provider the instances run in, e.g. aws.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"account_id": schema.StringAttribute{
+				Required:    true,
+				Description: "Cloud account the instances belong to. The account must be registered for snapshot scanning.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"region": schema.StringAttribute{
+				Required:    true,
+				Description: "Cloud region the instances run in.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"instance_ids": schema.SetAttribute{
+				Required:    true,
+				ElementType: types.StringType,
+				Description: "Identifiers of the instances to scan.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+			},
+			"wait_for_completion": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Wait for every scan to leave the pending and running states before finishing the apply. The wait is bounded by the create and update timeouts. Defaults to false.",
+			},
+			"job_ids": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "Map of instance id to the id of the scan started for it.",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"statuses": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "Map of instance id to the scan status reported by the api.",
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:    true,
+				Description: "Timestamp of the last Terraform update of the resource.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *snapshotScanJobResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan snapshotScanJobResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	id, err := utils.NewRandomID()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating snapshot scan job",
+			"Could not generate resource id: "+err.Error(),
+		)
+		return
+	}
+	plan.ID = types.StringValue(id)
+
+	var instanceIDs []string
+	resp.Diagnostics.Append(plan.InstanceIDs.ElementsAs(ctx, &instanceIDs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	sort.Strings(instanceIDs)
+
+	scans, diags := r.startScans(ctx, plan, instanceIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	jobIDs := make(map[string]string, len(scans))
+	statuses := make(map[string]string, len(scans))
+	for instanceID, scan := range scans {
+		jobIDs[instanceID] = *scan.ID
+		statuses[instanceID] = snapshotScanStatus(scan)
+	}
+
+	if plan.WaitForCompletion.ValueBool() {
+		statuses, diags = r.waitForScans(ctx, jobIDs)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	plan.JobIDs, diags = types.MapValueFrom(ctx, types.StringType, jobIDs)
+	resp.Diagnostics.Append(diags...)
+	plan.Statuses, diags = types.MapValueFrom(ctx, types.StringType, statuses)
+	resp.Diagnostics.Append(diags...)
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	// the scans were started even if waiting for them failed, so state is always saved.
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *snapshotScanJobResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state snapshotScanJobResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	jobIDs := map[string]string{}
+	resp.Diagnostics.Append(state.JobIDs.ElementsAs(ctx, &jobIDs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	statuses, diags := r.getStatuses(ctx, jobIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(statuses) == 0 {
+		tflog.Warn(
+			ctx,
+			"snapshot scans not found, removing snapshot scan job from state",
+			map[string]interface{}{"id": state.ID.ValueString()},
+		)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.Statuses, diags = types.MapValueFrom(ctx, types.StringType, statuses)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+// Only wait_for_completion and timeouts can change in place, so no scan is started.
+func (r *snapshotScanJobResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var plan snapshotScanJobResourceModel
+	var state snapshotScanJobResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	jobIDs := map[string]string{}
+	resp.Diagnostics.Append(state.JobIDs.ElementsAs(ctx, &jobIDs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var statuses map[string]string
+	if plan.WaitForCompletion.ValueBool() {
+		statuses, diags = r.waitForScans(ctx, jobIDs)
+	} else {
+		statuses, diags = r.getStatuses(ctx, jobIDs)
+	}
+	resp.Diagnostics.Append(diags...)
+
+	plan.JobIDs = state.JobIDs
+	plan.Statuses, diags = types.MapValueFrom(ctx, types.StringType, statuses)
+	resp.Diagnostics.Append(diags...)
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete removes the resource from Terraform state, the api can not stop a scan.
+func (r *snapshotScanJobResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+}
+
+// startScans starts a scan of each instance and returns the scans keyed by instance id.
+func (r *snapshotScanJobResource) startScans(
+	ctx context.Context,
+	plan snapshotScanJobResourceModel,
+	instanceIDs []string,
+) (map[string]*models.ModelsDeployment, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	scans := make(map[string]*models.ModelsDeployment, len(instanceIDs))
+
+	body := &models.ModelsCreateDeploymentInput{}
+	for _, instanceID := range instanceIDs {
+		body.Resources = append(body.Resources, &models.ModelsDeploymentResource{
+			AccountID:       plan.AccountID.ValueStringPointer(),
+			AssetIdentifier: &instanceID,
+			CloudProvider:   plan.CloudProvider.ValueStringPointer(),
+			Region:          plan.Region.ValueStringPointer(),
+		})
+	}
+
+	res, err := r.client.CloudSnapshots.CreateDeploymentEntity(
+		&cloud_snapshots.CreateDeploymentEntityParams{
+			Context: ctx,
+			Body:    body,
+		},
+	)
+	if err != nil {
+		diags.AddError(
+			"Error creating snapshot scan job",
+			fmt.Sprintf(
+				"Could not start snapshot scans (%s): %s",
+				strings.Join(instanceIDs, ", "),
+				tferrors.Message(err, snapshotScanJobScopes...),
+			),
+		)
+		return scans, diags
+	}
+
+	for _, apiErr := range res.Payload.Errors {
+		diags.AddError(
+			"Error creating snapshot scan job",
+			fmt.Sprintf("Could not start snapshot scan: %s", apiErr.String()),
+		)
+	}
+
+	for _, scan := range res.Payload.Resources {
+		if scan != nil && scan.ID != nil && scan.AssetIdentifier != nil {
+			scans[*scan.AssetIdentifier] = scan
+		}
+	}
+
+	for _, instanceID := range instanceIDs {
+		if _, ok := scans[instanceID]; !ok && !diags.HasError() {
+			diags.AddError(
+				"Error creating snapshot scan job",
+				fmt.Sprintf("No snapshot scan was started for instance %s.", instanceID),
+			)
+		}
+	}
+
+	return scans, diags
+}
+
+// waitForScans polls the scan statuses until none are pending or the context is done.
+func (r *snapshotScanJobResource) waitForScans(
+	ctx context.Context,
+	jobIDs map[string]string,
+) (map[string]string, diag.Diagnostics) {
+	for {
+		statuses, diags := r.getStatuses(ctx, jobIDs)
+		if diags.HasError() {
+			return statuses, diags
+		}
+
+		// scans missing from the response may not be readable yet.
+		if len(statuses) == len(jobIDs) && !hasPendingSnapshotScan(statuses) {
+			return statuses, diags
+		}
+
+		tflog.Debug(ctx, "waiting for snapshot scans to complete", map[string]interface{}{
+			"statuses": statuses,
+		})
+
+		select {
+		case <-ctx.Done():
+			diags.AddError(
+				"Error waiting for snapshot scan job",
+				fmt.Sprintf(
+					"Timed out waiting for snapshot scans to complete, increase the timeout or set wait_for_completion to false. Last statuses: %v",
+					statuses,
+				),
+			)
+			return statuses, diags
+		case <-time.After(snapshotScanPollInterval):
+		}
+	}
+}
+
+// getStatuses returns the status of each scan keyed by instance id, scans that do not exist are left out.
+func (r *snapshotScanJobResource) getStatuses(
+	ctx context.Context,
+	jobIDs map[string]string,
+) (map[string]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	statuses := make(map[string]string, len(jobIDs))
+
+	if len(jobIDs) == 0 {
+		return statuses, diags
+	}
+
+	instanceIDs := make(map[string]string, len(jobIDs))
+	ids := make([]string, 0, len(jobIDs))
+	for instanceID, jobID := range jobIDs {
+		instanceIDs[jobID] = instanceID
+		ids = append(ids, jobID)
+	}
+	sort.Strings(ids)
+
+	res, err := r.client.CloudSnapshots.ReadDeploymentsEntities(
+		&cloud_snapshots.ReadDeploymentsEntitiesParams{
+			Context: ctx,
+			Ids:     ids,
+		},
+	)
+
+	if tferrors.IsNotFound(err) {
+		return statuses, diags
+	}
+
+	if err != nil {
+		diags.AddError(
+			"Error reading snapshot scan job",
+			fmt.Sprintf(
+				"Could not read snapshot scans (%s): %s",
+				strings.Join(ids, ", "),
+				tferrors.Message(err, snapshotScanJobScopes...),
+			),
+		)
+		return statuses, diags
+	}
+
+	for _, scan := range res.Payload.Resources {
+		if scan == nil || scan.ID == nil {
+			continue
+		}
+
+		if instanceID, ok := instanceIDs[*scan.ID]; ok {
+			statuses[instanceID] = snapshotScanStatus(scan)
+		}
+	}
+
+	return statuses, diags
+}
+
+// snapshotScanStatus returns the status of a scan, or an empty string when it has none.
+func snapshotScanStatus(scan *models.ModelsDeployment) string {
+	if scan.Status == nil {
+		return ""
+	}
+
+	return *scan.Status
+}
+
+// hasPendingSnapshotScan returns true when any scan has not reached a final status.
+func hasPendingSnapshotScan(statuses map[string]string) bool {
+	for _, status := range statuses {
+		if status == "" {
+			return true
+		}
+
+		for _, pending := range snapshotScanPendingStatuses {
+			if strings.EqualFold(pending, status) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSnapshotScanJobResource(t *testing.T) {
+	instanceID := os.Getenv("SNAPSHOT_SCAN_INSTANCE_ID")
+	accountID := os.Getenv("SNAPSHOT_SCAN_ACCOUNT_ID")
+	region := os.Getenv("SNAPSHOT_SCAN_REGION")
+	if instanceID == "" || accountID == "" || region == "" {
+		t.Skip(
+			"SNAPSHOT_SCAN_INSTANCE_ID, SNAPSHOT_SCAN_ACCOUNT_ID and SNAPSHOT_SCAN_REGION must be set to run snapshot scan job acceptance tests",
+		)
+	}
+
+	resourceName := "crowdstrike_snapshot_scan_job.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "crowdstrike_snapshot_scan_job" "test" {
+  cloud_provider = "aws"
+  account_id     = %[1]q
+  region         = %[2]q
+  instance_ids   = [%[3]q]
+}
+`, accountID, region, instanceID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "instance_ids.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "wait_for_completion", "false"),
+					resource.TestCheckResourceAttrSet(resourceName, fmt.Sprintf("job_ids.%s", instanceID)),
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttrSet(resourceName, "last_updated"),
+				),
+			},
+		},
+	})
+}
+
+func TestSnapshotScanJobResource_waitForCompletion_mock(t *testing.T) {
+	interval := snapshotScanPollInterval
+	snapshotScanPollInterval = 10 * time.Millisecond
+	t.Cleanup(func() { snapshotScanPollInterval = interval })
+
+	m := newMockFalcon(t)
+
+	var mu sync.Mutex
+	reads := 0
+
+	m.handle("GET /snapshots/combined/deployments/v1", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]any{"resources": []any{}})
+	})
+
+	m.handle("POST /snapshots/entities/deployments/v1", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Resources []map[string]any `json:"resources"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeAPIError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		scans := []any{}
+		for _, scan := range body.Resources {
+			scan["id"] = "job-" + scan["asset_identifier"].(string)
+			scan["status"] = "pending"
+			scans = append(scans, scan)
+		}
+
+		writeJSON(w, http.StatusOK, map[string]any{"resources": scans})
+	})
+
+	// scans report running on the first read and completed after that.
+	m.handle("GET /snapshots/entities/deployments/v1", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		reads++
+		status := "completed"
+		if reads == 1 {
+			status = "running"
+		}
+		mu.Unlock()
+
+		scans := []any{}
+		for _, id := range r.URL.Query()["ids"] {
+			scans = append(scans, map[string]any{"id": id, "status": status})
+		}
+
+		writeJSON(w, http.StatusOK, map[string]any{"resources": scans})
+	})
+
+	resourceName := "crowdstrike_snapshot_scan_job.test"
+
+	mockUnitTest(t, m, resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: m.providerConfig() + `
+resource "crowdstrike_snapshot_scan_job" "test" {
+  cloud_provider      = "aws"
+  account_id          = "123456789012"
+  region              = "us-east-1"
+  instance_ids        = ["i-0123456789abcdef0"]
+  wait_for_completion = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						resourceName,
+						"job_ids.i-0123456789abcdef0",
+						"job-i-0123456789abcdef0",
+					),
+					resource.TestCheckResourceAttr(
+						resourceName,
+						"statuses.i-0123456789abcdef0",
+						"completed",
+					),
+				),
+			},
+		},
+	})
+}
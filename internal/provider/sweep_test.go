@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/crowdstrike/gofalcon/falcon/client/host_group"
+	"github.com/crowdstrike/gofalcon/falcon/client/sensor_update_policies"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/sweep"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestMain(m *testing.M) {
+	resource.TestMain(m)
+}
+
+func init() {
+	resource.AddTestSweepers("crowdstrike_sensor_update_policy", &resource.Sweeper{
+		Name: "crowdstrike_sensor_update_policy",
+		F:    sweepSensorUpdatePolicies,
+	})
+
+	resource.AddTestSweepers("crowdstrike_host_group", &resource.Sweeper{
+		Name: "crowdstrike_host_group",
+		// host groups can not be deleted while attached to a policy.
+		Dependencies: []string{"crowdstrike_sensor_update_policy"},
+		F:            sweepHostGroups,
+	})
+}
+
+func sweepSensorUpdatePolicies(_ string) error {
+	ctx := context.Background()
+	c, err := sweep.Client()
+	if err != nil {
+		return err
+	}
+
+	var ids []string
+	limit := sweep.QueryLimit
+	var offset int64
+
+	for {
+		res, err := c.SensorUpdatePolicies.QueryCombinedSensorUpdatePoliciesV2(
+			&sensor_update_policies.QueryCombinedSensorUpdatePoliciesV2Params{
+				Context: ctx,
+				Filter:  &sweep.NameFilter,
+				Limit:   &limit,
+				Offset:  &offset,
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("listing sensor update policies: %w", err)
+		}
+
+		for _, p := range res.Payload.Resources {
+			if sweep.IsSweepable(p.Name) {
+				ids = append(ids, *p.ID)
+			}
+		}
+
+		var more bool
+		offset, more = sweep.NextOffset(offset, len(res.Payload.Resources), res.Payload.Meta)
+		if !more {
+			break
+		}
+	}
+
+	for _, id := range ids {
+		_, err := c.SensorUpdatePolicies.PerformSensorUpdatePoliciesAction(
+			&sensor_update_policies.PerformSensorUpdatePoliciesActionParams{
+				Context:    ctx,
+				ActionName: "disable",
+				Body:       &models.MsaEntityActionRequestV2{Ids: []string{id}},
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("disabling sensor update policy %s: %w", id, err)
+		}
+
+		_, err = c.SensorUpdatePolicies.DeleteSensorUpdatePolicies(
+			&sensor_update_policies.DeleteSensorUpdatePoliciesParams{
+				Context: ctx,
+				Ids:     []string{id},
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("deleting sensor update policy %s: %w", id, err)
+		}
+	}
+
+	return nil
+}
+
+func sweepHostGroups(_ string) error {
+	ctx := context.Background()
+	c, err := sweep.Client()
+	if err != nil {
+		return err
+	}
+
+	var ids []string
+	limit := sweep.QueryLimit
+	var offset int64
+
+	for {
+		res, err := c.HostGroup.QueryCombinedHostGroups(
+			&host_group.QueryCombinedHostGroupsParams{
+				Context: ctx,
+				Filter:  &sweep.NameFilter,
+				Limit:   &limit,
+				Offset:  &offset,
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("listing host groups: %w", err)
+		}
+
+		for _, g := range res.Payload.Resources {
+			if sweep.IsSweepable(g.Name) {
+				ids = append(ids, *g.ID)
+			}
+		}
+
+		var more bool
+		offset, more = sweep.NextOffset(offset, len(res.Payload.Resources), res.Payload.Meta)
+		if !more {
+			break
+		}
+	}
+
+	for _, id := range ids {
+		_, err := c.HostGroup.DeleteHostGroups(&host_group.DeleteHostGroupsParams{
+			Context: ctx,
+			Ids:     []string{id},
+		})
+		if err != nil {
+			return fmt.Errorf("deleting host group %s: %w", id, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,209 @@
+package provider
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// transportConfig holds the network settings used to build the http client
+// shared by the provider and gofalcon.
+type transportConfig struct {
+	ProxyURL              string
+	CABundlePath          string
+	TLSInsecureSkipVerify bool
+	// MaxConcurrentRequests limits the number of in-flight requests, 0 is unlimited.
+	MaxConcurrentRequests int
+	// Debug logs each request and response.
+	Debug bool
+	// MaxIdleConns is the maximum number of idle keep-alive connections, 0 uses the default.
+	MaxIdleConns int
+	// IdleConnTimeout is how long an idle connection is kept open, 0 uses the default.
+	IdleConnTimeout time.Duration
+	// TLSHandshakeTimeout is the maximum time to wait for a TLS handshake, 0 uses the default.
+	TLSHandshakeTimeout time.Duration
+}
+
+// tokenRequests counts the oauth2 tokens requested by the plugin process.
+var tokenRequests atomic.Int64
+
+// newHTTPClient returns an http client configured with the proxy and tls settings.
+// When no proxy url is set the standard HTTPS_PROXY, HTTP_PROXY, and NO_PROXY
+// environment variables are used.
+func newHTTPClient(config transportConfig) (*http.Client, error) {
+	// http.DefaultTransport is a *http.Transport unless another package replaced it, in
+	// which case start from the settings of the standard default transport.
+	transport, ok := http.DefaultTransport.(*http.Transport)
+	if ok {
+		transport = transport.Clone()
+	} else {
+		transport = &http.Transport{
+			DialContext: (&net.Dialer{
+				Timeout:   30 * time.Second,
+				KeepAlive: 30 * time.Second,
+			}).DialContext,
+			ForceAttemptHTTP2:     true,
+			MaxIdleConns:          100,
+			IdleConnTimeout:       90 * time.Second,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ExpectContinueTimeout: 1 * time.Second,
+		}
+	}
+	transport.Proxy = http.ProxyFromEnvironment
+
+	if config.MaxIdleConns > 0 {
+		transport.MaxIdleConns = config.MaxIdleConns
+	}
+
+	// All requests go to a single host, so allow every idle connection to be
+	// reused for it instead of the default of 2 per host.
+	transport.MaxIdleConnsPerHost = transport.MaxIdleConns
+
+	if config.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = config.IdleConnTimeout
+	}
+
+	if config.TLSHandshakeTimeout > 0 {
+		transport.TLSHandshakeTimeout = config.TLSHandshakeTimeout
+	}
+
+	if config.ProxyURL != "" {
+		proxyURL, err := url.Parse(config.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy url: %w", err)
+		}
+
+		if proxyURL.Scheme == "" || proxyURL.Host == "" {
+			return nil, fmt.Errorf(
+				"invalid proxy url %q: must include a scheme and host, for example http://proxy.example.com:8080",
+				config.ProxyURL,
+			)
+		}
+
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// #nosec G402 -- only enabled when explicitly requested by the user.
+		InsecureSkipVerify: config.TLSInsecureSkipVerify,
+	}
+
+	if config.CABundlePath != "" {
+		pool, err := loadCABundle(config.CABundlePath)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	transport.TLSClientConfig = tlsConfig
+
+	var roundTripper http.RoundTripper = &tokenMetricsTransport{next: transport}
+
+	if config.Debug {
+		roundTripper = &loggingTransport{next: roundTripper}
+	}
+
+	if config.MaxConcurrentRequests > 0 {
+		roundTripper = newConcurrencyLimiter(roundTripper, config.MaxConcurrentRequests)
+	}
+
+	roundTripper = newCircuitBreakerTransport(roundTripper)
+
+	return &http.Client{Transport: roundTripper}, nil
+}
+
+// tokenMetricsTransport is a http.RoundTripper that counts and logs oauth2 token requests.
+type tokenMetricsTransport struct {
+	next http.RoundTripper
+}
+
+func (t *tokenMetricsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Path == "/oauth2/token" {
+		tflog.Debug(req.Context(), "Requesting CrowdStrike API OAuth2 token", map[string]any{
+			"token_requests": tokenRequests.Add(1),
+		})
+	}
+
+	return t.next.RoundTrip(req)
+}
+
+// concurrencyLimiter is a http.RoundTripper that limits the number of in-flight requests.
+// A slot is held until the response body is closed so streamed responses count against the limit.
+type concurrencyLimiter struct {
+	next http.RoundTripper
+	sem  chan struct{}
+}
+
+func newConcurrencyLimiter(next http.RoundTripper, limit int) *concurrencyLimiter {
+	return &concurrencyLimiter{
+		next: next,
+		sem:  make(chan struct{}, limit),
+	}
+}
+
+func (l *concurrencyLimiter) RoundTrip(req *http.Request) (*http.Response, error) {
+	select {
+	case l.sem <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+
+	res, err := l.next.RoundTrip(req)
+	if err != nil || res.Body == nil {
+		l.release()
+		return res, err
+	}
+
+	res.Body = &releaseOnClose{ReadCloser: res.Body, release: l.release}
+
+	return res, nil
+}
+
+func (l *concurrencyLimiter) release() {
+	<-l.sem
+}
+
+// releaseOnClose calls release exactly once when the body is closed.
+type releaseOnClose struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (r *releaseOnClose) Close() error {
+	err := r.ReadCloser.Close()
+	r.once.Do(r.release)
+	return err
+}
+
+// loadCABundle returns the system cert pool with the certificates in the PEM file appended.
+func loadCABundle(path string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read ca bundle: %w", err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, errors.New("ca bundle " + path + " does not contain any PEM encoded certificates")
+	}
+
+	return pool, nil
+}
@@ -0,0 +1,139 @@
+package provider
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestConcurrencyLimiter(t *testing.T) {
+	var inFlight, maxInFlight int32
+
+	next := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body: &releaseOnClose{
+				ReadCloser: io.NopCloser(strings.NewReader("ok")),
+				release:    func() { atomic.AddInt32(&inFlight, -1) },
+			},
+		}, nil
+	})
+
+	limiter := newConcurrencyLimiter(next, 2)
+	done := make(chan struct{})
+
+	for i := 0; i < 10; i++ {
+		go func() {
+			defer func() { done <- struct{}{} }()
+
+			req, _ := http.NewRequest(http.MethodGet, "https://api.crowdstrike.com", nil)
+			res, err := limiter.RoundTrip(req)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			res.Body.Close()
+		}()
+	}
+
+	for i := 0; i < 10; i++ {
+		<-done
+	}
+
+	if maxInFlight > 2 {
+		t.Errorf("concurrencyLimiter allowed %d concurrent requests, want at most 2", maxInFlight)
+	}
+
+	if len(limiter.sem) != 0 {
+		t.Errorf("concurrencyLimiter leaked %d slots", len(limiter.sem))
+	}
+}
+
+func TestCircuitBreakerTransport(t *testing.T) {
+	cooldown := circuitBreakerCooldown
+	circuitBreakerCooldown = 50 * time.Millisecond
+	t.Cleanup(func() { circuitBreakerCooldown = cooldown })
+
+	var calls, status atomic.Int32
+	status.Store(http.StatusServiceUnavailable)
+
+	next := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		calls.Add(1)
+		return &http.Response{
+			StatusCode: int(status.Load()),
+			Body:       io.NopCloser(strings.NewReader("{}")),
+		}, nil
+	})
+
+	breaker := newCircuitBreakerTransport(next)
+	get := func(path string) error {
+		req, _ := http.NewRequest(http.MethodGet, "https://api.crowdstrike.com"+path, nil)
+		res, err := breaker.RoundTrip(req)
+		if err == nil {
+			res.Body.Close()
+		}
+		return err
+	}
+
+	for i := 0; i < circuitBreakerThreshold; i++ {
+		if err := get("/devices/entities/host-groups/v1"); err != nil {
+			t.Fatalf("request %d failed before the circuit opened: %s", i+1, err)
+		}
+	}
+
+	err := get("/devices/entities/host-groups/v1")
+	if err == nil || !strings.Contains(err.Error(), "GET /devices/entities/host-groups/v1 is degraded") {
+		t.Fatalf("expected the degraded endpoint to fail fast, got: %v", err)
+	}
+
+	if n := calls.Load(); n != circuitBreakerThreshold {
+		t.Errorf("expected %d requests to be sent, got %d", circuitBreakerThreshold, n)
+	}
+
+	if err := get("/policy/queries/prevention/v1"); err != nil {
+		t.Errorf("expected other endpoints to be unaffected, got: %s", err)
+	}
+
+	time.Sleep(circuitBreakerCooldown)
+	status.Store(http.StatusOK)
+
+	if err := get("/devices/entities/host-groups/v1"); err != nil {
+		t.Fatalf("expected a request after the cooldown to be sent, got: %s", err)
+	}
+
+	if err := get("/devices/entities/host-groups/v1"); err != nil {
+		t.Errorf("expected the circuit to close after a success, got: %s", err)
+	}
+}
+
+func TestNewHTTPClient_replacedDefaultTransport(t *testing.T) {
+	defaultTransport := http.DefaultTransport
+	t.Cleanup(func() { http.DefaultTransport = defaultTransport })
+
+	http.DefaultTransport = roundTripFunc(func(*http.Request) (*http.Response, error) {
+		t.Fatal("expected the replaced default transport not to be used")
+		return nil, nil
+	})
+
+	if _, err := newHTTPClient(transportConfig{}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
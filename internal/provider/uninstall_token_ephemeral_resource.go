@@ -0,0 +1,169 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/crowdstrike/gofalcon/falcon/client/sensor_update_policies"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/scopes"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/tferrors"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ ephemeral.EphemeralResource              = &uninstallTokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &uninstallTokenEphemeralResource{}
+)
+
+// maintenanceTokenDeviceID is the device id the api expects to reveal the bulk maintenance token.
+const maintenanceTokenDeviceID = "MAINTENANCE"
+
+var uninstallTokenScopes = []scopes.Scope{
+	{
+		Name:  "Sensor update policies",
+		Read:  false,
+		Write: true,
+	},
+}
+
+// NewUninstallTokenEphemeralResource is a helper function to simplify the provider implementation.
+func NewUninstallTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &uninstallTokenEphemeralResource{}
+}
+
+// uninstallTokenEphemeralResource is the ephemeral resource implementation.
+type uninstallTokenEphemeralResource struct {
+	data *ephemeralResourceData
+}
+
+// uninstallTokenEphemeralResourceModel maps the ephemeral resource schema data.
+type uninstallTokenEphemeralResourceModel struct {
+	DeviceID       types.String `tfsdk:"device_id"`
+	AuditMessage   types.String `tfsdk:"audit_message"`
+	UninstallToken types.String `tfsdk:"uninstall_token"`
+}
+
+// Configure adds the provider configured data to the ephemeral resource.
+func (r *uninstallTokenEphemeralResource) Configure(
+	ctx context.Context,
+	req ephemeral.ConfigureRequest,
+	resp *ephemeral.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ephemeralResourceData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf(
+				"Expected *provider.ephemeralResourceData, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+
+		return
+	}
+
+	r.data = data
+}
+
+// Metadata returns the ephemeral resource type name.
+func (r *uninstallTokenEphemeralResource) Metadata(
+	_ context.Context,
+	req ephemeral.MetadataRequest,
+	resp *ephemeral.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_sensor_update_policy_uninstall_token"
+}
+
+// Schema defines the schema for the ephemeral resource.
+func (r *uninstallTokenEphemeralResource) Schema(
+	_ context.Context,
+	_ ephemeral.SchemaRequest,
+	resp *ephemeral.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: fmt.Sprintf(
+			"Sensor Update Policy --- This ephemeral resource reveals the uninstall token of a host protected by a sensor update policy with uninstall protection enabled, or the bulk maintenance token when no host is given. The token is never written to state or plan. Each reveal is recorded in the Falcon audit log. Requires Terraform 1.10 or later.\n\n%s",
+			scopes.GenerateScopeDescription(uninstallTokenScopes),
+		),
+		Attributes: map[string]schema.Attribute{
+			"device_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "Host id to reveal the uninstall token for. The bulk maintenance token is revealed when left blank.",
+			},
+			"audit_message": schema.StringAttribute{
+				Optional:    true,
+				Description: "Message recorded in the audit log for the reveal.",
+			},
+			"uninstall_token": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The uninstall or bulk maintenance token.",
+			},
+		},
+	}
+}
+
+// Open reveals the token.
+func (r *uninstallTokenEphemeralResource) Open(
+	ctx context.Context,
+	req ephemeral.OpenRequest,
+	resp *ephemeral.OpenResponse,
+) {
+	var config uninstallTokenEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.data == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured CrowdStrike Provider",
+			"The provider must be configured before the crowdstrike_sensor_update_policy_uninstall_token ephemeral resource can be opened.",
+		)
+		return
+	}
+
+	deviceID := maintenanceTokenDeviceID
+	if config.DeviceID.ValueString() != "" {
+		deviceID = config.DeviceID.ValueString()
+	}
+
+	res, err := r.data.client.SensorUpdatePolicies.RevealUninstallToken(
+		&sensor_update_policies.RevealUninstallTokenParams{
+			Context: ctx,
+			Body: &models.UninstallTokenRevealUninstallTokenReqV1{
+				DeviceID:     &deviceID,
+				AuditMessage: config.AuditMessage.ValueString(),
+			},
+		},
+	)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error revealing CrowdStrike uninstall token",
+			"Could not reveal uninstall token: "+tferrors.Message(err, uninstallTokenScopes...),
+		)
+		return
+	}
+
+	if len(res.Payload.Resources) == 0 || res.Payload.Resources[0].UninstallToken == nil {
+		resp.Diagnostics.AddError(
+			"Error revealing CrowdStrike uninstall token",
+			fmt.Sprintf("No uninstall token was returned for device %s.", deviceID),
+		)
+		return
+	}
+
+	config.UninstallToken = types.StringValue(*res.Payload.Resources[0].UninstallToken)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &config)...)
+}
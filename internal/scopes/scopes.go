@@ -12,6 +12,20 @@ type Scope struct {
 	Write bool
 }
 
+// Permission returns the permission required for the scope, for example "Read & Write".
+func (s Scope) Permission() string {
+	switch {
+	case s.Read && s.Write:
+		return "Read & Write"
+	case s.Write:
+		return "Write"
+	case s.Read:
+		return "Read"
+	default:
+		return ""
+	}
+}
+
 // GenerateScopeDescription generates the api scopes block for resource, data-source, and function documentation.
 func GenerateScopeDescription(scopes []Scope) string {
 	if len(scopes) == 0 {
@@ -25,18 +39,7 @@ func GenerateScopeDescription(scopes []Scope) string {
 		if !scope.Read && !scope.Write {
 			continue
 		}
-		if scope.Read && scope.Write {
-			sb.WriteString(fmt.Sprintf("- %s | Read & Write\n", scope.Name))
-			continue
-		}
-		if scope.Write {
-			sb.WriteString(fmt.Sprintf("- %s | Write\n", scope.Name))
-			continue
-		}
-		if scope.Read {
-			sb.WriteString(fmt.Sprintf("- %s | Write\n", scope.Name))
-			continue
-		}
+		sb.WriteString(fmt.Sprintf("- %s | %s\n", scope.Name, scope.Permission()))
 	}
 
 	return sb.String()
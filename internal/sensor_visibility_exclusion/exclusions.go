@@ -0,0 +1,890 @@
+package sensorvisibilityexclusion
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/client/sensor_visibility_exclusions"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+	hostgroups "github.com/crowdstrike/terraform-provider-crowdstrike/internal/host_groups"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/scopes"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/tferrors"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &sensorVisibilityExclusionsResource{}
+	_ resource.ResourceWithConfigure      = &sensorVisibilityExclusionsResource{}
+	_ resource.ResourceWithImportState    = &sensorVisibilityExclusionsResource{}
+	_ resource.ResourceWithIdentity       = &sensorVisibilityExclusionsResource{}
+	_ resource.ResourceWithValidateConfig = &sensorVisibilityExclusionsResource{}
+	_ resource.ResourceWithModifyPlan     = &sensorVisibilityExclusionsResource{}
+)
+
+// NewSensorVisibilityExclusionsResource is a helper function to simplify the provider implementation.
+func NewSensorVisibilityExclusionsResource() resource.Resource {
+	return &sensorVisibilityExclusionsResource{}
+}
+
+// sensorVisibilityExclusionsResource is the resource implementation.
+type sensorVisibilityExclusionsResource struct {
+	client *client.CrowdStrikeAPISpecification
+}
+
+// sensorVisibilityExclusionsResourceModel is the resource model.
+type sensorVisibilityExclusionsResourceModel struct {
+	ID           types.String   `tfsdk:"id"`
+	LastUpdated  types.String   `tfsdk:"last_updated"`
+	Exclusions   []exclusion    `tfsdk:"exclusions"`
+	ExclusionIDs types.Map      `tfsdk:"exclusion_ids"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
+}
+
+// sensorVisibilityExclusionsIdentityModel is the resource identity. The id of the resource
+// is generated by the provider, so the set is identified by the ids of its exclusions.
+type sensorVisibilityExclusionsIdentityModel struct {
+	ExclusionIDs types.List `tfsdk:"exclusion_ids"`
+}
+
+// exclusion is a single sensor visibility exclusion.
+type exclusion struct {
+	Value                      types.String `tfsdk:"value"`
+	ApplyToDescendantProcesses types.Bool   `tfsdk:"apply_to_descendant_processes"`
+	Comment                    types.String `tfsdk:"comment"`
+	HostGroups                 types.Set    `tfsdk:"host_groups"`
+	AllowBroadPattern          types.Bool   `tfsdk:"allow_broad_pattern"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *sensorVisibilityExclusionsResource) Configure(
+	ctx context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.CrowdStrikeAPISpecification)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf(
+				"Expected *client.CrowdStrikeAPISpecification, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Metadata returns the resource type name.
+func (r *sensorVisibilityExclusionsResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_sensor_visibility_exclusions"
+	// the identity is the ids of the exclusions, which change as exclusions are added and removed.
+	resp.ResourceBehavior.MutableIdentity = true
+}
+
+// Schema defines the schema for the resource.
+func (r *sensorVisibilityExclusionsResource) Schema(
+	ctx context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: fmt.Sprintf(
+			"Sensor Visibility Exclusion --- This resource manages a set of sensor visibility exclusions as a unit. Exclusions are matched by value, so adding or removing an entry only creates or deletes that exclusion. Use it for large, generated lists of exclusions.\n\n%s",
+			scopes.GenerateScopeDescription(apiScopes),
+		),
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Identifier for the set of exclusions.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:    true,
+				Description: "Timestamp of the last Terraform update of the resource.",
+			},
+			"exclusions": schema.SetNestedAttribute{
+				Required:    true,
+				Description: "The sensor visibility exclusions to manage.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"value": schema.StringAttribute{
+							Required:    true,
+							Description: "The file path or pattern to exclude from sensor visibility. Must be unique within the set.",
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"apply_to_descendant_processes": schema.BoolAttribute{
+							Optional:    true,
+							Computed:    true,
+							Default:     booldefault.StaticBool(false),
+							Description: "Whether the exclusion also applies to processes spawned by the excluded process.",
+						},
+						"comment": schema.StringAttribute{
+							Optional:    true,
+							Description: "Comment for the exclusion. The api does not return comments, so changes made outside of Terraform are not detected.",
+						},
+						"host_groups": schema.SetAttribute{
+							Optional:    true,
+							ElementType: types.StringType,
+							Description: "Host Group ids the exclusion applies to. The exclusion applies to all hosts when omitted.",
+							Validators: []validator.Set{
+								setvalidator.SizeAtLeast(1),
+								setvalidator.ValueStringsAre(stringvalidator.NoneOf(globalHostGroup)),
+							},
+						},
+						"allow_broad_pattern": allowBroadPatternAttribute(),
+					},
+				},
+			},
+			"exclusion_ids": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "Map of exclusion value to the id of the exclusion.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *sensorVisibilityExclusionsResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan sensorVisibilityExclusionsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	id, err := utils.NewRandomID()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating CrowdStrike sensor visibility exclusions",
+			"Could not generate resource id: "+err.Error(),
+		)
+		return
+	}
+	plan.ID = types.StringValue(id)
+
+	ids, diags := r.reconcile(ctx, plan.Exclusions, nil, map[string]string{})
+	resp.Diagnostics.Append(diags...)
+
+	// save whatever was created so a failed apply does not leave untracked exclusions behind.
+	resp.Diagnostics.Append(r.setState(ctx, &resp.State, plan, nil, ids)...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, ids)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *sensorVisibilityExclusionsResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state sensorVisibilityExclusionsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	priorIDs := map[string]string{}
+	resp.Diagnostics.Append(state.ExclusionIDs.ElementsAs(ctx, &priorIDs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the api does not return comments or allow_broad_pattern, so they are carried over
+	// from the prior state.
+	priorComments := make(map[string]types.String, len(priorIDs))
+	priorAllowBroadPattern := make(map[string]types.Bool, len(priorIDs))
+	for _, e := range state.Exclusions {
+		if id, ok := priorIDs[e.Value.ValueString()]; ok {
+			priorComments[id] = e.Comment
+			priorAllowBroadPattern[id] = e.AllowBroadPattern
+		}
+	}
+
+	ids := make([]string, 0, len(priorIDs))
+	for _, id := range priorIDs {
+		ids = append(ids, id)
+	}
+
+	found, diags := r.getExclusions(ctx, ids)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(found) == 0 && len(ids) > 0 {
+		tflog.Warn(
+			ctx,
+			"sensor visibility exclusions not found, removing from state",
+			map[string]interface{}{"id": state.ID.ValueString()},
+		)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.Exclusions = []exclusion{}
+	exclusionIDs := make(map[string]string, len(found))
+
+	for _, f := range found {
+		if f == nil || f.ID == nil || f.Value == nil {
+			continue
+		}
+
+		e := exclusion{
+			Value:                      types.StringValue(*f.Value),
+			ApplyToDescendantProcesses: types.BoolValue(f.IsDescendantProcess),
+			Comment:                    priorComments[*f.ID],
+			HostGroups:                 types.SetNull(types.StringType),
+			AllowBroadPattern:          types.BoolValue(false),
+		}
+
+		if allow, ok := priorAllowBroadPattern[*f.ID]; ok && !allow.IsNull() {
+			e.AllowBroadPattern = allow
+		}
+
+		if f.AppliedGlobally == nil || !*f.AppliedGlobally {
+			var groups []string
+			for _, g := range f.Groups {
+				if g != nil && g.ID != nil {
+					groups = append(groups, *g.ID)
+				}
+			}
+
+			hostGroups, diags := types.SetValueFrom(ctx, types.StringType, groups)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			e.HostGroups = hostGroups
+		}
+
+		state.Exclusions = append(state.Exclusions, e)
+		exclusionIDs[*f.Value] = *f.ID
+	}
+
+	exclusionIDsMap, diags := types.MapValueFrom(ctx, types.StringType, exclusionIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.ExclusionIDs = exclusionIDsMap
+
+	// Set refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, exclusionIDs)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *sensorVisibilityExclusionsResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var plan sensorVisibilityExclusionsResourceModel
+	var state sensorVisibilityExclusionsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	priorIDs := map[string]string{}
+	resp.Diagnostics.Append(state.ExclusionIDs.ElementsAs(ctx, &priorIDs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ids, diags := r.reconcile(ctx, plan.Exclusions, state.Exclusions, priorIDs)
+	resp.Diagnostics.Append(diags...)
+
+	// save whatever was reconciled so a failed apply does not leave untracked exclusions behind.
+	resp.Diagnostics.Append(r.setState(ctx, &resp.State, plan, state.Exclusions, ids)...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, ids)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *sensorVisibilityExclusionsResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var state sensorVisibilityExclusionsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	exclusionIDs := map[string]string{}
+	resp.Diagnostics.Append(state.ExclusionIDs.ElementsAs(ctx, &exclusionIDs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ids := make([]string, 0, len(exclusionIDs))
+	for _, id := range exclusionIDs {
+		ids = append(ids, id)
+	}
+
+	resp.Diagnostics.Append(r.deleteExclusions(ctx, ids)...)
+}
+
+// IdentitySchema defines the identity schema for the resource.
+func (r *sensorVisibilityExclusionsResource) IdentitySchema(
+	_ context.Context,
+	_ resource.IdentitySchemaRequest,
+	resp *resource.IdentitySchemaResponse,
+) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"exclusion_ids": identityschema.ListAttribute{
+				ElementType:       types.StringType,
+				RequiredForImport: true,
+				Description:       "The ids of the sensor visibility exclusions in the set, sorted.",
+			},
+		},
+	}
+}
+
+// ImportState implements the logic to support resource imports.
+func (r *sensorVisibilityExclusionsResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	importIDs := strings.Split(req.ID, ",")
+
+	// an import block with an identity has no import id.
+	if req.ID == "" && req.Identity != nil {
+		var identity sensorVisibilityExclusionsIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		resp.Diagnostics.Append(identity.ExclusionIDs.ElementsAs(ctx, &importIDs, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	exclusionIDs := map[string]string{}
+	for _, id := range importIDs {
+		id = strings.TrimSpace(id)
+		if id != "" {
+			// keyed by id until read replaces the keys with the exclusion values.
+			exclusionIDs[id] = id
+		}
+	}
+
+	if len(exclusionIDs) == 0 {
+		resp.Diagnostics.AddError(
+			"Invalid import id",
+			"Import sensor visibility exclusions with a comma separated list of exclusion ids, e.g. id1,id2.",
+		)
+		return
+	}
+
+	id, err := utils.NewRandomID()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error importing CrowdStrike sensor visibility exclusions",
+			"Could not generate resource id: "+err.Error(),
+		)
+		return
+	}
+
+	exclusionIDsMap, diags := types.MapValueFrom(ctx, types.StringType, exclusionIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("exclusion_ids"), exclusionIDsMap)...)
+}
+
+// ValidateConfig runs during validate, plan, and apply
+// validate resource is configured as expected.
+func (r *sensorVisibilityExclusionsResource) ValidateConfig(
+	ctx context.Context,
+	req resource.ValidateConfigRequest,
+	resp *resource.ValidateConfigResponse,
+) {
+	var config sensorVisibilityExclusionsResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	values := make(map[string]bool, len(config.Exclusions))
+	for _, e := range config.Exclusions {
+		if e.Value.IsUnknown() || e.Value.IsNull() {
+			continue
+		}
+
+		resp.Diagnostics.Append(validateBroadPattern(path.Root("exclusions"), e.Value, e.AllowBroadPattern)...)
+
+		if values[e.Value.ValueString()] {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("exclusions"),
+				"Duplicate exclusion value",
+				fmt.Sprintf(
+					"The exclusion value %q is declared more than once. Each value can only be declared once.",
+					e.Value.ValueString(),
+				),
+			)
+		}
+
+		values[e.Value.ValueString()] = true
+	}
+}
+
+// ModifyPlan verifies the host groups referenced by the planned exclusions exist, so a
+// mistyped or deleted host group is reported at plan time instead of silently scoping
+// the exclusion to fewer hosts than intended.
+func (r *sensorVisibilityExclusionsResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan sensorVisibilityExclusionsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	referenced := map[string][]string{}
+	var ids []string
+	for _, e := range plan.Exclusions {
+		if e.HostGroups.IsUnknown() || e.HostGroups.IsNull() {
+			continue
+		}
+
+		for _, element := range e.HostGroups.Elements() {
+			group, ok := element.(types.String)
+			if !ok || group.IsUnknown() || group.ValueString() == globalHostGroup {
+				continue
+			}
+
+			if _, seen := referenced[group.ValueString()]; !seen {
+				ids = append(ids, group.ValueString())
+			}
+			referenced[group.ValueString()] = append(referenced[group.ValueString()], e.Value.ValueString())
+		}
+	}
+
+	if len(ids) == 0 {
+		return
+	}
+
+	found, err := hostgroups.ExistingIDs(ctx, r.client, ids)
+	if tferrors.StatusCode(err) == http.StatusForbidden {
+		// the lookup only guards against mistakes, so missing scopes do not block the plan.
+		resp.Diagnostics.AddWarning(
+			"Unable to verify host groups",
+			"The host groups referenced by the exclusions could not be verified: "+tferrors.Message(err, hostGroupScopes...),
+		)
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading CrowdStrike host groups",
+			"Could not verify the host groups referenced by the exclusions: "+tferrors.Message(err, hostGroupScopes...),
+		)
+		return
+	}
+
+	for _, id := range ids {
+		if found[id] {
+			continue
+		}
+
+		resp.Diagnostics.AddAttributeError(
+			path.Root("exclusions"),
+			"Host group not found",
+			fmt.Sprintf(
+				"The host group %s referenced by the exclusions %s does not exist.",
+				id,
+				strings.Join(referenced[id], ", "),
+			),
+		)
+	}
+}
+
+// reconcile creates, updates, and deletes exclusions so the api matches planned.
+// Exclusions are matched by value against prior and priorIDs. The returned map holds the
+// value to id of every exclusion that exists after reconciling, even when an error occurred.
+func (r *sensorVisibilityExclusionsResource) reconcile(
+	ctx context.Context,
+	planned []exclusion,
+	prior []exclusion,
+	priorIDs map[string]string,
+) (map[string]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	ids := make(map[string]string, len(planned))
+	for value, id := range priorIDs {
+		ids[value] = id
+	}
+
+	priorByValue := make(map[string]exclusion, len(prior))
+	for _, e := range prior {
+		priorByValue[e.Value.ValueString()] = e
+	}
+
+	plannedValues := make(map[string]bool, len(planned))
+	for _, e := range planned {
+		value := e.Value.ValueString()
+		plannedValues[value] = true
+
+		id, exists := ids[value]
+		if !exists {
+			id, diags = r.createExclusion(ctx, e)
+			if diags.HasError() {
+				return ids, diags
+			}
+
+			ids[value] = id
+			continue
+		}
+
+		if p, ok := priorByValue[value]; ok && exclusionEqual(p, e) {
+			continue
+		}
+
+		diags = r.updateExclusion(ctx, id, e)
+		if diags.HasError() {
+			return ids, diags
+		}
+	}
+
+	var toDelete []string
+	for value, id := range ids {
+		if !plannedValues[value] {
+			toDelete = append(toDelete, id)
+		}
+	}
+
+	diags = r.deleteExclusions(ctx, toDelete)
+	if diags.HasError() {
+		return ids, diags
+	}
+
+	for value := range ids {
+		if !plannedValues[value] {
+			delete(ids, value)
+		}
+	}
+
+	return ids, diags
+}
+
+// setState saves the exclusions that exist in ids. Planned exclusions take precedence
+// over prior ones so a partially applied plan is tracked as far as it got.
+func (r *sensorVisibilityExclusionsResource) setState(
+	ctx context.Context,
+	state *tfsdk.State,
+	plan sensorVisibilityExclusionsResourceModel,
+	prior []exclusion,
+	ids map[string]string,
+) diag.Diagnostics {
+	exclusions := []exclusion{}
+	tracked := make(map[string]bool, len(ids))
+
+	for _, e := range plan.Exclusions {
+		if _, ok := ids[e.Value.ValueString()]; ok {
+			exclusions = append(exclusions, e)
+			tracked[e.Value.ValueString()] = true
+		}
+	}
+
+	for _, e := range prior {
+		if _, ok := ids[e.Value.ValueString()]; ok && !tracked[e.Value.ValueString()] {
+			exclusions = append(exclusions, e)
+		}
+	}
+
+	exclusionIDs, diags := types.MapValueFrom(ctx, types.StringType, ids)
+	if diags.HasError() {
+		return diags
+	}
+
+	plan.Exclusions = exclusions
+	plan.ExclusionIDs = exclusionIDs
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	diags.Append(state.Set(ctx, plan)...)
+	return diags
+}
+
+// setIdentity sets the resource identity to the sorted ids of the exclusions in ids.
+func setIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, ids map[string]string) diag.Diagnostics {
+	if identity == nil {
+		return nil
+	}
+
+	exclusionIDs := slices.Sorted(maps.Values(ids))
+
+	list, diags := types.ListValueFrom(ctx, types.StringType, exclusionIDs)
+	if diags.HasError() {
+		return diags
+	}
+
+	diags.Append(identity.Set(ctx, sensorVisibilityExclusionsIdentityModel{ExclusionIDs: list})...)
+	return diags
+}
+
+// createExclusion creates a single exclusion and returns its id.
+func (r *sensorVisibilityExclusionsResource) createExclusion(
+	ctx context.Context,
+	e exclusion,
+) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	groups, diags := hostGroupsForRequest(ctx, e.HostGroups)
+	if diags.HasError() {
+		return "", diags
+	}
+
+	res, err := r.client.SensorVisibilityExclusions.CreateSVExclusionsV1(
+		&sensor_visibility_exclusions.CreateSVExclusionsV1Params{
+			Context: ctx,
+			Body: &models.SvExclusionsCreateReqV1{
+				Value:               e.Value.ValueString(),
+				IsDescendantProcess: e.ApplyToDescendantProcesses.ValueBool(),
+				Comment:             e.Comment.ValueString(),
+				Groups:              groups,
+			},
+		},
+	)
+
+	if err != nil {
+		diags.AddError(
+			"Error creating CrowdStrike sensor visibility exclusion",
+			fmt.Sprintf(
+				"Could not create sensor visibility exclusion %q: %s",
+				e.Value.ValueString(),
+				tferrors.Message(err, apiScopes...),
+			),
+		)
+		return "", diags
+	}
+
+	if len(res.Payload.Resources) == 0 || res.Payload.Resources[0].ID == nil {
+		diags.AddError(
+			"Error creating CrowdStrike sensor visibility exclusion",
+			fmt.Sprintf("No id was returned for sensor visibility exclusion %q.", e.Value.ValueString()),
+		)
+		return "", diags
+	}
+
+	return *res.Payload.Resources[0].ID, diags
+}
+
+// updateExclusion updates the settings of a single exclusion.
+func (r *sensorVisibilityExclusionsResource) updateExclusion(
+	ctx context.Context,
+	id string,
+	e exclusion,
+) diag.Diagnostics {
+	groups, diags := hostGroupsForRequest(ctx, e.HostGroups)
+	if diags.HasError() {
+		return diags
+	}
+
+	_, err := r.client.SensorVisibilityExclusions.UpdateSensorVisibilityExclusionsV1(
+		&sensor_visibility_exclusions.UpdateSensorVisibilityExclusionsV1Params{
+			Context: ctx,
+			Body: &models.SvExclusionsUpdateReqV1{
+				ID:                  &id,
+				Value:               e.Value.ValueString(),
+				IsDescendantProcess: e.ApplyToDescendantProcesses.ValueBool(),
+				Comment:             e.Comment.ValueString(),
+				Groups:              groups,
+			},
+		},
+	)
+
+	if err != nil {
+		diags.AddError(
+			"Error updating CrowdStrike sensor visibility exclusion",
+			fmt.Sprintf(
+				"Could not update sensor visibility exclusion %q: %s",
+				e.Value.ValueString(),
+				tferrors.Message(err, apiScopes...),
+			),
+		)
+	}
+
+	return diags
+}
+
+// deleteExclusions deletes exclusions in batches, exclusions that no longer exist are ignored.
+func (r *sensorVisibilityExclusionsResource) deleteExclusions(
+	ctx context.Context,
+	ids []string,
+) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for _, batch := range batches(ids) {
+		_, err := r.client.SensorVisibilityExclusions.DeleteSensorVisibilityExclusionsV1(
+			&sensor_visibility_exclusions.DeleteSensorVisibilityExclusionsV1Params{
+				Context: ctx,
+				Ids:     batch,
+			},
+		)
+
+		if err != nil && !tferrors.IsNotFound(err) {
+			diags.AddError(
+				"Error deleting CrowdStrike sensor visibility exclusions",
+				"Could not delete sensor visibility exclusions: "+tferrors.Message(err, apiScopes...),
+			)
+			return diags
+		}
+	}
+
+	return diags
+}
+
+// getExclusions returns the exclusions for ids in batches. Exclusions that no longer exist are
+// left out of the result.
+func (r *sensorVisibilityExclusionsResource) getExclusions(
+	ctx context.Context,
+	ids []string,
+) ([]*models.SvExclusionsSVExclusionV1, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	exclusions, err := utils.GetInBatches(ids, batchSize, func(batch []string) ([]*models.SvExclusionsSVExclusionV1, error) {
+		res, err := r.client.SensorVisibilityExclusions.GetSensorVisibilityExclusionsV1(
+			&sensor_visibility_exclusions.GetSensorVisibilityExclusionsV1Params{
+				Context: ctx,
+				Ids:     batch,
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+		return res.Payload.Resources, nil
+	})
+	if err != nil {
+		diags.AddError(
+			"Error reading CrowdStrike sensor visibility exclusions",
+			"Could not read sensor visibility exclusions: "+tferrors.Message(err, apiScopes...),
+		)
+	}
+
+	return exclusions, diags
+}
+
+// exclusionEqual returns true when a and b have the same settings.
+func exclusionEqual(a, b exclusion) bool {
+	return a.ApplyToDescendantProcesses.Equal(b.ApplyToDescendantProcesses) &&
+		a.Comment.Equal(b.Comment) &&
+		a.HostGroups.Equal(b.HostGroups)
+}
+
+// hostGroupsForRequest returns the host groups to send to the api, an exclusion without
+// host groups applies to all hosts.
+func hostGroupsForRequest(ctx context.Context, hostGroups types.Set) ([]string, diag.Diagnostics) {
+	if hostGroups.IsNull() || len(hostGroups.Elements()) == 0 {
+		return []string{globalHostGroup}, nil
+	}
+
+	var groups []string
+	diags := hostGroups.ElementsAs(ctx, &groups, false)
+
+	return groups, diags
+}
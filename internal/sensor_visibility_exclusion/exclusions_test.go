@@ -0,0 +1,243 @@
+package sensorvisibilityexclusion_test
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/acctest"
+	sdkacctest "github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccSensorVisibilityExclusionsResource(t *testing.T) {
+	rName := sdkacctest.RandomWithPrefix("tf-acceptance-test")
+	resourceName := "crowdstrike_sensor_visibility_exclusions.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.ProviderConfig + fmt.Sprintf(`
+resource "crowdstrike_sensor_visibility_exclusions" "test" {
+  exclusions = [
+    {
+      value   = "/opt/%[1]s/one/**"
+      comment = "first"
+    },
+    {
+      value   = "/opt/%[1]s/two/**"
+      comment = "second"
+    },
+  ]
+}
+`, rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "exclusions.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "exclusion_ids.%", "2"),
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttrSet(resourceName, "last_updated"),
+				),
+			},
+			{
+				Config: acctest.ProviderConfig + fmt.Sprintf(`
+resource "crowdstrike_host_group" "test" {
+  name        = "%[1]s"
+  description = "made with terraform"
+  type        = "dynamic"
+}
+
+resource "crowdstrike_sensor_visibility_exclusions" "test" {
+  exclusions = [
+    {
+      value                         = "/opt/%[1]s/two/**"
+      comment                       = "second updated"
+      apply_to_descendant_processes = true
+      host_groups                   = [crowdstrike_host_group.test.id]
+    },
+    {
+      value = "/opt/%[1]s/three/**"
+    },
+  ]
+}
+`, rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "exclusions.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "exclusion_ids.%", "2"),
+					resource.TestCheckNoResourceAttr(resourceName, fmt.Sprintf("exclusion_ids./opt/%s/one/**", rName)),
+					resource.TestCheckResourceAttrSet(resourceName, fmt.Sprintf("exclusion_ids./opt/%s/three/**", rName)),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "exclusions.*", map[string]string{
+						"value":                         fmt.Sprintf("/opt/%s/two/**", rName),
+						"apply_to_descendant_processes": "true",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "exclusions.*", map[string]string{
+						"value":                         fmt.Sprintf("/opt/%s/three/**", rName),
+						"apply_to_descendant_processes": "false",
+					}),
+				),
+			},
+			// comments are not returned by the api, so the imported exclusions are checked on their own.
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateIdFunc: importStateID(resourceName),
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 {
+						return fmt.Errorf("expected 1 state, got %d", len(states))
+					}
+
+					attrs := states[0].Attributes
+					if attrs["exclusions.#"] != "2" {
+						return fmt.Errorf("expected 2 exclusions, got %s", attrs["exclusions.#"])
+					}
+
+					for k, v := range attrs {
+						if strings.HasSuffix(k, ".apply_to_descendant_processes") && v == "true" {
+							return nil
+						}
+					}
+
+					return fmt.Errorf("expected an imported exclusion with apply_to_descendant_processes set")
+				},
+			},
+		},
+	})
+}
+
+func TestAccSensorVisibilityExclusionsResource_identity(t *testing.T) {
+	rName := sdkacctest.RandomWithPrefix("tf-acceptance-test")
+	resourceName := "crowdstrike_sensor_visibility_exclusions.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		Steps: []resource.TestStep{
+			// comments are not returned by the api, so they are left out to compare the imported state.
+			{
+				Config: acctest.ProviderConfig + fmt.Sprintf(`
+resource "crowdstrike_sensor_visibility_exclusions" "test" {
+  exclusions = [
+    {
+      value = "/opt/%[1]s/one/**"
+    },
+    {
+      value = "/opt/%[1]s/two/**"
+    },
+  ]
+}
+`, rName),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentity(resourceName, map[string]knownvalue.Check{
+						"exclusion_ids": knownvalue.ListSizeExact(2),
+					}),
+				},
+			},
+			{
+				ResourceName:    resourceName,
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+		},
+	})
+}
+
+// importStateID returns the exclusion ids of resourceName as a comma separated list.
+func importStateID(resourceName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		var ids []string
+		for k, v := range rs.Primary.Attributes {
+			if strings.HasPrefix(k, "exclusion_ids.") && k != "exclusion_ids.%" {
+				ids = append(ids, v)
+			}
+		}
+
+		return strings.Join(ids, ","), nil
+	}
+}
+
+func TestAccSensorVisibilityExclusionsResourceDuplicateValue(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.ProviderConfig + `
+resource "crowdstrike_sensor_visibility_exclusions" "test" {
+  exclusions = [
+    {
+      value   = "/opt/duplicate/**"
+      comment = "first"
+    },
+    {
+      value   = "/opt/duplicate/**"
+      comment = "second"
+    },
+  ]
+}
+`,
+				ExpectError: regexp.MustCompile("Duplicate exclusion value"),
+			},
+		},
+	})
+}
+
+func TestAccSensorVisibilityExclusionsResourceBroadPattern(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.ProviderConfig + `
+resource "crowdstrike_sensor_visibility_exclusions" "test" {
+  exclusions = [
+    {
+      value = "/opt/specific/**"
+    },
+    {
+      value = "C:\\*"
+    },
+  ]
+}
+`,
+				ExpectError: regexp.MustCompile("Overly broad exclusion value"),
+			},
+		},
+	})
+}
+
+func TestAccSensorVisibilityExclusionsResource_missingHostGroup(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.ProviderConfig + `
+resource "crowdstrike_sensor_visibility_exclusions" "test" {
+  exclusions = [
+    {
+      value       = "/opt/missing-group/**"
+      host_groups = ["00000000000000000000000000000000"]
+    },
+  ]
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Host group not found"),
+			},
+		},
+	})
+}
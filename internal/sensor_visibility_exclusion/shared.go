@@ -0,0 +1,119 @@
+package sensorvisibilityexclusion
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/scopes"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// defaultTimeout is used for an operation when the timeouts block does not configure one.
+const defaultTimeout = 20 * time.Minute
+
+// batchSize is the number of exclusion ids sent in a single get or delete request.
+const batchSize = 100
+
+// globalHostGroup is the host group id the api uses for exclusions that apply to all hosts.
+const globalHostGroup = "all"
+
+var apiScopes = []scopes.Scope{
+	{
+		Name:  "Sensor visibility exclusions",
+		Read:  true,
+		Write: true,
+	},
+	{
+		Name:  "Host groups",
+		Read:  true,
+		Write: false,
+	},
+}
+
+// hostGroupScopes are the scopes needed to verify the host groups referenced by exclusions.
+var hostGroupScopes = []scopes.Scope{
+	{
+		Name:  "Host groups",
+		Read:  true,
+		Write: false,
+	},
+}
+
+// batches splits ids into slices of at most batchSize ids.
+func batches(ids []string) [][]string {
+	var result [][]string
+	for len(ids) > batchSize {
+		result = append(result, ids[:batchSize])
+		ids = ids[batchSize:]
+	}
+
+	if len(ids) > 0 {
+		result = append(result, ids)
+	}
+
+	return result
+}
+
+// drivePattern matches a windows drive such as C: or a wildcard drive such as *:.
+var drivePattern = regexp.MustCompile(`^([a-zA-Z]|\*):$`)
+
+// isBroadPattern reports whether an exclusion value matches every file of a host or of a
+// whole drive, such as *, /*, /** or C:\*. Such an exclusion disables detection for every
+// host it applies to.
+func isBroadPattern(value string) bool {
+	segments := strings.Split(strings.ReplaceAll(strings.TrimSpace(value), `\`, "/"), "/")
+
+	// the root of the path, / or a drive.
+	if segments[0] == "" || drivePattern.MatchString(segments[0]) {
+		segments = segments[1:]
+	}
+
+	for _, segment := range segments {
+		if strings.Trim(segment, "*") != "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// allowBroadPatternAttribute returns the attribute that opts an exclusion out of the
+// overly broad pattern check.
+func allowBroadPatternAttribute() schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Optional:    true,
+		Computed:    true,
+		Default:     booldefault.StaticBool(false),
+		Description: "Allow a value that matches every file of a host or drive, such as `*`, `/*`, or `C:\\*`. Such an exclusion disables detection for every host it applies to.",
+	}
+}
+
+// validateBroadPattern returns an error when value is an overly broad pattern and
+// allow_broad_pattern is not set.
+func validateBroadPattern(p path.Path, value types.String, allow types.Bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if value.IsNull() || value.IsUnknown() || allow.IsUnknown() || allow.ValueBool() {
+		return diags
+	}
+
+	if isBroadPattern(value.ValueString()) {
+		diags.AddAttributeError(
+			p,
+			"Overly broad exclusion value",
+			fmt.Sprintf(
+				"The exclusion value %q matches every file of a host or drive and would disable detection for every host it applies to. "+
+					"Use a more specific path, or set allow_broad_pattern = true if this is intended.",
+				value.ValueString(),
+			),
+		)
+	}
+
+	return diags
+}
@@ -0,0 +1,29 @@
+package sensorvisibilityexclusion
+
+import (
+	"testing"
+)
+
+func TestIsBroadPattern(t *testing.T) {
+	tests := map[string]bool{
+		"*":                  true,
+		"**":                 true,
+		"/*":                 true,
+		"/**":                true,
+		"/**/*":              true,
+		`C:\*`:               true,
+		`c:\**\*`:            true,
+		`*:\**`:              true,
+		"/":                  true,
+		"*.log":              false,
+		"/opt/**":            false,
+		`C:\Program Files\*`: false,
+		`D:\build\**`:        false,
+	}
+
+	for value, expected := range tests {
+		if got := isBroadPattern(value); got != expected {
+			t.Errorf("isBroadPattern(%q) = %t; want %t", value, got, expected)
+		}
+	}
+}
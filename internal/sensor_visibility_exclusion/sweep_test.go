@@ -0,0 +1,96 @@
+package sensorvisibilityexclusion_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/crowdstrike/gofalcon/falcon/client/sensor_visibility_exclusions"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/sweep"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestMain(m *testing.M) {
+	resource.TestMain(m)
+}
+
+func init() {
+	resource.AddTestSweepers("crowdstrike_sensor_visibility_exclusions", &resource.Sweeper{
+		Name: "crowdstrike_sensor_visibility_exclusions",
+		F:    sweepSensorVisibilityExclusions,
+	})
+}
+
+func sweepSensorVisibilityExclusions(_ string) error {
+	ctx := context.Background()
+	c, err := sweep.Client()
+	if err != nil {
+		return err
+	}
+
+	// exclusions have no name, acceptance tests put the prefix in the value instead.
+	filter := fmt.Sprintf("value:~'%s'", sweep.Prefix)
+	var ids []string
+	limit := sweep.QueryLimit
+	var offset int64
+
+	for {
+		res, err := c.SensorVisibilityExclusions.QuerySensorVisibilityExclusionsV1(
+			&sensor_visibility_exclusions.QuerySensorVisibilityExclusionsV1Params{
+				Context: ctx,
+				Filter:  &filter,
+				Limit:   &limit,
+				Offset:  &offset,
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("listing sensor visibility exclusions: %w", err)
+		}
+
+		ids = append(ids, res.Payload.Resources...)
+
+		var more bool
+		offset, more = sweep.NextOffset(offset, len(res.Payload.Resources), res.Payload.Meta)
+		if !more {
+			break
+		}
+	}
+
+	for i := 0; i < len(ids); i += 100 {
+		batch := ids[i:min(i+100, len(ids))]
+
+		res, err := c.SensorVisibilityExclusions.GetSensorVisibilityExclusionsV1(
+			&sensor_visibility_exclusions.GetSensorVisibilityExclusionsV1Params{
+				Context: ctx,
+				Ids:     batch,
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("reading sensor visibility exclusions: %w", err)
+		}
+
+		var toDelete []string
+		for _, e := range res.Payload.Resources {
+			if e.Value != nil && strings.Contains(*e.Value, sweep.Prefix) {
+				toDelete = append(toDelete, *e.ID)
+			}
+		}
+
+		if len(toDelete) == 0 {
+			continue
+		}
+
+		_, err = c.SensorVisibilityExclusions.DeleteSensorVisibilityExclusionsV1(
+			&sensor_visibility_exclusions.DeleteSensorVisibilityExclusionsV1Params{
+				Context: ctx,
+				Ids:     toDelete,
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("deleting sensor visibility exclusions: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+// Package sweep provides helpers for acceptance test sweepers, which remove resources
+// left behind by failed acceptance tests. Run sweepers with:
+//
+//	go test ./internal/... -v -sweep=all -timeout 60m
+package sweep
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/crowdstrike/gofalcon/falcon"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+)
+
+// Prefix is the name prefix acceptance tests give the resources they create.
+const Prefix = "tf-acceptance-test"
+
+// QueryLimit is the page size used when sweepers list resources.
+const QueryLimit int64 = 500
+
+// NameFilter is an FQL filter matching resources whose name contains Prefix.
+var NameFilter = fmt.Sprintf("name:~'%s'", Prefix)
+
+// Client returns a CrowdStrike api client configured from the FALCON_CLIENT_ID,
+// FALCON_CLIENT_SECRET, and FALCON_CLOUD environment variables.
+func Client() (*client.CrowdStrikeAPISpecification, error) {
+	cloud := os.Getenv("FALCON_CLOUD")
+	if cloud == "" {
+		cloud = "autodiscover"
+	}
+
+	return falcon.NewClient(&falcon.ApiConfig{
+		ClientId:     os.Getenv("FALCON_CLIENT_ID"),
+		ClientSecret: os.Getenv("FALCON_CLIENT_SECRET"),
+		Cloud:        falcon.Cloud(cloud),
+		Context:      context.Background(),
+	})
+}
+
+// IsSweepable returns true when name was created by an acceptance test.
+func IsSweepable(name *string) bool {
+	return name != nil && strings.HasPrefix(*name, Prefix)
+}
+
+// NextOffset returns the offset of the next page and whether there are more results.
+func NextOffset(offset int64, pageSize int, meta *models.MsaMetaInfo) (int64, bool) {
+	offset += int64(pageSize)
+
+	if pageSize == 0 || meta == nil || meta.Pagination == nil || meta.Pagination.Total == nil {
+		return offset, false
+	}
+
+	return offset, offset < *meta.Pagination.Total
+}
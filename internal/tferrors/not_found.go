@@ -0,0 +1,60 @@
+package tferrors
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// ErrNotFound is returned when the CrowdStrike API reports success but the
+// requested resource is missing from the response.
+var ErrNotFound = errors.New("resource not found")
+
+// IsNotFound reports whether err is a 404 Not Found response from the CrowdStrike API
+// or wraps ErrNotFound.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound) || StatusCode(err) == http.StatusNotFound
+}
+
+// notFoundDiagnostic is an error diagnostic for a resource that no longer exists.
+// Read functions use HasNotFoundError to remove the resource from state instead of failing.
+type notFoundDiagnostic struct {
+	summary string
+	detail  string
+}
+
+var _ diag.Diagnostic = notFoundDiagnostic{}
+
+// NewNotFoundError returns an error diagnostic that marks the resource as not found.
+func NewNotFoundError(summary, detail string) diag.Diagnostic {
+	return notFoundDiagnostic{summary: summary, detail: detail}
+}
+
+func (d notFoundDiagnostic) Severity() diag.Severity {
+	return diag.SeverityError
+}
+
+func (d notFoundDiagnostic) Summary() string {
+	return d.summary
+}
+
+func (d notFoundDiagnostic) Detail() string {
+	return d.detail
+}
+
+func (d notFoundDiagnostic) Equal(o diag.Diagnostic) bool {
+	other, ok := o.(notFoundDiagnostic)
+	return ok && other == d
+}
+
+// HasNotFoundError reports whether diags contains a diagnostic created by NewNotFoundError.
+func HasNotFoundError(diags diag.Diagnostics) bool {
+	for _, d := range diags {
+		if _, ok := d.(notFoundDiagnostic); ok {
+			return true
+		}
+	}
+
+	return false
+}
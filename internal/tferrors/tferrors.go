@@ -0,0 +1,198 @@
+// Package tferrors formats errors returned by gofalcon for use in diagnostics.
+package tferrors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/crowdstrike/gofalcon/falcon"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/scopes"
+)
+
+// operationPattern matches the method and path gofalcon includes in its error messages,
+// for example "[GET /policy/queries/sensor-update/v1][403] ...".
+var operationPattern = regexp.MustCompile(`^\[([A-Z]+) ([^\]]+)\]\[\d+\]`)
+
+// APIError holds the details of a CrowdStrike API error response.
+type APIError struct {
+	// StatusCode is the http status code, 0 if the request did not receive a response.
+	StatusCode int
+	// TraceID is the value of the X-Cs-Traceid header, submit this to support when resolving an issue.
+	TraceID string
+	// Messages are the errors included in the response body.
+	Messages []string
+	// Method is the http method of the request, empty if unknown.
+	Method string
+	// Path is the path of the request, empty if unknown.
+	Path string
+}
+
+type errorPayload struct {
+	Errors []struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		ID      string `json:"id"`
+	} `json:"errors"`
+}
+
+// FromError extracts the status code, trace id, and error messages from a gofalcon error.
+// It returns false if err was not returned by the CrowdStrike API.
+func FromError(err error) (APIError, bool) {
+	var apiErr APIError
+
+	// gofalcon response errors implement error and Code, matching both avoids asserting
+	// the matched value back to an error.
+	var coder interface {
+		error
+		Code() int
+	}
+	if !errors.As(err, &coder) {
+		return apiErr, false
+	}
+
+	apiErr.StatusCode = coder.Code()
+	apiErr.TraceID = traceID(coder)
+
+	if m := operationPattern.FindStringSubmatch(coder.Error()); m != nil {
+		apiErr.Method = m[1]
+		apiErr.Path = m[2]
+	}
+
+	payload := falcon.ErrorExtractPayload(coder)
+	if payload == nil || reflect.ValueOf(payload).IsNil() {
+		return apiErr, true
+	}
+
+	b, marshalErr := payload.MarshalBinary()
+	if marshalErr != nil {
+		return apiErr, true
+	}
+
+	var body errorPayload
+	if json.Unmarshal(b, &body) != nil {
+		return apiErr, true
+	}
+
+	for _, e := range body.Errors {
+		if e.Message == "" {
+			continue
+		}
+
+		if e.ID != "" {
+			apiErr.Messages = append(apiErr.Messages, fmt.Sprintf("%s: %s", e.ID, e.Message))
+		} else {
+			apiErr.Messages = append(apiErr.Messages, e.Message)
+		}
+	}
+
+	return apiErr, true
+}
+
+// StatusCode returns the http status code of a gofalcon error, 0 if there is none.
+func StatusCode(err error) int {
+	apiErr, _ := FromError(err)
+	return apiErr.StatusCode
+}
+
+// Message formats a gofalcon error for the detail of a diagnostic, including the
+// status code, error messages, and trace id from the API response when available.
+// When the API returns 403 Forbidden the scopes required by the request are listed
+// so the user knows which scope to grant the API client.
+func Message(err error, apiScopes ...scopes.Scope) string {
+	if err == nil {
+		return ""
+	}
+
+	apiErr, ok := FromError(err)
+	if !ok {
+		return falcon.ErrorExplain(err)
+	}
+
+	var sb strings.Builder
+
+	if len(apiErr.Messages) > 0 {
+		sb.WriteString(strings.Join(apiErr.Messages, ", "))
+	} else {
+		sb.WriteString(err.Error())
+	}
+
+	sb.WriteString(fmt.Sprintf("\n\nStatus Code: %d", apiErr.StatusCode))
+
+	if apiErr.TraceID != "" {
+		sb.WriteString("\nTrace ID: " + apiErr.TraceID)
+	}
+
+	if apiErr.StatusCode == http.StatusForbidden {
+		sb.WriteString("\n\n" + forbiddenMessage(apiErr, apiScopes))
+	}
+
+	return sb.String()
+}
+
+// IsForbidden reports whether err is a 403 Forbidden response from the CrowdStrike API.
+func IsForbidden(err error) bool {
+	return StatusCode(err) == http.StatusForbidden
+}
+
+// forbiddenMessage explains which api scopes the API client needs for the failed request.
+// Only scopes with the permission required by the request method are listed.
+func forbiddenMessage(apiErr APIError, apiScopes []scopes.Scope) string {
+	if len(apiScopes) == 0 {
+		return "The API client may be missing a required API scope. " +
+			"Verify the API client has the scopes listed in the documentation for this resource."
+	}
+
+	var required []string
+	for _, scope := range apiScopes {
+		switch {
+		case apiErr.Method == http.MethodGet && scope.Read:
+			required = append(required, fmt.Sprintf("- %s | Read", scope.Name))
+		case apiErr.Method != "" && apiErr.Method != http.MethodGet && scope.Write:
+			required = append(required, fmt.Sprintf("- %s | Write", scope.Name))
+		}
+	}
+
+	// Fall back to every scope when the request method is unknown.
+	if len(required) == 0 {
+		for _, scope := range apiScopes {
+			if scope.Permission() != "" {
+				required = append(required, fmt.Sprintf("- %s | %s", scope.Name, scope.Permission()))
+			}
+		}
+	}
+
+	operation := "the request"
+	if apiErr.Method != "" {
+		operation = fmt.Sprintf("%s %s", apiErr.Method, apiErr.Path)
+	}
+
+	return fmt.Sprintf(
+		"The API client is not authorized to perform %s. Ensure the API client has the following API scopes:\n\n%s",
+		operation,
+		strings.Join(required, "\n"),
+	)
+}
+
+// traceID returns the XCSTRACEID field of a gofalcon error response.
+func traceID(v any) string {
+	value := reflect.ValueOf(v)
+	if value.Kind() == reflect.Ptr {
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Struct {
+		return ""
+	}
+
+	field := value.FieldByName("XCSTRACEID")
+	if !field.IsValid() || field.Kind() != reflect.String {
+		return ""
+	}
+
+	return field.String()
+}
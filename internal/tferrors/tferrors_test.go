@@ -0,0 +1,129 @@
+package tferrors
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/crowdstrike/gofalcon/falcon/client/sensor_update_policies"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/scopes"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func TestMessage(t *testing.T) {
+	code := int32(403)
+	message := "access denied, authorization failed"
+
+	forbidden := sensor_update_policies.NewQuerySensorUpdatePoliciesForbidden()
+	forbidden.XCSTRACEID = "0123-abcd"
+	forbidden.Payload = &models.MsaErrorsOnly{
+		Errors: []*models.MsaAPIError{{Code: &code, Message: &message}},
+	}
+
+	empty := sensor_update_policies.NewQuerySensorUpdatePoliciesForbidden()
+
+	tests := []struct {
+		name string
+		err  error
+		want []string
+	}{
+		{
+			name: "api error",
+			err:  forbidden,
+			want: []string{message, "Status Code: 403", "Trace ID: 0123-abcd"},
+		},
+		{
+			name: "wrapped api error",
+			err:  fmt.Errorf("wrapped: %w", forbidden),
+			want: []string{message, "Status Code: 403", "Trace ID: 0123-abcd"},
+		},
+		{
+			name: "api error without payload",
+			err:  empty,
+			want: []string{"Status Code: 403"},
+		},
+		{
+			name: "non api error",
+			err:  errors.New("connection refused"),
+			want: []string{"connection refused"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Message(tt.err)
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("Message() = %q, want it to contain %q", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestMessageForbiddenScopes(t *testing.T) {
+	apiScopes := []scopes.Scope{
+		{Name: "Sensor update policies", Read: true, Write: true},
+		{Name: "Host groups", Read: true},
+	}
+
+	got := Message(sensor_update_policies.NewQuerySensorUpdatePoliciesForbidden(), apiScopes...)
+
+	for _, want := range []string{"GET /policy/queries/sensor-update/v1", "- Sensor update policies | Read", "- Host groups | Read"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Message() = %q, want it to contain %q", got, want)
+		}
+	}
+
+	got = Message(sensor_update_policies.NewCreateSensorUpdatePoliciesForbidden(), apiScopes...)
+
+	if !strings.Contains(got, "- Sensor update policies | Write") {
+		t.Errorf("Message() = %q, want it to contain the write scope", got)
+	}
+
+	if strings.Contains(got, "Host groups") {
+		t.Errorf("Message() = %q, want it to only contain scopes with write permission", got)
+	}
+}
+
+func TestIsNotFound(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "404 response", err: sensor_update_policies.NewGetSensorUpdatePoliciesV2NotFound(), want: true},
+		{name: "wrapped ErrNotFound", err: fmt.Errorf("policy abc: %w", ErrNotFound), want: true},
+		{name: "403 response", err: sensor_update_policies.NewQuerySensorUpdatePoliciesForbidden(), want: false},
+		{name: "nil", err: nil, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsNotFound(tt.err); got != tt.want {
+				t.Errorf("IsNotFound() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHasNotFoundError(t *testing.T) {
+	var diags diag.Diagnostics
+	diags.AddError("Error reading policy", "unexpected error")
+
+	if HasNotFoundError(diags) {
+		t.Errorf("HasNotFoundError() = true for diagnostics without a not found error")
+	}
+
+	diags.Append(NewNotFoundError("Error reading policy", "policy not found"))
+
+	if !HasNotFoundError(diags) {
+		t.Errorf("HasNotFoundError() = false for diagnostics with a not found error")
+	}
+
+	if !diags.HasError() {
+		t.Errorf("not found errors must be error diagnostics")
+	}
+}
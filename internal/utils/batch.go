@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/tferrors"
+)
+
+// GetInBatches calls get with ids in batches of at most size ids and returns every item
+// found. Get endpoints fail the whole batch with 404 Not Found when one of the ids does not
+// exist, so a batch that fails with 404 is looked up again one id at a time and the ids that
+// do not exist are left out of the result. Any other error stops the lookup and is returned
+// with the items found so far.
+func GetInBatches[T any](ids []string, size int, get func(batch []string) ([]T, error)) ([]T, error) {
+	var found []T
+
+	for i := 0; i < len(ids); i += size {
+		batch := ids[i:min(i+size, len(ids))]
+
+		items, err := get(batch)
+
+		if tferrors.IsNotFound(err) && len(batch) > 1 {
+			for _, id := range batch {
+				single, err := GetInBatches([]string{id}, size, get)
+				if err != nil {
+					return found, err
+				}
+				found = append(found, single...)
+			}
+			continue
+		}
+
+		if tferrors.IsNotFound(err) {
+			continue
+		}
+
+		if err != nil {
+			return found, err
+		}
+
+		found = append(found, items...)
+	}
+
+	return found, nil
+}
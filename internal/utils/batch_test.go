@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestGetInBatches(t *testing.T) {
+	existing := []string{"a", "b", "d", "e"}
+	var calls [][]string
+
+	// like the api, the request fails when any of the ids does not exist.
+	get := func(batch []string) ([]string, error) {
+		calls = append(calls, batch)
+		for _, id := range batch {
+			if !slices.Contains(existing, id) {
+				return nil, statusError(http.StatusNotFound)
+			}
+		}
+		return batch, nil
+	}
+
+	found, err := GetInBatches([]string{"a", "b", "c", "d", "e"}, 2, get)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !reflect.DeepEqual(found, existing) {
+		t.Errorf("GetInBatches() = %v, want %v", found, existing)
+	}
+
+	// [a b], [c d] fails and is retried as [c], [d], then [e].
+	if len(calls) != 5 {
+		t.Errorf("expected 5 requests, got %d: %v", len(calls), calls)
+	}
+
+	failure := errors.New("connection reset")
+	_, err = GetInBatches([]string{"a"}, 2, func([]string) ([]string, error) { return nil, failure })
+	if !errors.Is(err, failure) {
+		t.Errorf("expected the error to be returned, got %v", err)
+	}
+}
@@ -0,0 +1,36 @@
+package utils
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+)
+
+// DeletionProtectionAttribute returns the deletion_protection attribute for resources
+// that refuse to be deleted while the flag is set.
+func DeletionProtectionAttribute(resourceType string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Optional: true,
+		Computed: true,
+		Default:  booldefault.StaticBool(false),
+		Description: fmt.Sprintf(
+			"Prevent the %s from being deleted or replaced by Terraform. Set to false and apply before destroying the %s. Defaults to false.",
+			resourceType,
+			resourceType,
+		),
+	}
+}
+
+// DeletionProtectionError returns the error for a delete blocked by deletion_protection.
+func DeletionProtectionError(resourceType, id string) diag.Diagnostic {
+	return diag.NewErrorDiagnostic(
+		fmt.Sprintf("Unable to delete %s", resourceType),
+		fmt.Sprintf(
+			"The %s %s has deletion_protection enabled. Set deletion_protection to false and apply before deleting or replacing it.",
+			resourceType,
+			id,
+		),
+	)
+}
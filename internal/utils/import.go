@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// importNamePrefix is the import id prefix used to import a resource by name instead of id.
+const importNamePrefix = "name:"
+
+// NameFromImportID returns the name from an import id in the form name:<name>.
+// ok is false when the import id is a plain resource id.
+func NameFromImportID(id string) (name string, ok bool) {
+	name, ok = strings.CutPrefix(id, importNamePrefix)
+	if !ok || name == "" {
+		return "", false
+	}
+
+	return name, true
+}
+
+// QuoteFQL quotes value as an FQL string literal, escaping backslashes and single quotes.
+func QuoteFQL(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `'`, `\'`)
+	return "'" + value + "'"
+}
+
+// IDFromNameLookup returns the only id in ids, the result of looking up a resource by name.
+// An error is returned when no resource or more than one resource has the name, since it
+// is not possible to know which resource the import is meant for.
+func IDFromNameLookup(resourceType, name string, ids []string) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	switch len(ids) {
+	case 1:
+		return ids[0], diags
+	case 0:
+		diags.AddError(
+			fmt.Sprintf("Unable to import %s", resourceType),
+			fmt.Sprintf("No %s named %q was found.", resourceType, name),
+		)
+	default:
+		diags.AddError(
+			fmt.Sprintf("Unable to import %s", resourceType),
+			fmt.Sprintf(
+				"More than one %s is named %q: %s\n\nImport the %s by id instead.",
+				resourceType,
+				name,
+				strings.Join(ids, ", "),
+				resourceType,
+			),
+		)
+	}
+
+	return "", diags
+}
@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"testing"
+)
+
+func TestNameFromImportID(t *testing.T) {
+	tests := []struct {
+		id       string
+		expected string
+		ok       bool
+	}{
+		{id: "7fb858a949034a0cbca175f660f1e769", expected: "", ok: false},
+		{id: "name:my policy", expected: "my policy", ok: true},
+		{id: "name:name:nested", expected: "name:nested", ok: true},
+		{id: "name:", expected: "", ok: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.id, func(t *testing.T) {
+			name, ok := NameFromImportID(tc.id)
+			if name != tc.expected || ok != tc.ok {
+				t.Errorf("NameFromImportID(%q) = %q, %t; want %q, %t", tc.id, name, ok, tc.expected, tc.ok)
+			}
+		})
+	}
+}
+
+func TestQuoteFQL(t *testing.T) {
+	tests := map[string]string{
+		"simple":     "'simple'",
+		"it's":       `'it\'s'`,
+		`back\slash`: `'back\\slash'`,
+		`trailing\'`: `'trailing\\\''`,
+	}
+
+	for value, expected := range tests {
+		if got := QuoteFQL(value); got != expected {
+			t.Errorf("QuoteFQL(%q) = %s; want %s", value, got, expected)
+		}
+	}
+}
+
+func TestIDFromNameLookup(t *testing.T) {
+	id, diags := IDFromNameLookup("host group", "example", []string{"a"})
+	if diags.HasError() || id != "a" {
+		t.Errorf("expected id a without errors, got %q %v", id, diags)
+	}
+
+	for _, ids := range [][]string{nil, {"a", "b"}} {
+		_, diags = IDFromNameLookup("host group", "example", ids)
+		if !diags.HasError() {
+			t.Errorf("expected an error for ids %v", ids)
+		}
+	}
+}
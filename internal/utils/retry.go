@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/tferrors"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// conflictRetryAttempts is the number of times a create is tried while the api returns 409.
+const conflictRetryAttempts = 5
+
+// conflictRetryDelay is the wait before the first retry of a conflicting create, each
+// following retry waits twice as long.
+var conflictRetryDelay = 2 * time.Second
+
+// RetryOnConflict calls create until it succeeds, fails with an error other than 409
+// Conflict, or has been tried conflictRetryAttempts times, and returns the last error.
+// The api returns transient conflicts while an object with the same name is being created
+// or deleted. Only use it for creates that the api rejects as a whole on conflict, so a
+// retry can not create a duplicate.
+func RetryOnConflict(ctx context.Context, create func() error) error {
+	delay := conflictRetryDelay
+
+	for attempt := 1; ; attempt++ {
+		err := create()
+		if err == nil || attempt == conflictRetryAttempts || tferrors.StatusCode(err) != http.StatusConflict {
+			return err
+		}
+
+		tflog.Debug(ctx, "Create conflicted with a concurrent change, retrying", map[string]any{
+			"attempt": attempt,
+			"delay":   delay.String(),
+		})
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+	}
+}
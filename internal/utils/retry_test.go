@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// statusError is an api error with a status code, like the errors returned by gofalcon.
+type statusError int
+
+func (e statusError) Error() string { return fmt.Sprintf("[POST /test/entities/v1][%d] test", int(e)) }
+
+func (e statusError) Code() int { return int(e) }
+
+func TestRetryOnConflict(t *testing.T) {
+	delay := conflictRetryDelay
+	conflictRetryDelay = time.Millisecond
+	t.Cleanup(func() { conflictRetryDelay = delay })
+
+	tests := []struct {
+		name      string
+		errs      []error
+		wantCalls int
+		wantErr   bool
+	}{
+		{
+			name:      "success",
+			errs:      []error{nil},
+			wantCalls: 1,
+		},
+		{
+			name:      "conflict then success",
+			errs:      []error{statusError(http.StatusConflict), statusError(http.StatusConflict), nil},
+			wantCalls: 3,
+		},
+		{
+			name:      "other errors are not retried",
+			errs:      []error{statusError(http.StatusBadRequest)},
+			wantCalls: 1,
+			wantErr:   true,
+		},
+		{
+			name: "gives up after the last attempt",
+			errs: []error{
+				statusError(http.StatusConflict),
+				statusError(http.StatusConflict),
+				statusError(http.StatusConflict),
+				statusError(http.StatusConflict),
+				statusError(http.StatusConflict),
+				nil,
+			},
+			wantCalls: conflictRetryAttempts,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			err := RetryOnConflict(context.Background(), func() error {
+				err := tt.errs[calls]
+				calls++
+				return err
+			})
+
+			if calls != tt.wantCalls {
+				t.Errorf("expected %d calls, got %d", tt.wantCalls, calls)
+			}
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	// Embed the timezone database so timezones validate on hosts without one.
+	_ "time/tzdata"
+)
+
+// scheduleDateLayout is the mm-dd-yyyy layout used by schedule start and end dates.
+const scheduleDateLayout = "01-02-2006"
+
+// cronField is the allowed range and names of a field in a cron expression.
+type cronField struct {
+	name     string
+	min, max int
+	names    []string
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{
+		name: "month", min: 1, max: 12,
+		names: []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"},
+	},
+	{
+		name: "day of week", min: 0, max: 7,
+		names: []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"},
+	},
+}
+
+// ValidateCron returns an error describing the first problem with a five field cron
+// expression (minute, hour, day of month, month, day of week).
+func ValidateCron(expr string) error {
+	fields := strings.Fields(expr)
+	if len(fields) != len(cronFields) {
+		return fmt.Errorf(
+			"expected 5 fields (minute hour day-of-month month day-of-week), got %d in %q",
+			len(fields),
+			expr,
+		)
+	}
+
+	for i, value := range fields {
+		field := cronFields[i]
+		for _, part := range strings.Split(value, ",") {
+			if err := field.validate(part); err != nil {
+				return fmt.Errorf("invalid %s %q: %w", field.name, value, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validate checks a single list element of the field: *, a value, or a range, each
+// optionally followed by a /step.
+func (f cronField) validate(part string) error {
+	rangePart, step, hasStep := strings.Cut(part, "/")
+	if hasStep {
+		n, err := strconv.Atoi(step)
+		if err != nil || n < 1 {
+			return fmt.Errorf("step %q must be a positive number", step)
+		}
+	}
+
+	if rangePart == "*" {
+		return nil
+	}
+
+	low, high, isRange := strings.Cut(rangePart, "-")
+
+	start, err := f.value(low)
+	if err != nil {
+		return err
+	}
+
+	if !isRange {
+		return nil
+	}
+
+	end, err := f.value(high)
+	if err != nil {
+		return err
+	}
+
+	if start > end {
+		return fmt.Errorf("range %q starts after it ends", rangePart)
+	}
+
+	return nil
+}
+
+// value parses a number or name of the field.
+func (f cronField) value(s string) (int, error) {
+	for i, name := range f.names {
+		if strings.EqualFold(s, name) {
+			return i + f.min, nil
+		}
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a number", s)
+	}
+
+	if n < f.min || n > f.max {
+		return 0, fmt.Errorf("%d is outside %d-%d", n, f.min, f.max)
+	}
+
+	return n, nil
+}
+
+// ValidateTimezone returns an error when name is not a timezone in the IANA database.
+func ValidateTimezone(name string) error {
+	// time.LoadLocation treats an empty name and Local as the host timezone.
+	if name == "" || name == "Local" {
+		return fmt.Errorf("%q is not an IANA timezone, use a name such as America/Los_Angeles", name)
+	}
+
+	if _, err := time.LoadLocation(name); err != nil {
+		return fmt.Errorf("%q is not an IANA timezone, use a name such as America/Los_Angeles", name)
+	}
+
+	return nil
+}
+
+// ParseScheduleDate parses a schedule date in mm-dd-yyyy format.
+func ParseScheduleDate(value string) (time.Time, error) {
+	date, err := time.Parse(scheduleDateLayout, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is not a date in mm-dd-yyyy format", value)
+	}
+
+	return date, nil
+}
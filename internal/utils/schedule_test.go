@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateCron(t *testing.T) {
+	tests := map[string]string{
+		"0 6 * * 1-5":          "",
+		"*/15 * * * *":         "",
+		"30 22 1,15 * *":       "",
+		"0 0 * JAN-MAR mon":    "",
+		"0 9-17/2 * * SUN,SAT": "",
+		"0 0 * * 7":            "",
+		"0 6 * *":              "expected 5 fields",
+		"0 6 * * * *":          "expected 5 fields",
+		"60 * * * *":           "invalid minute",
+		"0 24 * * *":           "invalid hour",
+		"0 0 0 * *":            "invalid day of month",
+		"0 0 * 13 *":           "invalid month",
+		"0 0 * * 8":            "invalid day of week",
+		"0 0 * * FRI-MON":      "starts after it ends",
+		"*/0 * * * *":          "must be a positive number",
+		"@daily":               "expected 5 fields",
+		"a * * * *":            "is not a number",
+	}
+
+	for expr, expected := range tests {
+		t.Run(expr, func(t *testing.T) {
+			err := ValidateCron(expr)
+			if expected == "" {
+				if err != nil {
+					t.Errorf("ValidateCron(%q) returned unexpected error: %s", expr, err)
+				}
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), expected) {
+				t.Errorf("ValidateCron(%q) = %v; want error containing %q", expr, err, expected)
+			}
+		})
+	}
+}
+
+func TestValidateTimezone(t *testing.T) {
+	tests := map[string]bool{
+		"America/Los_Angeles": true,
+		"Etc/UTC":             true,
+		"UTC":                 true,
+		"Local":               false,
+		"":                    false,
+		"Mars/Olympus_Mons":   false,
+		"PST8":                false,
+	}
+
+	for name, valid := range tests {
+		if err := ValidateTimezone(name); (err == nil) != valid {
+			t.Errorf("ValidateTimezone(%q) = %v; want valid %t", name, err, valid)
+		}
+	}
+}
+
+func TestParseScheduleDate(t *testing.T) {
+	if _, err := ParseScheduleDate("01-31-2030"); err != nil {
+		t.Errorf("ParseScheduleDate returned unexpected error: %s", err)
+	}
+
+	for _, value := range []string{"2030-01-31", "31-01-2030", "01/31/2030"} {
+		if _, err := ParseScheduleDate(value); err == nil {
+			t.Errorf("ParseScheduleDate(%q) expected an error", value)
+		}
+	}
+}
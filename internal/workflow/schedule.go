@@ -0,0 +1,516 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/client/workflows"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/scopes"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/tferrors"
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &workflowScheduleResource{}
+	_ resource.ResourceWithConfigure      = &workflowScheduleResource{}
+	_ resource.ResourceWithImportState    = &workflowScheduleResource{}
+	_ resource.ResourceWithIdentity       = &workflowScheduleResource{}
+	_ resource.ResourceWithValidateConfig = &workflowScheduleResource{}
+)
+
+// NewWorkflowScheduleResource is a helper function to simplify the provider implementation.
+func NewWorkflowScheduleResource() resource.Resource {
+	return &workflowScheduleResource{}
+}
+
+// workflowScheduleResource is the resource implementation.
+type workflowScheduleResource struct {
+	client *client.CrowdStrikeAPISpecification
+}
+
+// workflowScheduleResourceModel is the resource model.
+type workflowScheduleResourceModel struct {
+	ID             types.String   `tfsdk:"id"`
+	WorkflowID     types.String   `tfsdk:"workflow_id"`
+	Cron           types.String   `tfsdk:"cron"`
+	Timezone       types.String   `tfsdk:"timezone"`
+	StartDate      types.String   `tfsdk:"start_date"`
+	EndDate        types.String   `tfsdk:"end_date"`
+	SkipConcurrent types.Bool     `tfsdk:"skip_concurrent"`
+	Enabled        types.Bool     `tfsdk:"enabled"`
+	LastUpdated    types.String   `tfsdk:"last_updated"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
+}
+
+// workflowScheduleIdentityModel maps the resource identity schema data. A schedule belongs
+// to a single workflow, so it is identified by the workflow id.
+type workflowScheduleIdentityModel struct {
+	WorkflowID types.String `tfsdk:"workflow_id"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *workflowScheduleResource) Configure(
+	ctx context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.CrowdStrikeAPISpecification)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf(
+				"Expected *client.CrowdStrikeAPISpecification, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Metadata returns the resource type name.
+func (r *workflowScheduleResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_workflow_schedule"
+}
+
+// Schema defines the schema for the resource.
+func (r *workflowScheduleResource) Schema(
+	ctx context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: fmt.Sprintf(
+			"Workflow --- This resource manages the schedule of an existing scheduled Fusion workflow, the rest of the workflow definition is left as it is. Destroying the resource leaves the schedule of the workflow unchanged.\n\n%s",
+			scopes.GenerateScopeDescription(apiScopes),
+		),
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Identifier for the workflow schedule, the same as workflow_id.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"workflow_id": schema.StringAttribute{
+				Required:    true,
+				Description: "Identifier of the workflow definition. The workflow must use a schedule trigger.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"cron": schema.StringAttribute{
+				Required:    true,
+				Description: "Cron expression the workflow runs on, e.g. 0 6 * * 1-5.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"timezone": schema.StringAttribute{
+				Required:    true,
+				Description: "IANA timezone the cron expression is evaluated in, e.g. America/Los_Angeles.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"start_date": schema.StringAttribute{
+				Optional:    true,
+				Description: "Date the schedule starts, in mm-dd-yyyy format.",
+			},
+			"end_date": schema.StringAttribute{
+				Optional:    true,
+				Description: "Date the schedule ends, in mm-dd-yyyy format.",
+			},
+			"skip_concurrent": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Skip a scheduled run while the previous run is still executing. Defaults to true.",
+				Default:     booldefault.StaticBool(true),
+			},
+			"enabled": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Enable the workflow so it runs on the schedule. Defaults to true.",
+				Default:     booldefault.StaticBool(true),
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:    true,
+				Description: "Timestamp of the last Terraform update of the resource.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *workflowScheduleResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan workflowScheduleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(r.updateSchedule(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(setScheduleIdentity(ctx, resp.Identity, plan.WorkflowID)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *workflowScheduleResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state workflowScheduleResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	workflow, diags := r.getWorkflow(ctx, state.WorkflowID.ValueString())
+	if tferrors.HasNotFoundError(diags) {
+		tflog.Warn(
+			ctx,
+			"Workflow not found, removing schedule from state",
+			map[string]interface{}{"id": state.WorkflowID.ValueString()},
+		)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	schedule, diags := scheduleOf(workflow)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.ID = types.StringPointerValue(workflow.ID)
+	state.WorkflowID = types.StringPointerValue(workflow.ID)
+	state.Cron = types.StringPointerValue(schedule.TimeCycle)
+	state.Timezone = types.StringPointerValue(schedule.Tz)
+	state.StartDate = optionalString(schedule.StartDate)
+	state.EndDate = optionalString(schedule.EndDate)
+	state.SkipConcurrent = types.BoolPointerValue(schedule.SkipConcurrent)
+	state.Enabled = types.BoolPointerValue(workflow.Enabled)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(setScheduleIdentity(ctx, resp.Identity, state.WorkflowID)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *workflowScheduleResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var plan workflowScheduleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(r.updateSchedule(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(setScheduleIdentity(ctx, resp.Identity, plan.WorkflowID)...)
+}
+
+// Delete removes the resource from the Terraform state, a scheduled workflow always has a
+// schedule so it is left unchanged.
+func (r *workflowScheduleResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+}
+
+// IdentitySchema defines the identity schema for the resource.
+func (r *workflowScheduleResource) IdentitySchema(
+	_ context.Context,
+	_ resource.IdentitySchemaRequest,
+	resp *resource.IdentitySchemaResponse,
+) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"workflow_id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "The id of the scheduled workflow.",
+			},
+		},
+	}
+}
+
+// ImportState implements the logic to support resource imports.
+func (r *workflowScheduleResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	// Retrieve import ID or identity and save to workflow_id attribute
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("workflow_id"), path.Root("workflow_id"), req, resp)
+}
+
+// setScheduleIdentity stores workflowID as the identity of the resource.
+func setScheduleIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, workflowID types.String) diag.Diagnostics {
+	if identity == nil {
+		return nil
+	}
+
+	return identity.Set(ctx, workflowScheduleIdentityModel{WorkflowID: workflowID})
+}
+
+// ValidateConfig runs during validate, plan, and apply
+// validate resource is configured as expected.
+func (r *workflowScheduleResource) ValidateConfig(
+	ctx context.Context,
+	req resource.ValidateConfigRequest,
+	resp *resource.ValidateConfigResponse,
+) {
+	var config workflowScheduleResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Cron.IsNull() && !config.Cron.IsUnknown() {
+		if err := utils.ValidateCron(config.Cron.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("cron"), "Invalid cron expression", err.Error())
+		}
+	}
+
+	if !config.Timezone.IsNull() && !config.Timezone.IsUnknown() {
+		if err := utils.ValidateTimezone(config.Timezone.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("timezone"), "Invalid timezone", err.Error())
+		}
+	}
+
+	var startDate, endDate time.Time
+
+	if !config.StartDate.IsNull() && !config.StartDate.IsUnknown() {
+		date, err := utils.ParseScheduleDate(config.StartDate.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("start_date"), "Invalid start date", err.Error())
+		}
+		startDate = date
+	}
+
+	if !config.EndDate.IsNull() && !config.EndDate.IsUnknown() {
+		date, err := utils.ParseScheduleDate(config.EndDate.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("end_date"), "Invalid end date", err.Error())
+		}
+		endDate = date
+	}
+
+	if !startDate.IsZero() && !endDate.IsZero() && endDate.Before(startDate) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("end_date"),
+			"Invalid end date",
+			fmt.Sprintf(
+				"end_date %s is before start_date %s.",
+				config.EndDate.ValueString(),
+				config.StartDate.ValueString(),
+			),
+		)
+	}
+}
+
+// updateSchedule replaces the schedule of the workflow with the planned schedule and
+// keeps the rest of the definition. The id and last updated are set on plan.
+func (r *workflowScheduleResource) updateSchedule(
+	ctx context.Context,
+	plan *workflowScheduleResourceModel,
+) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	workflow, getDiags := r.getWorkflow(ctx, plan.WorkflowID.ValueString())
+	diags.Append(getDiags...)
+	if diags.HasError() {
+		return diags
+	}
+
+	schedule, scheduleDiags := scheduleOf(workflow)
+	diags.Append(scheduleDiags...)
+	if diags.HasError() {
+		return diags
+	}
+
+	schedule.TimeCycle = plan.Cron.ValueStringPointer()
+	schedule.Tz = plan.Timezone.ValueStringPointer()
+	schedule.StartDate = plan.StartDate.ValueString()
+	schedule.EndDate = plan.EndDate.ValueString()
+	schedule.SkipConcurrent = plan.SkipConcurrent.ValueBoolPointer()
+
+	_, err := r.client.Workflows.WorkflowDefinitionsUpdate(&workflows.WorkflowDefinitionsUpdateParams{
+		Context: ctx,
+		Body: &models.ModelsDefinitionUpdateRequestV2{
+			ID:         workflow.ID,
+			Definition: workflow.Definition,
+			Enabled:    plan.Enabled.ValueBoolPointer(),
+			ChangeLog:  "Schedule updated by Terraform",
+		},
+	})
+
+	if err != nil {
+		diags.AddError(
+			"Error updating workflow schedule",
+			fmt.Sprintf(
+				"Could not update the schedule of workflow %s: %s",
+				plan.WorkflowID.ValueString(),
+				tferrors.Message(err, apiScopes...),
+			),
+		)
+		return diags
+	}
+
+	plan.ID = plan.WorkflowID
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	return diags
+}
+
+// getWorkflow returns the workflow definition with the given id.
+func (r *workflowScheduleResource) getWorkflow(
+	ctx context.Context,
+	id string,
+) (*models.DefinitionsDefinitionExt, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	filter := "id:" + utils.QuoteFQL(id)
+	limit := int64(1)
+
+	res, err := r.client.Workflows.WorkflowDefinitionsCombined(&workflows.WorkflowDefinitionsCombinedParams{
+		Context: ctx,
+		Filter:  filter,
+		Limit:   &limit,
+	})
+
+	if tferrors.IsNotFound(err) || (err == nil && len(res.Payload.Resources) == 0) {
+		diags.Append(tferrors.NewNotFoundError(
+			"Workflow not found",
+			fmt.Sprintf("No workflow definition with id %s was found.", id),
+		))
+		return nil, diags
+	}
+
+	if err != nil {
+		diags.AddError(
+			"Error reading workflow",
+			fmt.Sprintf("Could not read workflow %s: %s", id, tferrors.Message(err, apiScopes...)),
+		)
+		return nil, diags
+	}
+
+	return res.Payload.Resources[0], diags
+}
+
+// scheduleOf returns the schedule of a workflow, or an error when the workflow is not
+// triggered by a schedule.
+func scheduleOf(workflow *models.DefinitionsDefinitionExt) (*models.GraphTimerEventDefinition, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if workflow.Definition == nil || workflow.Definition.Trigger == nil ||
+		workflow.Definition.Trigger.Schedule == nil {
+		diags.AddAttributeError(
+			path.Root("workflow_id"),
+			"Workflow is not scheduled",
+			fmt.Sprintf(
+				"The workflow %s does not use a schedule trigger, only the schedule of scheduled workflows can be managed.",
+				*workflow.ID,
+			),
+		)
+		return nil, diags
+	}
+
+	return workflow.Definition.Trigger.Schedule, diags
+}
+
+// optionalString returns a null string for an empty value returned by the API.
+func optionalString(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+
+	return types.StringValue(value)
+}
@@ -0,0 +1,158 @@
+package workflow_test
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccWorkflowScheduleResource(t *testing.T) {
+	// workflows cannot be created by the provider, an existing scheduled workflow is used.
+	workflowID := os.Getenv("SCHEDULED_WORKFLOW_ID")
+	if workflowID == "" {
+		t.Skip("SCHEDULED_WORKFLOW_ID must be set to run workflow schedule acceptance tests")
+	}
+
+	resourceName := "crowdstrike_workflow_schedule.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.ProviderConfig + fmt.Sprintf(`
+resource "crowdstrike_workflow_schedule" "test" {
+  workflow_id = "%s"
+  cron        = "0 6 * * 1-5"
+  timezone    = "America/Los_Angeles"
+  enabled     = false
+}
+`, workflowID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", workflowID),
+					resource.TestCheckResourceAttr(resourceName, "cron", "0 6 * * 1-5"),
+					resource.TestCheckResourceAttr(resourceName, "timezone", "America/Los_Angeles"),
+					resource.TestCheckResourceAttr(resourceName, "skip_concurrent", "true"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "false"),
+					resource.TestCheckResourceAttrSet(resourceName, "last_updated"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateId:           workflowID,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			{
+				Config: acctest.ProviderConfig + fmt.Sprintf(`
+resource "crowdstrike_workflow_schedule" "test" {
+  workflow_id     = "%s"
+  cron            = "30 22 * * *"
+  timezone        = "Etc/UTC"
+  start_date      = "01-01-2030"
+  skip_concurrent = false
+  enabled         = false
+}
+`, workflowID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "cron", "30 22 * * *"),
+					resource.TestCheckResourceAttr(resourceName, "timezone", "Etc/UTC"),
+					resource.TestCheckResourceAttr(resourceName, "start_date", "01-01-2030"),
+					resource.TestCheckResourceAttr(resourceName, "skip_concurrent", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccWorkflowScheduleResource_identity(t *testing.T) {
+	workflowID := os.Getenv("SCHEDULED_WORKFLOW_ID")
+	if workflowID == "" {
+		t.Skip("SCHEDULED_WORKFLOW_ID must be set to run workflow schedule acceptance tests")
+	}
+
+	resourceName := "crowdstrike_workflow_schedule.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.ProviderConfig + fmt.Sprintf(`
+resource "crowdstrike_workflow_schedule" "test" {
+  workflow_id = "%s"
+  cron        = "0 6 * * 1-5"
+  timezone    = "America/Los_Angeles"
+  enabled     = false
+}
+`, workflowID),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentityValueMatchesState(
+						resourceName,
+						tfjsonpath.New("workflow_id"),
+					),
+				},
+			},
+			{
+				ResourceName:    resourceName,
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+		},
+	})
+}
+
+func TestAccWorkflowScheduleResource_invalidSchedule(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: acctest.ProviderConfig + `
+resource "crowdstrike_workflow_schedule" "test" {
+  workflow_id = "7fb858a949034a0cbca175f660f1e769"
+  cron        = "0 25 * * *"
+  timezone    = "America/Los_Angeles"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Invalid cron expression"),
+			},
+			{
+				Config: acctest.ProviderConfig + `
+resource "crowdstrike_workflow_schedule" "test" {
+  workflow_id = "7fb858a949034a0cbca175f660f1e769"
+  cron        = "0 6 * * *"
+  timezone    = "Pacific Standard Time"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Invalid timezone"),
+			},
+			{
+				Config: acctest.ProviderConfig + `
+resource "crowdstrike_workflow_schedule" "test" {
+  workflow_id = "7fb858a949034a0cbca175f660f1e769"
+  cron        = "0 6 * * *"
+  timezone    = "Etc/UTC"
+  start_date  = "06-01-2030"
+  end_date    = "01-01-2030"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("is before start_date"),
+			},
+		},
+	})
+}
@@ -0,0 +1,18 @@
+package workflow
+
+import (
+	"time"
+
+	"github.com/crowdstrike/terraform-provider-crowdstrike/internal/scopes"
+)
+
+// defaultTimeout is used for an operation when the timeouts block does not configure one.
+const defaultTimeout = 20 * time.Minute
+
+var apiScopes = []scopes.Scope{
+	{
+		Name:  "Workflow",
+		Read:  true,
+		Write: true,
+	},
+}